@@ -1,10 +1,17 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"io"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
+	// embed the timezone database - the container image does not provide one for the objectives' schedule windows.
+	_ "time/tzdata"
 
 	"github.com/intel/intent-driven-orchestration/pkg/controller"
 
@@ -12,25 +19,41 @@ import (
 	"github.com/intel/intent-driven-orchestration/pkg/planner/actuators"
 	"github.com/intel/intent-driven-orchestration/pkg/planner/astar"
 
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	kubeInformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 
 	genClient "github.com/intel/intent-driven-orchestration/pkg/generated/clientset/versioned"
 	genInformer "github.com/intel/intent-driven-orchestration/pkg/generated/informers/externalversions"
 )
 
+// shutdownTimeout defines how long in-flight plan executions & effect calculations are given to finish on shutdown.
+const shutdownTimeout = 25 * time.Second
+
+// probeTimeout defines how long the readiness probe waits for the Mongo DB to respond.
+const probeTimeout = 2 * time.Second
+
 var (
-	kubeConfig string
-	config     string
+	kubeConfig     string
+	config         string
+	webhook        bool
+	webhookAddress string
+	tlsCertFile    string
+	tlsKeyFile     string
 )
 
 func main() {
 	klog.InitFlags(nil)
 	klog.Info("Hello from your friendly autonomous planning component...")
 
-	stopper := make(chan struct{})
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	stopper := ctx.Done()
 
 	// config stuff.
 	flag.Parse()
@@ -68,8 +91,15 @@ func main() {
 		klog.Infof("Successfuly added to klog output the log file: %s", cfg.Generic.LogFile)
 	}
 
+	// additional profile types.
+	for _, item := range cfg.Monitor.Profile.Types {
+		if _, err = common.RegisterProfileType(item.Name, item.Minimize); err != nil {
+			klog.Fatalf("Error registering profile type: %v", err)
+		}
+	}
+
 	// K8s genClient setup
-	k8sClient, err := kubernetes.NewForConfig(k8sConfig)
+	k8sClient, err := controller.NewTargetClientForConfig(k8sConfig)
 	if err != nil {
 		klog.Fatalf("Error getting k8s-client config: %s", err)
 	}
@@ -79,56 +109,213 @@ func main() {
 	if err != nil {
 		klog.Fatalf("Error getting gen-client config: %s", err)
 	}
+	// in webhook mode we only validate Intents & KPIProfiles.
+	if webhook {
+		runWebhook(cfg, k8sClient, crdClient, stopper)
+		return
+	}
+
 	informerFactory := genInformer.NewSharedInformerFactory(crdClient, time.Second*time.Duration(cfg.Controller.InformerTimeout))
 
-	// The planning algorithm.
-	// TODO: make all of this more configurable.
-	var actuatorList []actuators.Actuator
-	// UNCOMMENT to add your local actuators.
-	// tracer := controller.NewMongoTracer(cfg.Generic.MongoEndpoint)
-	// actuatorList = append(actuatorList, scaling.NewScaleOutActuator(k8sClient, tracer))
-	// actuatorList = append(actuatorList, scaling.NewRmPodActuator(k8sClient, tracer))
-	// actuatorList = append(actuatorList, platform.NewRdtActuator(k8sClient, tracer))
-	planner := astar.NewAPlanner(actuatorList, cfg)
-	defer planner.Stop()
+	// events on Intents & KPIProfiles.
+	recorder, stopRecorder := controller.NewEventRecorder(k8sClient, "planner")
+	defer stopRecorder()
 
 	// This is the main controller.
 	tracer := controller.NewMongoTracer(cfg.Generic.MongoEndpoint)
-	c := controller.NewController(cfg, tracer, k8sClient, podInformerFactory.Core().V1().Pods())
-	c.SetPlanner(planner)
+	c := controller.NewController(cfg, tracer, k8sClient, crdClient, podInformerFactory.Core().V1().Pods(), podInformerFactory.Core().V1().Nodes())
+	c.SetEventRecorder(recorder)
 
-	// 1/3 bring up the monitor for the KPIProfiles.
+	// 1/4 bring up the monitor for the KPIProfiles.
 	profileMonitor := controller.NewKPIProfileMonitor(
 		cfg.Monitor,
 		crdClient,
 		informerFactory.Ido().V1alpha1().KPIProfiles(),
 		c.UpdateProfile())
+	profileMonitor.SetEventRecorder(recorder)
+	watchDefaultProfiles(cfg, k8sClient, profileMonitor.DefaultProfiles(), stopper)
 	go profileMonitor.Run(cfg.Monitor.Profile.Workers, stopper)
 
-	// 2/3 bring up the monitor for the intents.
+	// 2/4 bring up the monitor for the intents.
 	intentMonitor := controller.NewIntentMonitor(
+		cfg.Monitor,
 		crdClient,
+		k8sClient,
 		informerFactory.Ido().V1alpha1().Intents(),
 		c.UpdateIntent())
 	go intentMonitor.Run(cfg.Monitor.Intent.Workers, stopper)
 
-	// 3/3 bring up the monitor for the PODs.
+	// 3/4 bring up the monitor for the intent groups.
+	groupMonitor := controller.NewIntentGroupMonitor(
+		informerFactory.Ido().V1alpha1().IntentGroups(),
+		c.UpdateIntent())
+	go groupMonitor.Run(cfg.Monitor.Intent.Workers, stopper)
+
+	// 4/4 bring up the monitor for the PODs.
 	podMonitor := controller.NewPodMonitor(
 		k8sClient,
 		podInformerFactory.Core().V1().Pods(),
 		c.UpdatePodError())
 	go podMonitor.Run(cfg.Monitor.Pod.Workers, stopper)
 
-	// run the actual overall logic.
-	c.Run(cfg.Controller.Workers, stopper)
 	informerFactory.Start(stopper)
 	podInformerFactory.Start(stopper)
 
-	// TODO: implement proper stop signal handler.
-	<-stopper
+	// health & readiness - standby replicas do not become ready, as they do not run the plugin manager.
+	if cfg.Generic.Probes.Address != "" {
+		probes := common.NewProbes()
+		probes.AddReadinessCheck("informers", informersSynced(
+			informerFactory.Ido().V1alpha1().KPIProfiles().Informer().HasSynced,
+			informerFactory.Ido().V1alpha1().Intents().Informer().HasSynced,
+			informerFactory.Ido().V1alpha1().IntentGroups().Informer().HasSynced,
+			podInformerFactory.Core().V1().Pods().Informer().HasSynced,
+			podInformerFactory.Core().V1().Nodes().Informer().HasSynced))
+		probes.AddReadinessCheck("plugin-manager", c.PlannerReady)
+		probes.AddReadinessCheck("tracer", func() error {
+			pingCtx, cancel := context.WithTimeout(ctx, probeTimeout)
+			defer cancel()
+			return tracer.Ping(pingCtx)
+		})
+		probes.AddReadinessCheck("warmup", c.WarmedUp)
+		go func() {
+			if err := common.ServeProbes(cfg.Generic.Probes.Address, probes, stopper); err != nil {
+				klog.Fatalf("Error running probes server: %v", err)
+			}
+		}()
+	}
+
+	// metrics on the internals - exposed by all replicas.
+	if cfg.Generic.Metrics.Address != "" {
+		go func() {
+			if err := common.ServeMetrics(cfg.Generic.Metrics.Address, stopper); err != nil {
+				klog.Fatalf("Error running metrics server: %v", err)
+			}
+		}()
+	}
+
+	// run the actual overall logic - with multiple replicas only the leader plans; the others keep their state warm.
+	if cfg.Generic.LeaderElection.Enabled {
+		runElection(ctx, cfg, k8sClient, c, recorder)
+	} else {
+		runPlanner(ctx, cfg, c, recorder)
+	}
+	if err = tracer.Close(context.Background()); err != nil {
+		klog.Errorf("Error closing the tracer: %v", err)
+	}
+	klog.Info("Shutdown complete.")
+}
+
+// informersSynced returns a check which fails until the caches of all given informers are synced.
+func informersSynced(synced ...cache.InformerSynced) common.Check {
+	return func() error {
+		for _, item := range synced {
+			if !item() {
+				return errors.New("caches not synced yet")
+			}
+		}
+		return nil
+	}
+}
+
+// runElection runs the planner while this replica is the leader. The lease is only released after the planner has
+// shut down - so a new leader does not start planning while plans are still being executed.
+func runElection(ctx context.Context, cfg common.Config, k8sClient kubernetes.Interface, c *controller.IntentController, recorder record.EventRecorder) {
+	electionCtx, cancelElection := context.WithCancel(context.Background())
+	defer cancelElection()
+	done := make(chan struct{})
+	elector, err := controller.NewLeaderElector(cfg.Generic.LeaderElection, k8sClient, controller.LeaderIdentity(),
+		func(leaderCtx context.Context) {
+			defer close(done)
+			plannerCtx, cancel := context.WithCancel(leaderCtx)
+			defer cancel()
+			stopAfter := context.AfterFunc(ctx, cancel)
+			defer stopAfter()
+			runPlanner(plannerCtx, cfg, c, recorder)
+		},
+		func() {
+			if ctx.Err() == nil {
+				// plans might still be executed; restart as a standby replica.
+				klog.Fatal("Lost leadership - exiting.")
+			}
+		})
+	if err != nil {
+		klog.Fatalf("Error setting up leader election: %v", err)
+	}
+	go func() {
+		<-ctx.Done()
+		if elector.IsLeader() {
+			<-done
+		}
+		cancelElection()
+	}()
+	elector.Run(electionCtx)
+}
+
+// runPlanner sets up the planning algorithm - incl. the plugin manager actuators register with - and runs the
+// controller until the context is done.
+func runPlanner(ctx context.Context, cfg common.Config, c *controller.IntentController, recorder record.EventRecorder) {
+	// The planning algorithm.
+	// TODO: make all of this more configurable.
+	var actuatorList []actuators.Actuator
+	// UNCOMMENT to add your local actuators.
+	// tracer := controller.NewMongoTracer(cfg.Generic.MongoEndpoint)
+	// actuatorList = append(actuatorList, scaling.NewScaleOutActuator(k8sClient, tracer))
+	// actuatorList = append(actuatorList, scaling.NewRmPodActuator(k8sClient, tracer))
+	// actuatorList = append(actuatorList, platform.NewRdtActuator(k8sClient, tracer))
+	planner := astar.NewAPlanner(actuatorList, cfg)
+	defer planner.Stop()
+	planner.SetEventRecorder(recorder)
+	c.SetPlanner(planner)
+
+	c.Run(cfg.Controller.Workers, ctx.Done())
+	<-ctx.Done()
+
+	// stop planning & wait for what is in-flight; the plugin manager is stopped afterward.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := c.Shutdown(shutdownCtx); err != nil {
+		klog.Errorf("Error shutting down the controller: %v", err)
+	}
+}
+
+// runWebhook runs the validating admission webhook server.
+func runWebhook(cfg common.Config, k8sClient kubernetes.Interface, crdClient genClient.Interface, stopper <-chan struct{}) {
+	wh, err := controller.NewAdmissionWebhook(cfg.Monitor, crdClient)
+	if err != nil {
+		klog.Fatalf("Error setting up webhook: %v", err)
+	}
+	watchDefaultProfiles(cfg, k8sClient, wh.DefaultProfiles(), stopper)
+	keyPair, err := controller.NewKeyPairReloader(tlsCertFile, tlsKeyFile)
+	if err != nil {
+		klog.Fatalf("Error loading TLS key pair: %v", err)
+	}
+	if err = wh.Run(webhookAddress, keyPair, stopper); err != nil {
+		klog.Fatalf("Error running webhook server: %v", err)
+	}
+}
+
+// watchDefaultProfiles watches the ConfigMap with the default profile definitions - if one is configured.
+func watchDefaultProfiles(cfg common.Config, k8sClient kubernetes.Interface, defaults *controller.DefaultProfiles, stopper <-chan struct{}) {
+	if cfg.Monitor.Profile.ConfigMap == "" {
+		return
+	}
+	namespace, name, _ := strings.Cut(cfg.Monitor.Profile.ConfigMap, "/")
+	factory := kubeInformers.NewSharedInformerFactoryWithOptions(
+		k8sClient,
+		time.Second*time.Duration(cfg.Controller.InformerTimeout),
+		kubeInformers.WithNamespace(namespace),
+		kubeInformers.WithTweakListOptions(func(options *metaV1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+		}))
+	defaults.WatchConfigMap(factory.Core().V1().ConfigMaps(), namespace, name)
+	factory.Start(stopper)
 }
 
 func init() {
 	flag.StringVar(&kubeConfig, "kubeConfig", "", "Path to a kube config file.")
 	flag.StringVar(&config, "config", "", "Path to configuration file.")
+	flag.BoolVar(&webhook, "webhook", false, "Run the validating admission webhook server instead of the planner.")
+	flag.StringVar(&webhookAddress, "webhookAddress", ":8443", "Address the webhook server listens on.")
+	flag.StringVar(&tlsCertFile, "tlsCertFile", "/certs/tls.crt", "Path to the TLS certificate for the webhook server.")
+	flag.StringVar(&tlsKeyFile, "tlsKeyFile", "/certs/tls.key", "Path to the TLS key for the webhook server.")
 }
@@ -33,6 +33,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Intent{},
 		&IntentList{},
+		&IntentGroup{},
+		&IntentGroupList{},
 		&KPIProfile{},
 		&KPIProfileList{},
 	)
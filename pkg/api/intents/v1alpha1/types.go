@@ -14,8 +14,8 @@ type Intent struct {
 	metaV1.TypeMeta   `json:",inline"`
 	metaV1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec IntentSpec `json:"spec"`
-	// TODO: add status object.
+	Spec   IntentSpec   `json:"spec"`
+	Status IntentStatus `json:"status,omitempty"`
 }
 
 // IntentSpec represent the actual Intent spec.
@@ -24,12 +24,32 @@ type IntentSpec struct {
 	Priority        float64           `json:"priority"`
 	ActivelyManaged bool              `json:"active"`
 	Objectives      []TargetObjective `json:"objectives"`
+	// Budget optionally limits the resources the planner can assign to the workload.
+	Budget *Budget `json:"budget,omitempty"`
+	// AllowedActuators optionally restricts the actuators - by name or group - the planner can use for the workload.
+	AllowedActuators []string `json:"allowedActuators,omitempty"`
+	// DeniedActuators lists the actuators - by name or group - the planner must not use for the workload.
+	DeniedActuators []string `json:"deniedActuators,omitempty"`
+}
+
+// Budget represent the limits on the resources of a workload; unset values mean there is no limit.
+type Budget struct {
+	MinReplicas int32 `json:"minReplicas,omitempty"`
+	MaxReplicas int32 `json:"maxReplicas,omitempty"`
+	// MaxContainerCPU is the max CPU request of a single container in millicores.
+	MaxContainerCPU int64 `json:"maxContainerCPU,omitempty"`
+	// MaxTotalCPU is the max sum of the CPU requests of all containers of all replicas in millicores.
+	MaxTotalCPU int64 `json:"maxTotalCPU,omitempty"`
 }
 
 // TargetRef represent the data needed to find the related object.
 type TargetRef struct {
 	Kind string `json:"kind"`
-	Name string `json:"name"`
+	Name string `json:"name,omitempty"`
+	// Selector selects all workload resources of the given kind with matching labels - instead of a single one by name.
+	Selector *metaV1.LabelSelector `json:"selector,omitempty"`
+	// NamespaceSelector selects the namespaces to look for workload resources in; defaults to the namespace of the intent.
+	NamespaceSelector *metaV1.LabelSelector `json:"namespaceSelector,omitempty"`
 }
 
 // TargetObjective represent the actual objective.
@@ -38,6 +58,96 @@ type TargetObjective struct {
 	Value      float64 `json:"value"`
 	MeasuredBy string  `json:"measuredBy"`
 	Tolerance  float64 `json:"tolerance"`
+	// Weight of this objective when comparing how close states are to the desired state; defaults to 1.0.
+	Weight float64 `json:"weight,omitempty"`
+	// Normalization defines how the deviation from the target value is normalized - either "none" or "target".
+	Normalization string `json:"normalization,omitempty"`
+	// Schedule defines time windows with different target values; the first matching window applies - otherwise the value.
+	Schedule []ScheduleWindow `json:"schedule,omitempty"`
+}
+
+// ScheduleWindow represent a recurring time window with its own target value.
+type ScheduleWindow struct {
+	Name string `json:"name"`
+	// Days the window starts on - e.g. "Mon"; defaults to all days.
+	Days []string `json:"days,omitempty"`
+	// Start and End of the window in the format "HH:MM"; windows ending before they start span midnight.
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// TimeZone the window is defined in - e.g. "Europe/Berlin"; defaults to UTC.
+	TimeZone string  `json:"timeZone,omitempty"`
+	Value    float64 `json:"value"`
+}
+
+// Condition types used in the status of an Intent.
+const (
+	// ConditionCompliant indicates if all objectives are currently met - within their tolerances.
+	ConditionCompliant = "Compliant"
+	// ConditionPlanning indicates if the planner came up with a plan for the last observed state.
+	ConditionPlanning = "Planning"
+	// ConditionDegraded indicates if the planner cannot help to get the intent back to being compliant.
+	ConditionDegraded = "Degraded"
+	// ConditionProfileMissing indicates if objectives reference KPI profiles which are not known to the planner.
+	ConditionProfileMissing = "ProfileMissing"
+)
+
+// IntentStatus represent the status object.
+type IntentStatus struct {
+	Objectives   []ObjectiveStatus  `json:"objectives,omitempty"`
+	LastPlan     []PlannedAction    `json:"lastPlan,omitempty"`
+	LastPlanTime *metaV1.Time       `json:"lastPlanTime,omitempty"`
+	ShadowPlan   []ShadowPatch      `json:"shadowPlan,omitempty"`
+	Cooldowns    []CooldownStatus   `json:"cooldowns,omitempty"`
+	Conditions   []metaV1.Condition `json:"conditions,omitempty"`
+	Workloads    []WorkloadStatus   `json:"workloads,omitempty"`
+}
+
+// WorkloadStatus represent the status for one of the workload resources selected by an intent.
+type WorkloadStatus struct {
+	Name         string             `json:"name"`
+	Objectives   []ObjectiveStatus  `json:"objectives,omitempty"`
+	LastPlan     []PlannedAction    `json:"lastPlan,omitempty"`
+	LastPlanTime *metaV1.Time       `json:"lastPlanTime,omitempty"`
+	Cooldowns    []CooldownStatus   `json:"cooldowns,omitempty"`
+	Conditions   []metaV1.Condition `json:"conditions,omitempty"`
+}
+
+// ObjectiveStatus represent the last observed value for an objective.
+type ObjectiveStatus struct {
+	Name       string  `json:"name"`
+	MeasuredBy string  `json:"measuredBy"`
+	Value      float64 `json:"value"`
+	Target     float64 `json:"target"`
+	Tolerance  float64 `json:"tolerance"`
+	Compliant  bool    `json:"compliant"`
+	// ActiveWindow is the name of the schedule window the target value is taken from.
+	ActiveWindow string `json:"activeWindow,omitempty"`
+}
+
+// PlannedAction represent an action of the last plan.
+type PlannedAction struct {
+	Name       string            `json:"name"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// CooldownStatus represent an actuator which is not used for now as it recently changed the target workload.
+type CooldownStatus struct {
+	Actuator string `json:"actuator"`
+	// Until is not set while the cooldown waits for the change to be rolled out.
+	Until *metaV1.Time `json:"until,omitempty"`
+}
+
+// ShadowPatch represent a change the last plan would have applied to an object - if the intent is handled in shadow
+// mode.
+type ShadowPatch struct {
+	Actuator    string   `json:"actuator"`
+	Kind        string   `json:"kind"`
+	Namespace   string   `json:"namespace"`
+	Name        string   `json:"name"`
+	Subresource string   `json:"subresource,omitempty"`
+	Type        string   `json:"type"`
+	Patch       string   `json:"patch,omitempty"`
+	Diff        []string `json:"diff,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -50,6 +160,62 @@ type IntentList struct {
 	Items []Intent `json:"items"`
 }
 
+// IntentGroup
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IntentGroup kind definition.
+type IntentGroup struct {
+	metaV1.TypeMeta   `json:",inline"`
+	metaV1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IntentGroupSpec   `json:"spec"`
+	Status IntentGroupStatus `json:"status,omitempty"`
+}
+
+// IntentGroupSpec represent the actual IntentGroup spec.
+type IntentGroupSpec struct {
+	Priority        float64 `json:"priority"`
+	ActivelyManaged bool    `json:"active"`
+	// Objective is the end-to-end objective; it is measured for each member, and the values are combined using the aggregation.
+	Objective TargetObjective `json:"objective"`
+	// Aggregation defines how the values of the members combine into the end-to-end value - either "sum", "max", "min"
+	// or "product"; defaults to "sum".
+	Aggregation string        `json:"aggregation,omitempty"`
+	Members     []GroupMember `json:"members"`
+}
+
+// GroupMember represent one of the workload resources of an IntentGroup.
+type GroupMember struct {
+	TargetRef TargetRef `json:"targetRef"`
+	// MeasuredBy optionally overrides the KPI profile used to measure the member's contribution to the objective.
+	MeasuredBy string `json:"measuredBy,omitempty"`
+	// Share of the end-to-end objective assigned to this member; members without a share split the remainder evenly.
+	Share float64 `json:"share,omitempty"`
+	// Budget optionally limits the resources the planner can assign to the member.
+	Budget *Budget `json:"budget,omitempty"`
+}
+
+// IntentGroupStatus represent the status object.
+type IntentGroupStatus struct {
+	// Objective holds the end-to-end value combined from the values observed for the members.
+	Objective  *ObjectiveStatus   `json:"objective,omitempty"`
+	Bottleneck string             `json:"bottleneck,omitempty"`
+	Members    []WorkloadStatus   `json:"members,omitempty"`
+	Conditions []metaV1.Condition `json:"conditions,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IntentGroupList is a list of IntentGroup resources.
+type IntentGroupList struct {
+	metaV1.TypeMeta `json:",inline"`
+	metaV1.ListMeta `json:"metadata"`
+
+	Items []IntentGroup `json:"items"`
+}
+
 // KPIProfile
 
 // +genclient
@@ -66,11 +232,14 @@ type KPIProfile struct {
 
 // KPIProfileSpec represent the actual KPIProfile spec.
 type KPIProfileSpec struct {
-	Query       string            `json:"query"`
-	Description string            `json:"description"`
-	Minimize    bool              `json:"minimize"`
-	KPIType     string            `json:"type"`
-	Props       map[string]string `json:"props"`
+	Query       string `json:"query"`
+	Description string `json:"description"`
+	Minimize    bool   `json:"minimize"`
+	// TypeDirection indicates that the KPI is optimized in the direction of its type - instead of the one defined by
+	// Minimize.
+	TypeDirection bool              `json:"typeDirection,omitempty"`
+	KPIType       string            `json:"type"`
+	Props         map[string]string `json:"props"`
 }
 
 // KPIProfileStatus represent the status object.
@@ -9,8 +9,10 @@ import (
 	protobufs "github.com/intel/intent-driven-orchestration/pkg/api/plugins/v1alpha1/protobufs"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	"k8s.io/klog/v2"
 
@@ -65,6 +67,9 @@ func toPInfo(p *protobufs.PluginInfo) PInfo {
 		Name:     p.Name,
 		Endpoint: p.Endpoint,
 		Version:  p.SupportedVersions,
+		Group:    p.Group,
+		// copy so the plugin info does not refer to the grpc message.
+		ProfileTypes: append([]string(nil), p.ProfileTypes...),
 	}
 }
 
@@ -72,11 +77,15 @@ func toPInfo(p *protobufs.PluginInfo) PInfo {
 func toGrpcState(s *common.State) *protobufs.State {
 	gs := protobufs.State{
 		Intent: &protobufs.Intent{
-			Key:        s.Intent.Key,
-			Priority:   s.Intent.Priority,
-			TargetKey:  s.Intent.TargetKey,
-			TargetKind: s.Intent.TargetKind,
-			Objectives: s.Intent.Objectives,
+			Key:         s.Intent.Key,
+			ParentKey:   s.Intent.ParentKey,
+			ParentKind:  s.Intent.ParentKind,
+			Uid:         s.Intent.UID,
+			Priority:    s.Intent.Priority,
+			TargetKey:   s.Intent.TargetKey,
+			TargetKind:  s.Intent.TargetKind,
+			Objectives:  s.Intent.Objectives,
+			Tolerations: s.Intent.Tolerations,
 		},
 		CurrentPods: make(map[string]*protobufs.PodState),
 		CurrentData: make(map[string]*protobufs.DataEntry),
@@ -102,9 +111,28 @@ func toGrpcState(s *common.State) *protobufs.State {
 	for k, v := range s.Annotations {
 		gs.Annotations[k] = v
 	}
+	if len(s.Nodes) > 0 {
+		gs.Nodes = make(map[string]*protobufs.NodeCapacity, len(s.Nodes))
+		for k, v := range s.Nodes {
+			gs.Nodes[k] = toGrpcNodeCapacity(v)
+		}
+		gs.Headroom = toGrpcNodeCapacity(s.Headroom)
+	}
 	return &gs
 }
 
+// toGrpcNodeCapacity type convertor from internal node capacity to grpc node capacity
+func toGrpcNodeCapacity(n common.NodeCapacity) *protobufs.NodeCapacity {
+	return &protobufs.NodeCapacity{
+		AllocatableCpu:    n.AllocatableCPU,
+		AllocatableMemory: n.AllocatableMemory,
+		RequestedCpu:      n.RequestedCPU,
+		RequestedMemory:   n.RequestedMemory,
+		FreeCpu:           n.FreeCPU,
+		FreeMemory:        n.FreeMemory,
+	}
+}
+
 // toGrpcStates type convertor from internal states to grpc states
 func toGrpcStates(states []common.State) []*protobufs.State {
 	var res []*protobufs.State
@@ -116,10 +144,15 @@ func toGrpcStates(states []common.State) []*protobufs.State {
 
 // toGrpcProfile type convertor from internal profile to grpc profile
 func toGrpcProfile(v *common.Profile) *protobufs.Profile {
+	profileType := protobufs.ProfileType_CUSTOM
+	if v.ProfileType.IsBuiltin() {
+		profileType = protobufs.ProfileType(v.ProfileType) //nolint:gosec // explanation: only limit profile types exists.
+	}
 	return &protobufs.Profile{
 		Key:         v.Key,
-		ProfileType: protobufs.ProfileType(v.ProfileType), //nolint:gosec // explanation: only limit profile types exists.
+		ProfileType: profileType,
 		Minimize:    v.Minimize,
+		TypeName:    v.ProfileType.String(),
 	}
 }
 
@@ -172,11 +205,15 @@ func getNextStateResponse(r *protobufs.NextStateResponse) ([]common.State, []flo
 	for _, v := range r.States {
 		s := common.State{
 			Intent: common.Intent{
-				Key:        v.Intent.Key,
-				Priority:   v.Intent.Priority,
-				TargetKey:  v.Intent.TargetKey,
-				TargetKind: v.Intent.TargetKind,
-				Objectives: v.Intent.Objectives,
+				Key:         v.Intent.Key,
+				ParentKey:   v.Intent.ParentKey,
+				ParentKind:  v.Intent.ParentKind,
+				UID:         v.Intent.Uid,
+				Priority:    v.Intent.Priority,
+				TargetKey:   v.Intent.TargetKey,
+				TargetKind:  v.Intent.TargetKind,
+				Objectives:  v.Intent.Objectives,
+				Tolerations: v.Intent.Tolerations,
 			},
 			CurrentPods: make(map[string]common.PodState),
 			CurrentData: make(map[string]map[string]float64),
@@ -200,6 +237,7 @@ func getNextStateResponse(r *protobufs.NextStateResponse) ([]common.State, []flo
 		for kd, vd := range v.Annotations {
 			s.Annotations[kd] = vd
 		}
+		s.Nodes, s.Headroom = toNodes(v.Nodes, v.Headroom)
 		states = append(states, s)
 	}
 	var a []planner.Action
@@ -227,6 +265,68 @@ func getPerformRequest(state *common.State, plan []planner.Action) *protobufs.Pe
 	return &req
 }
 
+// toGrpcPatches converts the patches into their grpc representation
+func toGrpcPatches(patches []planner.Patch) []*protobufs.Patch {
+	res := make([]*protobufs.Patch, 0, len(patches))
+	for _, patch := range patches {
+		res = append(res, &protobufs.Patch{
+			Actuator:    patch.Actuator,
+			Kind:        patch.Kind,
+			Namespace:   patch.Namespace,
+			Name:        patch.Name,
+			Subresource: patch.Subresource,
+			Type:        patch.Type,
+			Data:        patch.Data,
+			Diff:        patch.Diff,
+		})
+	}
+	return res
+}
+
+// toGrpcPerformResponse converts the result of the perform function into its grpc representation
+func toGrpcPerformResponse(result planner.Result) *protobufs.PerformResponse {
+	return &protobufs.PerformResponse{
+		Status:    string(result.Status),
+		Reason:    result.Reason,
+		Transient: result.Transient,
+		Objects:   result.Objects,
+	}
+}
+
+// getPerformResponse converts the grpc response of the perform function into a result; plugins not reporting a status
+// are assumed to have applied the plan
+func getPerformResponse(name string, r *protobufs.PerformResponse) planner.Result {
+	res := planner.Result{
+		Actuator:  name,
+		Status:    planner.ResultStatus(r.Status),
+		Reason:    r.Reason,
+		Transient: r.Transient,
+		Objects:   r.Objects,
+	}
+	if res.Status == "" {
+		res.Status = planner.ResultApplied
+	}
+	return res
+}
+
+// getDryRunResponse converts the grpc response of the dry run function into patches
+func getDryRunResponse(r *protobufs.DryRunResponse) []planner.Patch {
+	var res []planner.Patch
+	for _, patch := range r.Patches {
+		res = append(res, planner.Patch{
+			Actuator:    patch.Actuator,
+			Kind:        patch.Kind,
+			Namespace:   patch.Namespace,
+			Name:        patch.Name,
+			Subresource: patch.Subresource,
+			Type:        patch.Type,
+			Data:        patch.Data,
+			Diff:        patch.Diff,
+		})
+	}
+	return res
+}
+
 // getEffectRequest create a new grpc request for the effect function
 func getEffectRequest(state *common.State, profiles map[string]common.Profile) *protobufs.EffectRequest {
 	req := protobufs.EffectRequest{
@@ -257,11 +357,37 @@ func (a *ActuatorClientStub) isStopped() bool {
 	return !a.stopTime.IsZero()
 }
 
+// Name returns the name the plugin registered with.
+func (a *ActuatorClientStub) Name() string {
+	return a.pluginInfo.Name
+}
+
+// Group returns the group the plugin registered with.
+func (a *ActuatorClientStub) Group() string {
+	return a.pluginInfo.Group
+}
+
+// InfluencedProfileTypes returns the known profile types the plugin registered as influencing.
+func (a *ActuatorClientStub) InfluencedProfileTypes() []common.ProfileType {
+	var res []common.ProfileType
+	for _, name := range a.pluginInfo.ProfileTypes {
+		if profileType := common.ProfileTypeFromText(name); profileType != common.Obsolete {
+			res = append(res, profileType)
+		}
+	}
+	if len(res) == 0 && len(a.pluginInfo.ProfileTypes) > 0 {
+		// none of the declared types are known; make sure no objective is influenced.
+		return []common.ProfileType{common.Obsolete}
+	}
+	return res
+}
+
 // NextState triggers NextState RPC to plugin
 func (a *ActuatorClientStub) NextState(state *common.State, goal *common.State, profiles map[string]common.Profile) ([]common.State, []float64, []planner.Action) {
 	klog.V(2).Infof("Invoking NextState for actuator client name:%s endpoint: %s", a.pluginInfo.Name, a.pluginInfo.Endpoint)
 	if a.isStopped() {
 		klog.Error("NextState ended unexpectedly for the plugin (stop event)")
+		common.NextStateErrors.WithLabelValues(a.pluginInfo.Name).Inc()
 		return []common.State{}, []float64{}, []planner.Action{}
 	}
 	if a.stream == nil {
@@ -270,12 +396,14 @@ func (a *ActuatorClientStub) NextState(state *common.State, goal *common.State,
 		a.stream = &stream
 		if err != nil {
 			klog.Errorf("Failed to call: %v.", err)
+			common.NextStateErrors.WithLabelValues(a.pluginInfo.Name).Inc()
 			return nil, nil, nil
 		}
 	}
 	request := getNextStateRequest(state, goal, profiles)
 	if err := (*a.stream).Send(request); err != nil {
 		klog.Errorf("Failed to send request: %v.", err)
+		common.NextStateErrors.WithLabelValues(a.pluginInfo.Name).Inc()
 		return nil, nil, nil
 	}
 
@@ -283,6 +411,7 @@ func (a *ActuatorClientStub) NextState(state *common.State, goal *common.State,
 	response, err := (*a.stream).Recv()
 	if err != nil {
 		klog.Errorf("Failed to get response: %v.", err)
+		common.NextStateErrors.WithLabelValues(a.pluginInfo.Name).Inc()
 		return nil, nil, nil
 	}
 
@@ -290,17 +419,41 @@ func (a *ActuatorClientStub) NextState(state *common.State, goal *common.State,
 }
 
 // Perform triggers Perform RPC to plugin
-func (a *ActuatorClientStub) Perform(state *common.State, plan []planner.Action) {
+func (a *ActuatorClientStub) Perform(state *common.State, plan []planner.Action) planner.Result {
 	klog.V(2).Infof("Invoking Perform for actuator client name:%s endpoint: %s", a.pluginInfo.Name, a.pluginInfo.Endpoint)
 	if a.isStopped() {
 		klog.Error("Perform ended unexpectedly for the plugin (stop event)")
-		return
+		return planner.Result{Actuator: a.pluginInfo.Name, Status: planner.ResultFailed, Reason: "plugin stopped", Transient: true}
 	}
-	_, err := a.client.Perform(context.Background(), getPerformRequest(state, plan))
-
+	response, err := a.client.Perform(context.Background(), getPerformRequest(state, plan))
 	if err != nil {
 		klog.Error(err)
+		switch status.Code(err) {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+			return planner.Result{Actuator: a.pluginInfo.Name, Status: planner.ResultFailed, Reason: err.Error(), Transient: true}
+		default:
+			return planner.Result{Actuator: a.pluginInfo.Name, Status: planner.ResultFailed, Reason: err.Error()}
+		}
+	}
+	return getPerformResponse(a.pluginInfo.Name, response)
+}
+
+// DryRun triggers DryRun RPC to plugin; plugins not implementing it report no patches
+func (a *ActuatorClientStub) DryRun(state *common.State, plan []planner.Action) []planner.Patch {
+	klog.V(2).Infof("Invoking DryRun for actuator client name:%s endpoint: %s", a.pluginInfo.Name, a.pluginInfo.Endpoint)
+	if a.isStopped() {
+		klog.Error("DryRun ended unexpectedly for the plugin (stop event)")
+		return nil
+	}
+	response, err := a.client.DryRun(context.Background(), getPerformRequest(state, plan))
+	if status.Code(err) == codes.Unimplemented {
+		klog.V(2).Infof("Plugin %s does not support dry runs.", a.pluginInfo.Name)
+		return nil
+	} else if err != nil {
+		klog.Error(err)
+		return nil
 	}
+	return getDryRunResponse(response)
 }
 
 // Effect triggers Effect RPC to plugin
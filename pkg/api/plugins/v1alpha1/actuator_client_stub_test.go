@@ -2,9 +2,12 @@ package plugins
 
 import (
 	"testing"
+	"time"
 
 	protobufs "github.com/intel/intent-driven-orchestration/pkg/api/plugins/v1alpha1/protobufs"
+	"github.com/intel/intent-driven-orchestration/pkg/common"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -45,3 +48,61 @@ func TestGetEffectRequest(t *testing.T) {
 	assert.Equal(t, vSetGrpc.start.String(), r.State.String())
 	assert.Equal(t, vSetGrpc.profiles, r.Profiles)
 }
+
+func TestProfileTypeConversion(t *testing.T) {
+	custom, err := common.RegisterProfileType("conversion_test", true)
+	assert.NoError(t, err)
+	profiles := map[string]common.Profile{
+		"p99":    {Key: "p99", ProfileType: common.Latency, Minimize: true},
+		"errors": {Key: "errors", ProfileType: common.ErrorRate, Minimize: true},
+		"custom": {Key: "custom", ProfileType: custom, Minimize: true},
+	}
+	r := toGrpcProfiles(profiles)
+	assert.Equal(t, protobufs.ProfileType_LATENCY, r["p99"].ProfileType)
+	assert.Equal(t, protobufs.ProfileType_ERROR_RATE, r["errors"].ProfileType)
+	assert.Equal(t, protobufs.ProfileType_CUSTOM, r["custom"].ProfileType)
+	assert.Equal(t, "conversion_test", r["custom"].TypeName)
+	assert.Equal(t, profiles, toProfiles(r))
+}
+
+func TestNodeCapacityConversion(t *testing.T) {
+	state := common.State{
+		Intent:      common.Intent{Key: "default/my-intent", ParentKey: "default/my-group", ParentKind: "IntentGroup", UID: "abc"},
+		CurrentPods: map[string]common.PodState{"pod_0": {NodeName: "node0"}},
+		CurrentData: map[string]map[string]float64{},
+		Resources:   map[string]int64{"0_cpu_requests": 500},
+		Annotations: map[string]string{},
+		Nodes: map[string]common.NodeCapacity{
+			"node0": {AllocatableCPU: 4000, AllocatableMemory: 8192000, RequestedCPU: 500, RequestedMemory: 1024000, FreeCPU: 3500, FreeMemory: 7168000},
+		},
+	}
+	state.Headroom = common.Headroom(state.Nodes)
+	r := toGrpcState(&state)
+	assert.Equal(t, int64(3500), r.Nodes["node0"].FreeCpu)
+	assert.Equal(t, int64(7168000), r.Headroom.FreeMemory)
+	assert.Equal(t, "IntentGroup", r.Intent.ParentKind)
+	assert.Equal(t, &state, toState(r))
+
+	// unknown capacity stays unknown.
+	state.Nodes, state.Headroom = nil, common.NodeCapacity{}
+	r = toGrpcState(&state)
+	assert.Nil(t, r.Headroom)
+	assert.Nil(t, toState(r).Nodes)
+}
+
+func TestInfluencedProfileTypes(t *testing.T) {
+	stub := ActuatorClientStub{pluginInfo: PInfo{Name: "foo"}}
+	assert.Nil(t, stub.InfluencedProfileTypes())
+	stub.pluginInfo.ProfileTypes = []string{"latency", "unknown_type"}
+	assert.Equal(t, []common.ProfileType{common.Latency}, stub.InfluencedProfileTypes())
+	stub.pluginInfo.ProfileTypes = []string{"unknown_type"}
+	assert.Equal(t, []common.ProfileType{common.Obsolete}, stub.InfluencedProfileTypes())
+}
+
+func TestNextStateErrorMetric(t *testing.T) {
+	stub := &ActuatorClientStub{pluginInfo: PInfo{Name: "test-errors"}, stopTime: time.Now()}
+	before := testutil.ToFloat64(common.NextStateErrors.WithLabelValues("test-errors"))
+	states, _, _ := stub.NextState(&common.State{}, &common.State{}, map[string]common.Profile{})
+	assert.Empty(t, states)
+	assert.Equal(t, before+1, testutil.ToFloat64(common.NextStateErrors.WithLabelValues("test-errors")))
+}
@@ -2,6 +2,7 @@ package plugins
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"sync"
@@ -20,11 +21,20 @@ import (
 	"github.com/intel/intent-driven-orchestration/pkg/planner"
 )
 
+// errNotRegistered is reported when the plugin has not (yet) registered, or has deregistered.
+var errNotRegistered = errors.New("not registered with the plugin manager")
+
+// deregistrationTimeout defines how long a plugin tries to deregister from the plugin manager when shutting down.
+const deregistrationTimeout = 5 * time.Second
+
 // stubNextStateFunc nextState function type for stub callbacks
 type stubNextStateFunc func(*common.State, *common.State, map[string]common.Profile) ([]common.State, []float64, []planner.Action)
 
 // stubPerformFunc perform function type for stub callbacks
-type stubPerformFunc func(*common.State, []planner.Action)
+type stubPerformFunc func(*common.State, []planner.Action) planner.Result
+
+// stubDryRunFunc dry run function type for stub callbacks
+type stubDryRunFunc func(*common.State, []planner.Action) []planner.Patch
 
 // stubEffectFunc effect function type for stub callbacks
 type stubEffectFunc func(*common.State, map[string]common.Profile)
@@ -35,7 +45,8 @@ func defaultNextStateFunc(*common.State, *common.State, map[string]common.Profil
 }
 
 // defaultPerformFunc default perform handler callback
-func defaultPerformFunc(*common.State, []planner.Action) {
+func defaultPerformFunc(*common.State, []planner.Action) planner.Result {
+	return planner.Result{}
 }
 
 // defaultEffectFunc default effect handler callback
@@ -48,6 +59,8 @@ type ActuatorPluginStub struct {
 	protobufs.UnimplementedActuatorPluginServer
 	server                *grpc.Server
 	name                  string
+	group                 string
+	profileTypes          []string
 	version               string
 	endpoint              string
 	port                  int
@@ -56,10 +69,17 @@ type ActuatorPluginStub struct {
 	retries               int
 	nextStateFunc         stubNextStateFunc
 	performFunc           stubPerformFunc
+	dryRunFunc            stubDryRunFunc
 	effectFunc            stubEffectFunc
 
-	stop chan interface{}
-	wg   sync.WaitGroup
+	stop             chan struct{}
+	wg               sync.WaitGroup
+	registration     chan struct{}
+	registrationOnce sync.Once
+	registering      sync.WaitGroup
+	// registrationErr holds the outcome of the last (de-)registration - guarded by registrationMu.
+	registrationErr error
+	registrationMu  sync.Mutex
 }
 
 // NewActuatorPluginStub creates a new actuator stub for a user defined plugin manager endpoint.
@@ -75,7 +95,9 @@ func NewActuatorPluginStub(name string, endpoint string, port int, serverEndpoin
 		nextStateFunc:         defaultNextStateFunc,
 		performFunc:           defaultPerformFunc,
 		effectFunc:            defaultEffectFunc,
-		stop:                  make(chan interface{}),
+		stop:                  make(chan struct{}),
+		registration:          make(chan struct{}),
+		registrationErr:       errNotRegistered,
 	}
 }
 
@@ -128,6 +150,7 @@ func (s *ActuatorPluginStub) Start() error {
 // and more than once. Not safe to be called concurrently by different
 // goroutines!
 func (s *ActuatorPluginStub) Stop() error {
+	s.stopRegistering()
 	if s.server == nil {
 		return nil
 	}
@@ -141,6 +164,28 @@ func (s *ActuatorPluginStub) Stop() error {
 
 // Register registers the actuator plugin for the given name with ido controller.
 func (s *ActuatorPluginStub) Register() error {
+	err := s.register()
+	s.setRegistrationErr(err)
+	return err
+}
+
+// Registered returns an error if the actuator plugin is not registered with the ido controller - based on the outcome
+// of the last (de-)registration.
+func (s *ActuatorPluginStub) Registered() error {
+	s.registrationMu.Lock()
+	defer s.registrationMu.Unlock()
+	return s.registrationErr
+}
+
+// setRegistrationErr records the outcome of a (de-)registration.
+func (s *ActuatorPluginStub) setRegistrationErr(err error) {
+	s.registrationMu.Lock()
+	defer s.registrationMu.Unlock()
+	s.registrationErr = err
+}
+
+// register performs the actual registration.
+func (s *ActuatorPluginStub) register() error {
 	klog.Infof("Actuator %s: performing plugin registration at %s:%d.", s.name, s.pluginManagerEndpoint, s.pluginManagerPort)
 	if s.port <= 0 || s.port > 65535 || s.pluginManagerPort <= 0 || s.pluginManagerPort > 65535 {
 		return fmt.Errorf("failed. Both ports need to be in a valid range: %d - %d", s.port, s.pluginManagerPort)
@@ -171,6 +216,8 @@ func (s *ActuatorPluginStub) Register() error {
 		PInfo: &protobufs.PluginInfo{
 			Type:              protobufs.PluginType_ACTUATOR,
 			Name:              s.name,
+			Group:             s.group,
+			ProfileTypes:      s.profileTypes,
 			Endpoint:          fmt.Sprintf("%s:%d", s.endpoint, s.port),
 			SupportedVersions: s.version,
 		},
@@ -182,6 +229,96 @@ func (s *ActuatorPluginStub) Register() error {
 	return err
 }
 
+// Shutdown deregisters the actuator plugin from the ido controller and gracefully stops the gRPC server - in-flight
+// calls are given until the deadline of the context to finish, before the server is stopped forcefully.
+func (s *ActuatorPluginStub) Shutdown(ctx context.Context) error {
+	s.stopRegistering()
+	deregisterCtx, cancel := context.WithTimeout(ctx, deregistrationTimeout)
+	defer cancel()
+	if err := s.Deregister(deregisterCtx); err != nil {
+		klog.Warningf("Actuator %s: deregistration failed: %v.", s.name, err)
+	}
+	// the plugin manager drops the plugin anyhow once it cannot reach it anymore.
+	s.setRegistrationErr(errNotRegistered)
+	if s.server == nil {
+		return nil
+	}
+	server := s.server
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("in-flight calls did not finish in time: %w", ctx.Err())
+	}
+	if stopErr := s.Stop(); stopErr != nil {
+		return stopErr
+	}
+	return err
+}
+
+// Deregister deregisters the actuator plugin from the ido controller, so it is not used anymore.
+func (s *ActuatorPluginStub) Deregister(ctx context.Context) error {
+	klog.Infof("Actuator %s: performing plugin deregistration at %s:%d.", s.name, s.pluginManagerEndpoint, s.pluginManagerPort)
+	// nolint:staticcheck // SA1019: grpc.Dial is deprecated — but supported in 1.0; for GRPC 2.0 we'll need to check if the connection is ready.
+	conn, err := grpc.DialContext(ctx, fmt.Sprintf("%s:%d", s.pluginManagerEndpoint, s.pluginManagerPort), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to establish a connection to plugin manager: %w", err)
+	}
+	defer conn.Close()
+	client := protobufs.NewRegistrationClient(conn)
+	req := protobufs.DeregisterRequest{
+		PInfo: &protobufs.PluginInfo{
+			Type:              protobufs.PluginType_ACTUATOR,
+			Name:              s.name,
+			Endpoint:          fmt.Sprintf("%s:%d", s.endpoint, s.port),
+			SupportedVersions: s.version,
+		},
+	}
+	resp, err := client.Deregister(ctx, &req)
+	if err == nil && resp.Error != "" {
+		return fmt.Errorf("server side deregistration error: %s", resp.Error)
+	}
+	if err == nil {
+		s.setRegistrationErr(errNotRegistered)
+	}
+	return err
+}
+
+// KeepRegistered periodically registers the actuator plugin again - so that a newly elected leader of the ido
+// controller learns about it. Stops once the stub is stopped or shut down.
+func (s *ActuatorPluginStub) KeepRegistered(period time.Duration) {
+	s.registering.Add(1)
+	go func() {
+		defer s.registering.Done()
+		wait.Until(func() {
+			if err := s.Register(); err != nil {
+				klog.Warningf("Actuator %s: re-registration failed: %v.", s.name, err)
+			}
+		}, period, s.registration)
+	}()
+}
+
+// stopRegistering stops the periodic registration and waits for an ongoing registration to finish.
+func (s *ActuatorPluginStub) stopRegistering() {
+	s.registrationOnce.Do(func() { close(s.registration) })
+	s.registering.Wait()
+}
+
+// SetGroup sets the group of the actuator which is sent to the plugin manager on registration.
+func (s *ActuatorPluginStub) SetGroup(group string) {
+	s.group = group
+}
+
+// SetProfileTypes sets the names of the profile types the actuator can influence, which are sent to the plugin manager on registration.
+func (s *ActuatorPluginStub) SetProfileTypes(profileTypes []string) {
+	s.profileTypes = profileTypes
+}
+
 // SetNextStateFunc sets the NextState function callback
 func (s *ActuatorPluginStub) SetNextStateFunc(f stubNextStateFunc) {
 	s.nextStateFunc = f
@@ -192,6 +329,11 @@ func (s *ActuatorPluginStub) SetPerformFunc(f stubPerformFunc) {
 	s.performFunc = f
 }
 
+// SetDryRunFunc sets the DryRun function callback; without one the plugin reports dry runs as not implemented
+func (s *ActuatorPluginStub) SetDryRunFunc(f stubDryRunFunc) {
+	s.dryRunFunc = f
+}
+
 // SetEffectFunc sets the Effect function callback
 func (s *ActuatorPluginStub) SetEffectFunc(f stubEffectFunc) {
 	s.effectFunc = f
@@ -201,11 +343,15 @@ func (s *ActuatorPluginStub) SetEffectFunc(f stubEffectFunc) {
 func toState(s *protobufs.State) *common.State {
 	gs := common.State{
 		Intent: common.Intent{
-			Key:        s.Intent.Key,
-			Priority:   s.Intent.Priority,
-			TargetKey:  s.Intent.TargetKey,
-			TargetKind: s.Intent.TargetKind,
-			Objectives: s.Intent.Objectives,
+			Key:         s.Intent.Key,
+			ParentKey:   s.Intent.ParentKey,
+			ParentKind:  s.Intent.ParentKind,
+			UID:         s.Intent.Uid,
+			Priority:    s.Intent.Priority,
+			TargetKey:   s.Intent.TargetKey,
+			TargetKind:  s.Intent.TargetKind,
+			Objectives:  s.Intent.Objectives,
+			Tolerations: s.Intent.Tolerations,
 		},
 		CurrentPods: make(map[string]common.PodState),
 		CurrentData: make(map[string]map[string]float64),
@@ -230,16 +376,47 @@ func toState(s *protobufs.State) *common.State {
 	for k, v := range s.Annotations {
 		gs.Annotations[k] = v
 	}
+	gs.Nodes, gs.Headroom = toNodes(s.Nodes, s.Headroom)
 	return &gs
 }
 
+// toNodes node capacity type conversion from grpc to internal datatype; no nodes means the capacity is unknown
+func toNodes(nodes map[string]*protobufs.NodeCapacity, headroom *protobufs.NodeCapacity) (map[string]common.NodeCapacity, common.NodeCapacity) {
+	if len(nodes) == 0 {
+		return nil, common.NodeCapacity{}
+	}
+	convert := func(n *protobufs.NodeCapacity) common.NodeCapacity {
+		return common.NodeCapacity{
+			AllocatableCPU:    n.GetAllocatableCpu(),
+			AllocatableMemory: n.GetAllocatableMemory(),
+			RequestedCPU:      n.GetRequestedCpu(),
+			RequestedMemory:   n.GetRequestedMemory(),
+			FreeCPU:           n.GetFreeCpu(),
+			FreeMemory:        n.GetFreeMemory(),
+		}
+	}
+	res := make(map[string]common.NodeCapacity, len(nodes))
+	for k, v := range nodes {
+		res[k] = convert(v)
+	}
+	return res, convert(headroom)
+}
+
 // toProfiles profiles type conversion from grpc to internal datatype
 func toProfiles(profiles map[string]*protobufs.Profile) map[string]common.Profile {
 	r := map[string]common.Profile{}
 	for k, v := range profiles {
+		profileType := common.ProfileTypeFromText(v.ProfileType.String())
+		if v.TypeName != "" {
+			// make sure types configured for the planner are known to the plugin as well.
+			var err error
+			if profileType, err = common.RegisterProfileType(v.TypeName, v.Minimize); err != nil {
+				klog.Warningf("Unable to register profile type of profile %s: %v.", v.Key, err)
+			}
+		}
 		r[k] = common.Profile{
 			Key:         v.Key,
-			ProfileType: common.ProfileTypeFromText(v.ProfileType.String()),
+			ProfileType: profileType,
 			Minimize:    v.Minimize,
 		}
 	}
@@ -290,10 +467,18 @@ func (s *ActuatorPluginStub) NextState(stream protobufs.ActuatorPlugin_NextState
 }
 
 // Perform grpc callback for the perform function of pluggable Actuators
-func (s *ActuatorPluginStub) Perform(_ context.Context, r *protobufs.PerformRequest) (*protobufs.Empty, error) {
+func (s *ActuatorPluginStub) Perform(_ context.Context, r *protobufs.PerformRequest) (*protobufs.PerformResponse, error) {
 	klog.V(3).InfoS("Perform GRPC call", "request", r)
-	s.performFunc(toState(r.State), toActions(r.Plan))
-	return &protobufs.Empty{}, nil
+	return toGrpcPerformResponse(s.performFunc(toState(r.State), toActions(r.Plan))), nil
+}
+
+// DryRun grpc callback for the dry run function of pluggable Actuators
+func (s *ActuatorPluginStub) DryRun(ctx context.Context, r *protobufs.PerformRequest) (*protobufs.DryRunResponse, error) {
+	klog.V(3).InfoS("DryRun GRPC call", "request", r)
+	if s.dryRunFunc == nil {
+		return s.UnimplementedActuatorPluginServer.DryRun(ctx, r)
+	}
+	return &protobufs.DryRunResponse{Patches: toGrpcPatches(s.dryRunFunc(toState(r.State), toActions(r.Plan)))}, nil
 }
 
 // Effect grpc callback for the Effect function of pluggable Actuators
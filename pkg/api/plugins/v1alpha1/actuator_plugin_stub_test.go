@@ -1,9 +1,12 @@
 package plugins
 
 import (
+	"context"
+
 	"github.com/stretchr/testify/assert"
 
 	"testing"
+	"time"
 
 	"github.com/intel/intent-driven-orchestration/pkg/common"
 	"github.com/intel/intent-driven-orchestration/pkg/planner"
@@ -26,9 +29,10 @@ func mockedNextStateFunc(_ *common.State, _ *common.State, _ map[string]common.P
 	return vSet.end, vSet.utilities, vSet.actions
 }
 
-func (tme *TestMe) mockedPerformFunc(state *common.State, plan []planner.Action) {
+func (tme *TestMe) mockedPerformFunc(state *common.State, plan []planner.Action) planner.Result {
 	assert.Equal(tme.t, *tme.vSet.start, *state)
 	assert.Equal(tme.t, tme.vSet.actions, plan)
+	return planner.Result{Status: planner.ResultApplied, Objects: []string{"Deployment default/foo"}}
 }
 
 func (tme *TestMe) mockedEffectFunc(state *common.State, profiles map[string]common.Profile) {
@@ -183,10 +187,60 @@ func TestActuatorPerform(t *testing.T) {
 	assert.Nil(t, err)
 	err = s.Register()
 	assert.Nil(t, err)
+	var result planner.Result
+	f := func(act actuators.Actuator) {
+		result = act.Perform(vSet.start, vSet.actions)
+	}
+	pm.Iter(f)
+	assert.Equal(t, planner.Result{Actuator: "test-actuator-1", Status: planner.ResultApplied, Objects: []string{"Deployment default/foo"}}, result)
+
+	// failures are reported - including whether they are transient.
+	s.SetPerformFunc(func(_ *common.State, _ []planner.Action) planner.Result {
+		return planner.Result{Status: planner.ResultFailed, Reason: "conflict", Transient: true}
+	})
+	pm.Iter(f)
+	assert.Equal(t, planner.Result{Actuator: "test-actuator-1", Status: planner.ResultFailed, Reason: "conflict", Transient: true}, result)
+
+	// plugins not reporting a status are assumed to have applied the plan.
+	s.SetPerformFunc(defaultPerformFunc)
+	pm.Iter(f)
+	assert.Equal(t, planner.ResultApplied, result.Status)
+	err = s.Stop()
+	assert.Nil(t, err)
+	err = pm.Stop()
+	assert.Nil(t, err)
+}
+
+func TestActuatorDryRun(t *testing.T) {
+	pm := NewPluginManagerServer([]actuators.Actuator{}, "localhost", 3333)
+	assert.NotNil(t, pm)
+	s := NewActuatorPluginStub("test-actuator-1", "localhost", 3334, "localhost", 3333)
+	assert.NotNil(t, s)
+	vSet := generateActuatorValidationSet()
+	err := pm.Start()
+	assert.Nil(t, err)
+	err = s.Start()
+	assert.Nil(t, err)
+	err = s.Register()
+	assert.Nil(t, err)
+
+	// plugin does not support dry runs.
+	var patches []planner.Patch
 	f := func(act actuators.Actuator) {
-		act.Perform(vSet.start, vSet.actions)
+		patches = act.(actuators.DryRunner).DryRun(vSet.start, vSet.actions)
 	}
 	pm.Iter(f)
+	assert.Nil(t, patches)
+
+	// plugin returns the patches.
+	expected := []planner.Patch{{Actuator: "test-actuator-1", Kind: "Deployment", Namespace: "default", Name: "foo", Type: "application/merge-patch+json", Data: `{"spec":{"replicas":2}}`, Diff: []string{"spec.replicas: 1 -> 2"}}}
+	s.SetDryRunFunc(func(state *common.State, plan []planner.Action) []planner.Patch {
+		assert.Equal(t, vSet.actions, plan)
+		return expected
+	})
+	pm.Iter(f)
+	assert.Equal(t, expected, patches)
+
 	err = s.Stop()
 	assert.Nil(t, err)
 	err = pm.Stop()
@@ -219,3 +273,73 @@ func TestActuatorEffect(t *testing.T) {
 	err = pm.Stop()
 	assert.Nil(t, err)
 }
+
+func TestPluginReRegistration(t *testing.T) {
+	pm := NewPluginManagerServer([]actuators.Actuator{}, "localhost", 3333)
+	s := NewActuatorPluginStub("test-actuator-1", "localhost", 3334, "localhost", 3333)
+	err := pm.Start()
+	assert.Nil(t, err)
+	err = s.Start()
+	assert.Nil(t, err)
+	err = s.Register()
+	assert.Nil(t, err)
+
+	// registering again is fine.
+	err = s.Register()
+	assert.Nil(t, err)
+
+	// a new plugin manager - e.g. on a newly elected leader - learns about the plugin.
+	s.KeepRegistered(100 * time.Millisecond)
+	err = pm.Stop()
+	assert.Nil(t, err)
+	pm = NewPluginManagerServer([]actuators.Actuator{}, "localhost", 3333)
+	err = pm.Start()
+	assert.Nil(t, err)
+	assert.Eventually(t, func() bool {
+		pm.mu.Lock()
+		defer pm.mu.Unlock()
+		_, ok := pm.registeredPlugins["test-actuator-1"]
+		return ok
+	}, 20*time.Second, 100*time.Millisecond)
+	err = s.Stop()
+	assert.Nil(t, err)
+	err = pm.Stop()
+	assert.Nil(t, err)
+}
+
+func TestPluginShutdown(t *testing.T) {
+	pm := NewPluginManagerServer([]actuators.Actuator{}, "localhost", 3333)
+	s := NewActuatorPluginStub("test-actuator-1", "localhost", 3334, "localhost", 3333)
+	assert.NotNil(t, pm.Ready())
+	err := pm.Start()
+	assert.Nil(t, err)
+	assert.Nil(t, pm.Ready())
+	err = s.Start()
+	assert.Nil(t, err)
+	assert.NotNil(t, s.Registered())
+	err = s.Register()
+	assert.Nil(t, err)
+	assert.Nil(t, s.Registered())
+	s.KeepRegistered(100 * time.Millisecond)
+
+	// plugin deregisters & does not register again.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = s.Shutdown(ctx)
+	assert.Nil(t, err)
+	time.Sleep(300 * time.Millisecond)
+	pm.mu.Lock()
+	_, ok := pm.registeredPlugins["test-actuator-1"]
+	pm.mu.Unlock()
+	assert.False(t, ok)
+	assert.NotNil(t, s.Registered())
+
+	// deregistering an unknown plugin fails; shutting down again is fine.
+	err = s.Deregister(ctx)
+	assert.NotNil(t, err)
+	err = s.Shutdown(ctx)
+	assert.Nil(t, err)
+	err = pm.Stop()
+	assert.Nil(t, err)
+	assert.NotNil(t, pm.Ready())
+}
@@ -34,21 +34,26 @@ func NewPluginManagerServer(actuators []actuators.Actuator, endpoint string, por
 
 // Register registration callback triggered when the server received a new register rpc call from a plugin
 func (pm *PluginManagerServer) Register(_ context.Context, r *protobufs.RegisterRequest) (*protobufs.RegistrationStatusResponse, error) {
-	klog.Infof("Received plugin registration for plugin name: %s with endpoint: %s.", r.PInfo.Name, r.PInfo.Endpoint)
 	resp := &protobufs.RegistrationStatusResponse{
 		PluginRegistered: false,
 		Error:            "",
 	}
+	pm.mu.Lock()
+	existing, ok := pm.registeredPlugins[r.PInfo.Name]
+	pm.mu.Unlock()
+	if ok && existing.pluginInfo.Endpoint == r.PInfo.Endpoint && r.PInfo.SupportedVersions == pluginVersion {
+		// plugins periodically register again; nothing to do if we already know about them.
+		klog.V(2).Infof("Plugin %s is already registered with endpoint: %s.", r.PInfo.Name, r.PInfo.Endpoint)
+		resp.PluginRegistered = true
+		return resp, nil
+	}
+	klog.Infof("Received plugin registration for plugin name: %s with endpoint: %s.", r.PInfo.Name, r.PInfo.Endpoint)
 
 	if r.PInfo.SupportedVersions != pluginVersion {
 		resp.Error = fmt.Sprintf("Unsupported plugin version: %s.", r.PInfo.SupportedVersions)
 		return resp, nil
 	}
 	// we do not allow plugin registration with the same name
-	ok := false
-	pm.mu.Lock()
-	_, ok = pm.registeredPlugins[r.PInfo.Name]
-	pm.mu.Unlock()
 	if !ok {
 		aClientStub, err := newActuatorClientStub(r.PInfo, pm.retries)
 		if err != nil {
@@ -61,12 +66,32 @@ func (pm *PluginManagerServer) Register(_ context.Context, r *protobufs.Register
 		resp.PluginRegistered = true
 
 	} else {
-		klog.Warningf("Plugin %s is already registered.", r.PInfo.Name)
+		klog.Warningf("Plugin %s is already registered with endpoint: %s.", r.PInfo.Name, existing.pluginInfo.Endpoint)
 		resp.Error = "Plugin is already registered."
 	}
 	return resp, nil
 }
 
+// Deregister deregistration callback triggered when a plugin shuts down; the plugin is not used anymore afterward.
+func (pm *PluginManagerServer) Deregister(_ context.Context, r *protobufs.DeregisterRequest) (*protobufs.RegistrationStatusResponse, error) {
+	resp := &protobufs.RegistrationStatusResponse{
+		PluginRegistered: false,
+		Error:            "",
+	}
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	existing, ok := pm.registeredPlugins[r.PInfo.Name]
+	if !ok || existing.pluginInfo.Endpoint != r.PInfo.Endpoint {
+		resp.Error = "Plugin is not registered."
+		return resp, nil
+	}
+	klog.Infof("Received plugin deregistration for plugin name: %s with endpoint: %s.", r.PInfo.Name, r.PInfo.Endpoint)
+	existing.stop()
+	delete(pm.registeredPlugins, r.PInfo.Name)
+	delete(pm.registeredPluginsRetries, r.PInfo.Name)
+	return resp, nil
+}
+
 // refreshRegisteredPlugin checks if registered plugins have healthy connection, if not they are removed from the registered list
 func (pm *PluginManagerServer) refreshRegisteredPlugin(retries int) {
 	pm.mu.Lock()
@@ -144,10 +169,23 @@ func (pm *PluginManagerServer) Start() error {
 		return lastDialErr
 	}
 
+	pm.mu.Lock()
+	pm.listening = true
+	pm.mu.Unlock()
 	klog.Infof("Starting to serve on endpoint %s:%d.", pm.endpoint, pm.port)
 	return nil
 }
 
+// Ready returns an error if the plugin manager is not listening for plugin registrations.
+func (pm *PluginManagerServer) Ready() error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+	if !pm.listening {
+		return fmt.Errorf("plugin manager is not listening on port %d", pm.port)
+	}
+	return nil
+}
+
 // Stop stops the plugin manager registration server and closes all outgoing plugin connections
 func (pm *PluginManagerServer) Stop() error {
 	pm.mu.Lock()
@@ -156,6 +194,7 @@ func (pm *PluginManagerServer) Stop() error {
 		p.stop()
 	}
 
+	pm.listening = false
 	if pm.server == nil {
 		return nil
 	}
@@ -21,7 +21,8 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	Registration_Register_FullMethodName = "/plugins.Registration/Register"
+	Registration_Register_FullMethodName   = "/plugins.Registration/Register"
+	Registration_Deregister_FullMethodName = "/plugins.Registration/Deregister"
 )
 
 // RegistrationClient is the client API for Registration service.
@@ -34,8 +35,10 @@ const (
 // Registration may fail when ido plugin version is not supported by
 // ido controller or the registered plugin name is already taken by another
 // active ido plugin. IDO plugin is expected to terminate upon registration failure
+// Plugins deregister when shutting down, so the controller stops using them right away
 type RegistrationClient interface {
 	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegistrationStatusResponse, error)
+	Deregister(ctx context.Context, in *DeregisterRequest, opts ...grpc.CallOption) (*RegistrationStatusResponse, error)
 }
 
 type registrationClient struct {
@@ -56,6 +59,16 @@ func (c *registrationClient) Register(ctx context.Context, in *RegisterRequest,
 	return out, nil
 }
 
+func (c *registrationClient) Deregister(ctx context.Context, in *DeregisterRequest, opts ...grpc.CallOption) (*RegistrationStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegistrationStatusResponse)
+	err := c.cc.Invoke(ctx, Registration_Deregister_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RegistrationServer is the server API for Registration service.
 // All implementations must embed UnimplementedRegistrationServer
 // for forward compatibility
@@ -66,8 +79,10 @@ func (c *registrationClient) Register(ctx context.Context, in *RegisterRequest,
 // Registration may fail when ido plugin version is not supported by
 // ido controller or the registered plugin name is already taken by another
 // active ido plugin. IDO plugin is expected to terminate upon registration failure
+// Plugins deregister when shutting down, so the controller stops using them right away
 type RegistrationServer interface {
 	Register(context.Context, *RegisterRequest) (*RegistrationStatusResponse, error)
+	Deregister(context.Context, *DeregisterRequest) (*RegistrationStatusResponse, error)
 	mustEmbedUnimplementedRegistrationServer()
 }
 
@@ -78,6 +93,9 @@ type UnimplementedRegistrationServer struct {
 func (UnimplementedRegistrationServer) Register(context.Context, *RegisterRequest) (*RegistrationStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
 }
+func (UnimplementedRegistrationServer) Deregister(context.Context, *DeregisterRequest) (*RegistrationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Deregister not implemented")
+}
 func (UnimplementedRegistrationServer) mustEmbedUnimplementedRegistrationServer() {}
 
 // UnsafeRegistrationServer may be embedded to opt out of forward compatibility for this service.
@@ -109,6 +127,24 @@ func _Registration_Register_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registration_Deregister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeregisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).Deregister(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Registration_Deregister_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).Deregister(ctx, req.(*DeregisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Registration_ServiceDesc is the grpc.ServiceDesc for Registration service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -120,6 +156,10 @@ var Registration_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Register",
 			Handler:    _Registration_Register_Handler,
 		},
+		{
+			MethodName: "Deregister",
+			Handler:    _Registration_Deregister_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/api/plugins/v1alpha1/protobufs/api.proto",
@@ -128,6 +168,7 @@ var Registration_ServiceDesc = grpc.ServiceDesc{
 const (
 	ActuatorPlugin_NextState_FullMethodName = "/plugins.ActuatorPlugin/NextState"
 	ActuatorPlugin_Perform_FullMethodName   = "/plugins.ActuatorPlugin/Perform"
+	ActuatorPlugin_DryRun_FullMethodName    = "/plugins.ActuatorPlugin/DryRun"
 	ActuatorPlugin_Effect_FullMethodName    = "/plugins.ActuatorPlugin/Effect"
 )
 
@@ -139,8 +180,10 @@ const (
 type ActuatorPluginClient interface {
 	// NextState should return a set of potential follow-up states for a given state if this actuator would potentially be used.
 	NextState(ctx context.Context, opts ...grpc.CallOption) (ActuatorPlugin_NextStateClient, error)
-	// Perform should perform those actions of the plan that it is in charge of
-	Perform(ctx context.Context, in *PerformRequest, opts ...grpc.CallOption) (*Empty, error)
+	// Perform should perform those actions of the plan that it is in charge of and report the outcome
+	Perform(ctx context.Context, in *PerformRequest, opts ...grpc.CallOption) (*PerformResponse, error)
+	// DryRun should return the patches Perform would apply - without applying them.
+	DryRun(ctx context.Context, in *PerformRequest, opts ...grpc.CallOption) (*DryRunResponse, error)
 	// Effect should (optionally) recalculate the effect this actuator has for ALL objectives for this workload.
 	Effect(ctx context.Context, in *EffectRequest, opts ...grpc.CallOption) (*Empty, error)
 }
@@ -185,9 +228,9 @@ func (x *actuatorPluginNextStateClient) Recv() (*NextStateResponse, error) {
 	return m, nil
 }
 
-func (c *actuatorPluginClient) Perform(ctx context.Context, in *PerformRequest, opts ...grpc.CallOption) (*Empty, error) {
+func (c *actuatorPluginClient) Perform(ctx context.Context, in *PerformRequest, opts ...grpc.CallOption) (*PerformResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Empty)
+	out := new(PerformResponse)
 	err := c.cc.Invoke(ctx, ActuatorPlugin_Perform_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -195,6 +238,16 @@ func (c *actuatorPluginClient) Perform(ctx context.Context, in *PerformRequest,
 	return out, nil
 }
 
+func (c *actuatorPluginClient) DryRun(ctx context.Context, in *PerformRequest, opts ...grpc.CallOption) (*DryRunResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DryRunResponse)
+	err := c.cc.Invoke(ctx, ActuatorPlugin_DryRun_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *actuatorPluginClient) Effect(ctx context.Context, in *EffectRequest, opts ...grpc.CallOption) (*Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Empty)
@@ -213,8 +266,10 @@ func (c *actuatorPluginClient) Effect(ctx context.Context, in *EffectRequest, op
 type ActuatorPluginServer interface {
 	// NextState should return a set of potential follow-up states for a given state if this actuator would potentially be used.
 	NextState(ActuatorPlugin_NextStateServer) error
-	// Perform should perform those actions of the plan that it is in charge of
-	Perform(context.Context, *PerformRequest) (*Empty, error)
+	// Perform should perform those actions of the plan that it is in charge of and report the outcome
+	Perform(context.Context, *PerformRequest) (*PerformResponse, error)
+	// DryRun should return the patches Perform would apply - without applying them.
+	DryRun(context.Context, *PerformRequest) (*DryRunResponse, error)
 	// Effect should (optionally) recalculate the effect this actuator has for ALL objectives for this workload.
 	Effect(context.Context, *EffectRequest) (*Empty, error)
 	mustEmbedUnimplementedActuatorPluginServer()
@@ -227,9 +282,12 @@ type UnimplementedActuatorPluginServer struct {
 func (UnimplementedActuatorPluginServer) NextState(ActuatorPlugin_NextStateServer) error {
 	return status.Errorf(codes.Unimplemented, "method NextState not implemented")
 }
-func (UnimplementedActuatorPluginServer) Perform(context.Context, *PerformRequest) (*Empty, error) {
+func (UnimplementedActuatorPluginServer) Perform(context.Context, *PerformRequest) (*PerformResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Perform not implemented")
 }
+func (UnimplementedActuatorPluginServer) DryRun(context.Context, *PerformRequest) (*DryRunResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DryRun not implemented")
+}
 func (UnimplementedActuatorPluginServer) Effect(context.Context, *EffectRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Effect not implemented")
 }
@@ -290,6 +348,24 @@ func _ActuatorPlugin_Perform_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ActuatorPlugin_DryRun_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PerformRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActuatorPluginServer).DryRun(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ActuatorPlugin_DryRun_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActuatorPluginServer).DryRun(ctx, req.(*PerformRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ActuatorPlugin_Effect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(EffectRequest)
 	if err := dec(in); err != nil {
@@ -319,6 +395,10 @@ var ActuatorPlugin_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Perform",
 			Handler:    _ActuatorPlugin_Perform_Handler,
 		},
+		{
+			MethodName: "DryRun",
+			Handler:    _ActuatorPlugin_DryRun_Handler,
+		},
 		{
 			MethodName: "Effect",
 			Handler:    _ActuatorPlugin_Effect_Handler,
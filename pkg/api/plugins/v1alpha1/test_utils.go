@@ -29,6 +29,8 @@ func generateActuatorGrpcValidationSet() *ActuatorGrpcValidationSet {
 		start: &protobufs.State{
 			Intent: &protobufs.Intent{
 				Key:        "test-my-objective",
+				ParentKey:  "default/my-group",
+				ParentKind: "IntentGroup",
 				Priority:   0.0,
 				TargetKey:  "my-deployment",
 				TargetKind: "Deployment",
@@ -52,21 +54,29 @@ func generateActuatorGrpcValidationSet() *ActuatorGrpcValidationSet {
 				TargetKind: "Deployment",
 				Objectives: map[string]float64{
 					"p99latency": 100,
+				},
+				Tolerations: map[string]float64{
+					"p99latency": 0.1,
 				}},
 			CurrentPods: nil,
 			CurrentData: nil,
 		},
-		profiles: map[string]*protobufs.Profile{"p99latency": {ProfileType: protobufs.ProfileType_LATENCY}},
+		profiles: map[string]*protobufs.Profile{"p99latency": {ProfileType: protobufs.ProfileType_LATENCY, TypeName: "latency"}},
 		end: []*protobufs.State{
 			{
 				Intent: &protobufs.Intent{
 					Key:        "end-objective",
+					ParentKey:  "default/my-group",
+					ParentKind: "IntentGroup",
 					Priority:   0.2,
 					TargetKey:  "my-deployment",
 					TargetKind: "Deployment",
 					Objectives: map[string]float64{
 						"p99latency": 222,
 					},
+					Tolerations: map[string]float64{
+						"p99latency": 0.1,
+					},
 				},
 				CurrentPods: map[string]*protobufs.PodState{"pod_0": {Availability: 0.6}},
 				CurrentData: map[string]*protobufs.DataEntry{"cpu_value": {Data: map[string]float64{"host0": 21.3}}},
@@ -100,6 +110,8 @@ func generateActuatorValidationSet() *ActuatorValidationSet {
 		start: &common.State{
 			Intent: common.Intent{
 				Key:        "test-my-objective",
+				ParentKey:  "default/my-group",
+				ParentKind: "IntentGroup",
 				Priority:   0.0,
 				TargetKey:  "my-deployment",
 				TargetKind: "Deployment",
@@ -120,6 +132,9 @@ func generateActuatorValidationSet() *ActuatorValidationSet {
 				TargetKind: "Deployment",
 				Objectives: map[string]float64{
 					"p99latency": 100,
+				},
+				Tolerations: map[string]float64{
+					"p99latency": 0.1,
 				}},
 			CurrentPods: nil,
 			CurrentData: nil,
@@ -129,12 +144,17 @@ func generateActuatorValidationSet() *ActuatorValidationSet {
 			{
 				Intent: common.Intent{
 					Key:        "end-objective",
+					ParentKey:  "default/my-group",
+					ParentKind: "IntentGroup",
 					Priority:   0.2,
 					TargetKey:  "my-deployment",
 					TargetKind: "Deployment",
 					Objectives: map[string]float64{
 						"p99latency": 222,
 					},
+					Tolerations: map[string]float64{
+						"p99latency": 0.1,
+					},
 				},
 				CurrentPods: map[string]common.PodState{"pod_0": {Availability: 0.6}},
 				CurrentData: map[string]map[string]float64{"cpu_value": {"host0": 21.3}},
@@ -23,6 +23,10 @@ type PInfo struct {
 	Endpoint string
 	// Plugin service API versions the plugin supports.
 	Version string
+	// Group the plugin is part of.
+	Group string
+	// ProfileTypes are the names of the profile types the plugin can influence.
+	ProfileTypes []string
 }
 
 // ActuatorsPluginManager interface for actuator plugins
@@ -38,6 +42,8 @@ type PluginManager interface {
 	Start() error
 	// Stop stops the grpc server responsible for plugin registrations
 	Stop() error
+	// Ready returns an error if the grpc server responsible for plugin registrations is not listening
+	Ready() error
 	// refreshRegisteredPlugin reconcile callback which checks if plugin connections are still alive and removes all dead connections
 	refreshRegisteredPlugin(retries int)
 }
@@ -59,4 +65,6 @@ type PluginManagerServer struct {
 	wg                       sync.WaitGroup
 	mu                       sync.Mutex
 	stop                     chan struct{}
+	// listening is set while the registration server is serving - guarded by mu.
+	listening bool
 }
@@ -0,0 +1,64 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Budget defines the resources an intent may use; zero values mean there is no limit.
+type Budget struct {
+	MinReplicas int
+	MaxReplicas int
+	// MaxContainerCPU defines the max CPU requests of a single container in millicores.
+	MaxContainerCPU int64
+	// MaxTotalCPU defines the max CPU requests of all containers of all replicas in millicores.
+	MaxTotalCPU int64
+}
+
+// replicas returns the number of replicas in a state.
+func replicas(state *State) int {
+	return len(state.CurrentPods)
+}
+
+// cpuRequests returns the max CPU requests of a single container, and the sum of the CPU requests of all containers of a POD.
+func cpuRequests(state *State) (int64, int64) {
+	var maxValue, sum int64
+	for key, value := range state.Resources {
+		// keys have the format <container index>_<resource name>_<requests|limits>.
+		parts := strings.Split(key, "_")
+		if len(parts) != 3 || parts[1] != "cpu" || parts[2] != "requests" {
+			continue
+		}
+		if value > maxValue {
+			maxValue = value
+		}
+		sum += value
+	}
+	return maxValue, sum
+}
+
+// Exceeded returns the constraints of the budget a state breaks, and which are broken less - or not at all - by the
+// state it is derived from; this way states moving back into the budget are still possible.
+func (b *Budget) Exceeded(previous *State, next *State) []string {
+	if b == nil {
+		return nil
+	}
+	var res []string
+	before, after := replicas(previous), replicas(next)
+	if b.MaxReplicas > 0 && after > b.MaxReplicas && after > before {
+		res = append(res, fmt.Sprintf("max replicas of %d", b.MaxReplicas))
+	}
+	if b.MinReplicas > 0 && after < b.MinReplicas && after < before {
+		res = append(res, fmt.Sprintf("min replicas of %d", b.MinReplicas))
+	}
+	containerBefore, podBefore := cpuRequests(previous)
+	containerAfter, podAfter := cpuRequests(next)
+	if b.MaxContainerCPU > 0 && containerAfter > b.MaxContainerCPU && containerAfter > containerBefore {
+		res = append(res, fmt.Sprintf("max CPU of %dm per container", b.MaxContainerCPU))
+	}
+	totalBefore, totalAfter := podBefore*int64(before), podAfter*int64(after)
+	if b.MaxTotalCPU > 0 && totalAfter > b.MaxTotalCPU && totalAfter > totalBefore {
+		res = append(res, fmt.Sprintf("max total CPU of %dm", b.MaxTotalCPU))
+	}
+	return res
+}
@@ -0,0 +1,62 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+// budgetState returns a state with the given number of replicas and CPU requests per container.
+func budgetState(replicas int, cpu ...int64) *State {
+	state := &State{CurrentPods: map[string]PodState{}, Resources: map[string]int64{}}
+	for i := 0; i < replicas; i++ {
+		state.CurrentPods[string(rune('a'+i))] = PodState{}
+	}
+	for i, value := range cpu {
+		state.Resources[string(rune('0'+i))+"_cpu_requests"] = value
+		state.Resources[string(rune('0'+i))+"_cpu_limits"] = 2 * value
+	}
+	return state
+}
+
+// Tests for success.
+
+// TestBudgetExceededForSuccess tests for success.
+func TestBudgetExceededForSuccess(t *testing.T) {
+	budget := &Budget{MaxReplicas: 3}
+	if res := budget.Exceeded(budgetState(3), budgetState(4)); len(res) != 1 {
+		t.Errorf("Expected the budget to be exceeded - got: %v.", res)
+	}
+}
+
+// Tests for sanity.
+
+// TestBudgetExceededForSanity tests for sanity.
+func TestBudgetExceededForSanity(t *testing.T) {
+	tests := []struct {
+		name     string
+		budget   *Budget
+		previous *State
+		next     *State
+		want     []string
+	}{
+		{name: "no-budget", budget: nil, previous: budgetState(1, 100), next: budgetState(10, 1000)},
+		{name: "no-limits", budget: &Budget{}, previous: budgetState(1, 100), next: budgetState(10, 1000)},
+		{name: "within", budget: &Budget{MinReplicas: 1, MaxReplicas: 4, MaxContainerCPU: 500, MaxTotalCPU: 2000}, previous: budgetState(2, 200), next: budgetState(4, 500)},
+		{name: "max-replicas", budget: &Budget{MaxReplicas: 4}, previous: budgetState(2), next: budgetState(5), want: []string{"max replicas of 4"}},
+		{name: "min-replicas", budget: &Budget{MinReplicas: 2}, previous: budgetState(2), next: budgetState(1), want: []string{"min replicas of 2"}},
+		{name: "container-cpu", budget: &Budget{MaxContainerCPU: 500}, previous: budgetState(1, 100, 500), next: budgetState(1, 100, 600), want: []string{"max CPU of 500m per container"}},
+		{name: "total-cpu", budget: &Budget{MaxTotalCPU: 1000}, previous: budgetState(2, 300, 100), next: budgetState(3, 300, 100), want: []string{"max total CPU of 1000m"}},
+		{name: "all", budget: &Budget{MaxReplicas: 1, MaxContainerCPU: 100, MaxTotalCPU: 100}, previous: budgetState(1, 100), next: budgetState(2, 200), want: []string{"max replicas of 1", "max CPU of 100m per container", "max total CPU of 100m"}},
+		// moving back into the budget is ok.
+		{name: "back-into-max-replicas", budget: &Budget{MaxReplicas: 4}, previous: budgetState(6), next: budgetState(5)},
+		{name: "back-into-min-replicas", budget: &Budget{MinReplicas: 4}, previous: budgetState(2), next: budgetState(3)},
+		{name: "back-into-total-cpu", budget: &Budget{MaxTotalCPU: 1000}, previous: budgetState(4, 400), next: budgetState(4, 300)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.budget.Exceeded(tt.previous, tt.next); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Exceeded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// NodeCapacity represents the CPU and memory of a node - in milli units, like the resources of a state - that are
+// allocatable, requested by the PODs running on it, and free.
+type NodeCapacity struct {
+	AllocatableCPU    int64
+	AllocatableMemory int64
+	RequestedCPU      int64
+	RequestedMemory   int64
+	FreeCPU           int64
+	FreeMemory        int64
+}
+
+// reserve updates the capacity for PODs requesting the given CPU and memory; negative values release resources.
+func (n *NodeCapacity) reserve(cpu int64, memory int64) {
+	n.RequestedCPU += cpu
+	n.RequestedMemory += memory
+	n.FreeCPU -= cpu
+	n.FreeMemory -= memory
+}
+
+// Headroom sums up the capacity of a set of nodes.
+func Headroom(nodes map[string]NodeCapacity) NodeCapacity {
+	var res NodeCapacity
+	for _, node := range nodes {
+		res.AllocatableCPU += node.AllocatableCPU
+		res.AllocatableMemory += node.AllocatableMemory
+		res.RequestedCPU += node.RequestedCPU
+		res.RequestedMemory += node.RequestedMemory
+		res.FreeCPU += node.FreeCPU
+		res.FreeMemory += node.FreeMemory
+	}
+	return res
+}
+
+// podRequests returns the sum of the CPU and memory requests of all containers of a POD.
+func podRequests(state *State) (int64, int64) {
+	var cpu, memory int64
+	for key, value := range state.Resources {
+		// keys have the format <container index>_<resource name>_<requests|limits>.
+		parts := strings.Split(key, "_")
+		if len(parts) != 3 || parts[2] != "requests" {
+			continue
+		}
+		switch parts[1] {
+		case "cpu":
+			cpu += value
+		case "memory":
+			memory += value
+		}
+	}
+	return cpu, memory
+}
+
+// place returns the node with the most free CPU which fits a POD - or, if none fits, the one with the most free CPU.
+func place(nodes map[string]NodeCapacity, cpu int64, memory int64) string {
+	names := make([]string, 0, len(nodes))
+	for name := range nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var res, fallback string
+	for _, name := range names {
+		node := nodes[name]
+		if fallback == "" || node.FreeCPU > nodes[fallback].FreeCPU {
+			fallback = name
+		}
+		if node.FreeCPU >= cpu && node.FreeMemory >= memory && (res == "" || node.FreeCPU > nodes[res].FreeCPU) {
+			res = name
+		}
+	}
+	if res == "" {
+		return fallback
+	}
+	return res
+}
+
+// UpdateCapacity updates the capacity of the nodes - taken from the state this state is derived from - for the PODs
+// added or removed, and the changes in their requests. PODs added are placed on the node with the most free CPU
+// which fits them. Does nothing if the capacity of the nodes is unknown.
+func (one *State) UpdateCapacity(previous *State) {
+	if previous.Nodes == nil {
+		return
+	}
+	nodes := make(map[string]NodeCapacity, len(previous.Nodes))
+	for name, node := range previous.Nodes {
+		nodes[name] = node
+	}
+	reserve := func(name string, cpu int64, memory int64) {
+		if node, ok := nodes[name]; ok {
+			node.reserve(cpu, memory)
+			nodes[name] = node
+		}
+	}
+	cpuBefore, memoryBefore := podRequests(previous)
+	cpuAfter, memoryAfter := podRequests(one)
+	for name, pod := range previous.CurrentPods {
+		if _, ok := one.CurrentPods[name]; !ok {
+			reserve(pod.NodeName, -cpuBefore, -memoryBefore)
+		}
+	}
+	names := make([]string, 0, len(one.CurrentPods))
+	for name := range one.CurrentPods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if pod, ok := previous.CurrentPods[name]; ok {
+			reserve(pod.NodeName, cpuAfter-cpuBefore, memoryAfter-memoryBefore)
+			continue
+		}
+		pod := one.CurrentPods[name]
+		if _, ok := nodes[pod.NodeName]; !ok {
+			pod.NodeName = place(nodes, cpuAfter, memoryAfter)
+			one.CurrentPods[name] = pod
+		}
+		reserve(pod.NodeName, cpuAfter, memoryAfter)
+	}
+	one.Nodes = nodes
+	one.Headroom = Headroom(nodes)
+}
+
+// Overcommitted returns the resources of the nodes of which more is requested than allocatable in this state, and
+// which were not overcommitted as much in the state it is derived from.
+func (one *State) Overcommitted(previous *State) []string {
+	var res []string
+	for name, node := range one.Nodes {
+		before, ok := previous.Nodes[name]
+		if node.FreeCPU < 0 && (!ok || node.FreeCPU < before.FreeCPU) {
+			res = append(res, fmt.Sprintf("cpu on node %s", name))
+		}
+		if node.FreeMemory < 0 && (!ok || node.FreeMemory < before.FreeMemory) {
+			res = append(res, fmt.Sprintf("memory on node %s", name))
+		}
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,107 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+// capacityState returns a state with a POD on node0 requesting the given CPU - on a cluster in which 1 CPU is free on
+// node0 and 2 CPUs on node1.
+func capacityState(cpu int64) *State {
+	return &State{
+		CurrentPods: map[string]PodState{"pod_0": {NodeName: "node0"}},
+		Resources:   map[string]int64{"0_cpu_requests": cpu, "0_memory_requests": 1000, "0_cpu_limits": 2 * cpu},
+		Nodes: map[string]NodeCapacity{
+			"node0": {AllocatableCPU: 4000, RequestedCPU: 3000, FreeCPU: 1000, AllocatableMemory: 4000, RequestedMemory: 1000, FreeMemory: 3000},
+			"node1": {AllocatableCPU: 2000, FreeCPU: 2000, AllocatableMemory: 4000, FreeMemory: 4000},
+		},
+	}
+}
+
+// Tests for success.
+
+// TestUpdateCapacityForSuccess tests for success.
+func TestUpdateCapacityForSuccess(t *testing.T) {
+	previous := capacityState(500)
+	next := previous.DeepCopy()
+	next.Resources["0_cpu_requests"] = 1000
+	next.UpdateCapacity(previous)
+	if next.Nodes["node0"].FreeCPU != 500 || next.Nodes["node0"].RequestedCPU != 3500 || next.Headroom.FreeCPU != 2500 {
+		t.Errorf("Expected the POD to request 0.5 CPU more on node0 - got: %v.", next.Nodes)
+	}
+	if previous.Nodes["node0"].FreeCPU != 1000 {
+		t.Error("Capacity of the previous state should not be changed.")
+	}
+}
+
+// Tests for sanity.
+
+// TestUpdateCapacityForSanity tests for sanity.
+func TestUpdateCapacityForSanity(t *testing.T) {
+	// new PODs are placed on the node with the most free CPU which fits them - or, if none does, the most free CPU.
+	previous := capacityState(1500)
+	next := previous.DeepCopy()
+	next.CurrentPods["dummy@1"] = PodState{}
+	next.CurrentPods["dummy@2"] = PodState{}
+	next.UpdateCapacity(previous)
+	if next.CurrentPods["dummy@1"].NodeName != "node1" || next.CurrentPods["dummy@2"].NodeName != "node0" {
+		t.Errorf("Expected the new PODs to be placed on node1 & node0 - got: %v.", next.CurrentPods)
+	}
+	if next.Nodes["node0"].FreeCPU != -500 || next.Nodes["node1"].FreeMemory != 3000 {
+		t.Errorf("Expected node0 to be overcommitted - got: %v.", next.Nodes)
+	}
+
+	// removed PODs free their resources.
+	last := next.DeepCopy()
+	delete(last.CurrentPods, "pod_0")
+	last.UpdateCapacity(&next)
+	if last.Nodes["node0"].FreeCPU != 1000 || last.Headroom.FreeCPU != 1500 {
+		t.Errorf("Expected the resources of the removed POD to be freed - got: %v.", last.Nodes)
+	}
+
+	// unknown capacity stays unknown.
+	unknown := State{CurrentPods: map[string]PodState{"pod_0": {}}}
+	other := unknown.DeepCopy()
+	other.CurrentPods["dummy@1"] = PodState{}
+	other.UpdateCapacity(&unknown)
+	if other.Nodes != nil || other.CurrentPods["dummy@1"].NodeName != "" {
+		t.Errorf("Expected the capacity to stay unknown - got: %v.", other.Nodes)
+	}
+}
+
+// TestOvercommittedForSanity tests for sanity.
+func TestOvercommittedForSanity(t *testing.T) {
+	tests := []struct {
+		name    string
+		cpu     int64
+		pods    int
+		reduced bool
+		want    []string
+	}{
+		{name: "fits", cpu: 1500, pods: 1},
+		{name: "scale-up-fits", cpu: 2000},
+		{name: "scale-up", cpu: 2500, want: []string{"cpu on node node0"}},
+		{name: "scale-out", cpu: 1500, pods: 2, want: []string{"cpu on node node0"}},
+		// nodes which are overcommitted already are ok as long as they do not get worse.
+		{name: "scale-down-overcommitted", cpu: 800, reduced: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := capacityState(1000)
+			if tt.reduced {
+				node := previous.Nodes["node0"]
+				node.FreeCPU = -500
+				previous.Nodes["node0"] = node
+			}
+			next := previous.DeepCopy()
+			next.Resources["0_cpu_requests"] = tt.cpu
+			for i := 0; i < tt.pods; i++ {
+				next.CurrentPods["dummy@"+string(rune('0'+i))] = PodState{}
+			}
+			next.UpdateCapacity(previous)
+			if got := next.Overcommitted(previous); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Overcommitted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package common
 import (
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/url"
 	"os"
 	goRuntime "runtime"
@@ -23,8 +24,34 @@ type Config struct {
 
 // GenericConfig captures generic configuration fields.
 type GenericConfig struct {
-	MongoEndpoint string `json:"mongo_endpoint"`
-	LogFile       string `json:"log_file"`
+	MongoEndpoint  string               `json:"mongo_endpoint"`
+	LogFile        string               `json:"log_file"`
+	LeaderElection LeaderElectionConfig `json:"leader_election"`
+	Metrics        MetricsConfig        `json:"metrics"`
+	Probes         ProbesConfig         `json:"probes"`
+}
+
+// MetricsConfig holds the configs for exposing metrics about the planner's internals.
+type MetricsConfig struct {
+	// Address the /metrics endpoint listens on - e.g. ":8080"; metrics are not exposed if empty.
+	Address string `json:"address"`
+}
+
+// ProbesConfig holds the configs for exposing the health & readiness probes of the planner.
+type ProbesConfig struct {
+	// Address the /healthz and /readyz endpoints listen on - e.g. ":8081"; probes are not exposed if empty.
+	Address string `json:"address"`
+}
+
+// LeaderElectionConfig holds the configs for electing the replica of the planner which executes the plans.
+type LeaderElectionConfig struct {
+	Enabled   bool   `json:"enabled"`
+	Namespace string `json:"namespace"`
+	LeaseName string `json:"lease_name"`
+	// LeaseDuration, RenewDeadline and RetryPeriod are given in seconds.
+	LeaseDuration int `json:"lease_duration"`
+	RenewDeadline int `json:"renew_deadline"`
+	RetryPeriod   int `json:"retry_period"`
 }
 
 // ControllerConfig holds controller related configs.
@@ -35,12 +62,42 @@ type ControllerConfig struct {
 	ControllerTimeout int    `json:"controller_timeout"`
 	PlanCacheTTL      int    `json:"plan_cache_ttl"`
 	PlanCacheTimeout  int    `json:"plan_cache_timeout"`
+	MinReplanInterval int    `json:"min_replan_interval"`
+	ReplanBackoffBase int    `json:"replan_backoff_base"`
+	ReplanBackoffMax  int    `json:"replan_backoff_max"`
 	TelemetryEndpoint string `json:"telemetry_endpoint"`
 	HostField         string `json:"host_field"`
 	Metrics           []struct {
 		Name  string `json:"name,omitempty"`
 		Query string `json:"query,omitempty"`
 	} `json:"metrics"`
+	// ShadowMode makes the planner only record the changes it would apply instead of applying them; ShadowNamespaces
+	// limits this to the intents in the given namespaces.
+	ShadowMode       bool     `json:"shadow_mode"`
+	ShadowNamespaces []string `json:"shadow_namespaces"`
+	// SettlingPeriod is the time in ms after executing a plan at which the observed objectives are compared to the
+	// predicted ones; the plan is rolled back if they are worse by more than the RollbackTolerance - e.g. 0.1 for 10%.
+	SettlingPeriod    int     `json:"settling_period"`
+	RollbackTolerance float64 `json:"rollback_tolerance"`
+	// Cooldowns holds per actuator - referenced by name or group - for how long it is not used for an intent after it
+	// changed the intent's target.
+	Cooldowns map[string]CooldownConfig `json:"cooldowns"`
+	// ArbitrationWindow is the time in ms the plans for different intents are collected before they are checked against
+	// the capacity of the nodes - admitting those of higher priority intents first; 0 disables the arbitration.
+	ArbitrationWindow int `json:"arbitration_window"`
+}
+
+// CooldownConfig defines the time in ms an actuator is not used for an intent after it changed the intent's target;
+// if AfterRollout is set, the cooldown only starts once the change was rolled out.
+type CooldownConfig struct {
+	Period       int  `json:"period"`
+	AfterRollout bool `json:"after_rollout"`
+}
+
+// ProfileTypeConfig defines a profile type and whether profiles of that type are minimized by default.
+type ProfileTypeConfig struct {
+	Name     string `json:"name"`
+	Minimize bool   `json:"minimize"`
 }
 
 // MonitorConfig holds monitor related configs.
@@ -51,9 +108,14 @@ type MonitorConfig struct {
 	Profile struct {
 		Workers int    `json:"workers"`
 		Queries string `json:"queries"`
+		// ConfigMap optionally names a ConfigMap - as "<namespace>/<name>" - holding the default profile definitions.
+		ConfigMap string `json:"config_map,omitempty"`
+		// Types are additional profile types besides the built-in ones.
+		Types []ProfileTypeConfig `json:"types,omitempty"`
 	} `json:"profile"`
 	Intent struct {
-		Workers int `json:"workers"`
+		Workers      int `json:"workers"`
+		ResyncPeriod int `json:"resync_period"`
 	} `json:"intent"`
 }
 
@@ -80,6 +142,12 @@ const (
 	MaxPlanCacheTimeout = 50000
 	// MaxPlanCacheTTL is max time-to-live (ms) for an entry in the planner's cache.
 	MaxPlanCacheTTL = 500000
+	// MaxReplanInterval is max time (ms) between two plans for the same intent or to back off after failed plans.
+	MaxReplanInterval = 3600000
+	// MaxResyncPeriod is max period (s) between each re-evaluation of the workloads selected by intents.
+	MaxResyncPeriod = 3600
+	// MaxLeaseDuration is max duration (s) a non-leader waits before trying to acquire the lease.
+	MaxLeaseDuration = 300
 )
 
 // maximumWorkers maximum number of logical cores for workers.
@@ -125,9 +193,30 @@ func ParseConfig(filename string) (Config, error) {
 		result.Controller.PlanCacheTimeout <= 0 ||
 		result.Controller.PlanCacheTimeout > MaxPlanCacheTimeout ||
 		result.Controller.PlanCacheTTL <= 0 ||
-		result.Controller.PlanCacheTTL > MaxPlanCacheTTL {
+		result.Controller.PlanCacheTTL > MaxPlanCacheTTL ||
+		result.Controller.MinReplanInterval < 0 ||
+		result.Controller.MinReplanInterval > MaxReplanInterval ||
+		result.Controller.ReplanBackoffBase < 0 ||
+		result.Controller.ReplanBackoffBase > MaxReplanInterval ||
+		result.Controller.ReplanBackoffMax < 0 ||
+		result.Controller.ReplanBackoffMax > MaxReplanInterval ||
+		result.Controller.SettlingPeriod < 0 ||
+		result.Controller.SettlingPeriod > MaxReplanInterval ||
+		result.Controller.RollbackTolerance < 0 ||
+		result.Controller.ArbitrationWindow < 0 ||
+		result.Controller.ArbitrationWindow > MaxReplanInterval ||
+		result.Monitor.Intent.ResyncPeriod < 0 ||
+		result.Monitor.Intent.ResyncPeriod > MaxResyncPeriod {
 		return *result, fmt.Errorf("invalid input value: Out of the provided limits")
 	}
+	if result.Controller.ReplanBackoffMax > 0 && result.Controller.ReplanBackoffBase > result.Controller.ReplanBackoffMax {
+		return *result, fmt.Errorf("invalid replan backoff: base exceeds max")
+	}
+	for name, cooldown := range result.Controller.Cooldowns {
+		if cooldown.Period < 0 || cooldown.Period > MaxReplanInterval {
+			return *result, fmt.Errorf("invalid cooldown for actuator '%s': Out of the provided limits", name)
+		}
+	}
 	if invalidWorkers(result.Controller.Workers) ||
 		invalidWorkers(result.Monitor.Profile.Workers) ||
 		invalidWorkers(result.Monitor.Intent.Workers) {
@@ -144,6 +233,39 @@ func ParseConfig(filename string) (Config, error) {
 	if !checkURL(result.Controller.TelemetryEndpoint) || !checkURL(result.Generic.MongoEndpoint) {
 		return *result, fmt.Errorf("invalid URL")
 	}
+	if result.Monitor.Profile.ConfigMap != "" {
+		parts := strings.Split(result.Monitor.Profile.ConfigMap, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return *result, fmt.Errorf("invalid config map name: '%s'", result.Monitor.Profile.ConfigMap)
+		}
+	}
+	if result.Generic.LeaderElection.Enabled {
+		election := result.Generic.LeaderElection
+		if election.Namespace == "" || election.LeaseName == "" {
+			return *result, fmt.Errorf("invalid leader election config: namespace and lease name need to be set")
+		}
+		if election.RetryPeriod <= 0 ||
+			election.RenewDeadline <= election.RetryPeriod ||
+			election.LeaseDuration <= election.RenewDeadline ||
+			election.LeaseDuration > MaxLeaseDuration {
+			return *result, fmt.Errorf("invalid leader election config: expected 0 < retry_period < renew_deadline < lease_duration <= %d", MaxLeaseDuration)
+		}
+	}
+	if result.Generic.Metrics.Address != "" {
+		if _, _, err := net.SplitHostPort(result.Generic.Metrics.Address); err != nil {
+			return *result, fmt.Errorf("invalid metrics address: %v", err)
+		}
+	}
+	if result.Generic.Probes.Address != "" {
+		if _, _, err := net.SplitHostPort(result.Generic.Probes.Address); err != nil {
+			return *result, fmt.Errorf("invalid probes address: %v", err)
+		}
+	}
+	for _, item := range result.Monitor.Profile.Types {
+		if !profileTypeFormat.MatchString(item.Name) {
+			return *result, fmt.Errorf("invalid profile type name: '%s'", item.Name)
+		}
+	}
 
 	return *result, nil
 }
@@ -3,6 +3,7 @@ package common
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -36,7 +37,242 @@ func TestParseConfigForFailure(t *testing.T) {
 
 // Tests for sanity.
 
-// n/a
+// TestParseLeaderElectionConfigForSanity tests for sanity.
+func TestParseLeaderElectionConfigForSanity(t *testing.T) {
+	tests := []struct {
+		name     string
+		election LeaderElectionConfig
+		wantErr  bool
+	}{
+		{name: "disabled", election: LeaderElectionConfig{}, wantErr: false},
+		{name: "valid", election: LeaderElectionConfig{Enabled: true, Namespace: "ido", LeaseName: "planner", LeaseDuration: 15, RenewDeadline: 10, RetryPeriod: 2}, wantErr: false},
+		{name: "no-lease-name", election: LeaderElectionConfig{Enabled: true, Namespace: "ido", LeaseDuration: 15, RenewDeadline: 10, RetryPeriod: 2}, wantErr: true},
+		{name: "no-retry-period", election: LeaderElectionConfig{Enabled: true, Namespace: "ido", LeaseName: "planner", LeaseDuration: 15, RenewDeadline: 10}, wantErr: true},
+		{name: "renew-after-lease", election: LeaderElectionConfig{Enabled: true, Namespace: "ido", LeaseName: "planner", LeaseDuration: 10, RenewDeadline: 15, RetryPeriod: 2}, wantErr: true},
+		{name: "lease-too-long", election: LeaderElectionConfig{Enabled: true, Namespace: "ido", LeaseName: "planner", LeaseDuration: 3600, RenewDeadline: 10, RetryPeriod: 2}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := SetupTestConfigFile(1, 100, 30, 45, 45000, 5000,
+				1, 1, 1,
+				0, 2000, 10, 33333,
+				"mongodb://planner-mongodb-service:27017/",
+				"http://prometheus-service.telemetry:9090/api/v1/query",
+				"exported_instance",
+				"cpu_value",
+				"avg(collectd_cpu_percent{exported_instance=~\"%s\"})by(exported_instance)",
+				"artefacts/examples/default_queries.json",
+				"plugin-manager-service",
+				"")
+			cfg.Generic.LeaderElection = tt.election
+			raw, err := json.Marshal(cfg)
+			if err != nil {
+				t.Fatalf("Could not marshal config: %v", err)
+			}
+			filename := filepath.Join(t.TempDir(), "config.json")
+			if err = os.WriteFile(filename, raw, 0600); err != nil {
+				t.Fatalf("Could not write config: %v", err)
+			}
+			res, err := ParseConfig(filename)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && res.Generic.LeaderElection != tt.election {
+				t.Errorf("ParseConfig() = %v, want %v", res.Generic.LeaderElection, tt.election)
+			}
+		})
+	}
+}
+
+// TestParseMetricsConfigForSanity tests for sanity.
+func TestParseMetricsConfigForSanity(t *testing.T) {
+	tests := []struct {
+		name    string
+		metrics MetricsConfig
+		wantErr bool
+	}{
+		{name: "disabled", metrics: MetricsConfig{}, wantErr: false},
+		{name: "port-only", metrics: MetricsConfig{Address: ":8080"}, wantErr: false},
+		{name: "host-and-port", metrics: MetricsConfig{Address: "localhost:8080"}, wantErr: false},
+		{name: "no-port", metrics: MetricsConfig{Address: "8080"}, wantErr: true},
+		{name: "invalid", metrics: MetricsConfig{Address: "a:b:c"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := SetupTestConfigFile(1, 100, 30, 45, 45000, 5000,
+				1, 1, 1,
+				0, 2000, 10, 33333,
+				"mongodb://planner-mongodb-service:27017/",
+				"http://prometheus-service.telemetry:9090/api/v1/query",
+				"exported_instance",
+				"cpu_value",
+				"avg(collectd_cpu_percent{exported_instance=~\"%s\"})by(exported_instance)",
+				"artefacts/examples/default_queries.json",
+				"plugin-manager-service",
+				"")
+			cfg.Generic.Metrics = tt.metrics
+			raw, err := json.Marshal(cfg)
+			if err != nil {
+				t.Fatalf("Could not marshal config: %v", err)
+			}
+			filename := filepath.Join(t.TempDir(), "config.json")
+			if err = os.WriteFile(filename, raw, 0600); err != nil {
+				t.Fatalf("Could not write config: %v", err)
+			}
+			res, err := ParseConfig(filename)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && res.Generic.Metrics != tt.metrics {
+				t.Errorf("ParseConfig() = %v, want %v", res.Generic.Metrics, tt.metrics)
+			}
+		})
+	}
+}
+
+// TestParseProbesConfigForSanity tests for sanity.
+func TestParseProbesConfigForSanity(t *testing.T) {
+	tests := []struct {
+		name    string
+		probes  ProbesConfig
+		wantErr bool
+	}{
+		{name: "disabled", probes: ProbesConfig{}, wantErr: false},
+		{name: "port-only", probes: ProbesConfig{Address: ":8081"}, wantErr: false},
+		{name: "host-and-port", probes: ProbesConfig{Address: "localhost:8081"}, wantErr: false},
+		{name: "no-port", probes: ProbesConfig{Address: "8080"}, wantErr: true},
+		{name: "invalid", probes: ProbesConfig{Address: "a:b:c"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := SetupTestConfigFile(1, 100, 30, 45, 45000, 5000,
+				1, 1, 1,
+				0, 2000, 10, 33333,
+				"mongodb://planner-mongodb-service:27017/",
+				"http://prometheus-service.telemetry:9090/api/v1/query",
+				"exported_instance",
+				"cpu_value",
+				"avg(collectd_cpu_percent{exported_instance=~\"%s\"})by(exported_instance)",
+				"artefacts/examples/default_queries.json",
+				"plugin-manager-service",
+				"")
+			cfg.Generic.Probes = tt.probes
+			raw, err := json.Marshal(cfg)
+			if err != nil {
+				t.Fatalf("Could not marshal config: %v", err)
+			}
+			filename := filepath.Join(t.TempDir(), "config.json")
+			if err = os.WriteFile(filename, raw, 0600); err != nil {
+				t.Fatalf("Could not write config: %v", err)
+			}
+			res, err := ParseConfig(filename)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && res.Generic.Probes != tt.probes {
+				t.Errorf("ParseConfig() = %v, want %v", res.Generic.Probes, tt.probes)
+			}
+		})
+	}
+}
+
+// TestParseReplanConfigForSanity tests for sanity.
+func TestParseReplanConfigForSanity(t *testing.T) {
+	tests := []struct {
+		name        string
+		minInterval int
+		base        int
+		max         int
+		wantErr     bool
+	}{
+		{name: "defaults", wantErr: false},
+		{name: "all-set", minInterval: 10000, base: 1000, max: 60000, wantErr: false},
+		{name: "only-base", base: 1000, wantErr: false},
+		{name: "negative-interval", minInterval: -1, wantErr: true},
+		{name: "interval-too-large", minInterval: MaxReplanInterval + 1, wantErr: true},
+		{name: "negative-base", base: -1, wantErr: true},
+		{name: "base-exceeds-max", base: 2000, max: 1000, wantErr: true},
+		{name: "max-too-large", max: MaxReplanInterval + 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := SetupTestConfigFile(1, 100, 30, 45, 45000, 5000,
+				1, 1, 1,
+				0, 2000, 10, 33333,
+				"mongodb://planner-mongodb-service:27017/",
+				"http://prometheus-service.telemetry:9090/api/v1/query",
+				"exported_instance",
+				"cpu_value",
+				"avg(collectd_cpu_percent{exported_instance=~\"%s\"})by(exported_instance)",
+				"artefacts/examples/default_queries.json",
+				"plugin-manager-service",
+				"")
+			cfg.Controller.MinReplanInterval = tt.minInterval
+			cfg.Controller.ReplanBackoffBase = tt.base
+			cfg.Controller.ReplanBackoffMax = tt.max
+			raw, err := json.Marshal(cfg)
+			if err != nil {
+				t.Fatalf("Could not marshal config: %v", err)
+			}
+			filename := filepath.Join(t.TempDir(), "config.json")
+			if err = os.WriteFile(filename, raw, 0600); err != nil {
+				t.Fatalf("Could not write config: %v", err)
+			}
+			res, err := ParseConfig(filename)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && res.Controller.MinReplanInterval != tt.minInterval {
+				t.Errorf("ParseConfig() = %v, want %v", res.Controller.MinReplanInterval, tt.minInterval)
+			}
+		})
+	}
+}
+
+// TestParseCooldownConfigForSanity tests for sanity.
+func TestParseCooldownConfigForSanity(t *testing.T) {
+	tests := []struct {
+		name      string
+		cooldowns map[string]CooldownConfig
+		wantErr   bool
+	}{
+		{name: "defaults", wantErr: false},
+		{name: "all-set", cooldowns: map[string]CooldownConfig{"scaleOut": {Period: 60000}, "platform": {Period: 300000, AfterRollout: true}}, wantErr: false},
+		{name: "negative-period", cooldowns: map[string]CooldownConfig{"scaleOut": {Period: -1}}, wantErr: true},
+		{name: "period-too-large", cooldowns: map[string]CooldownConfig{"scaleOut": {Period: MaxReplanInterval + 1}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := SetupTestConfigFile(1, 100, 30, 45, 45000, 5000,
+				1, 1, 1,
+				0, 2000, 10, 33333,
+				"mongodb://planner-mongodb-service:27017/",
+				"http://prometheus-service.telemetry:9090/api/v1/query",
+				"exported_instance",
+				"cpu_value",
+				"avg(collectd_cpu_percent{exported_instance=~\"%s\"})by(exported_instance)",
+				"artefacts/examples/default_queries.json",
+				"plugin-manager-service",
+				"")
+			cfg.Controller.Cooldowns = tt.cooldowns
+			raw, err := json.Marshal(cfg)
+			if err != nil {
+				t.Fatalf("Could not marshal config: %v", err)
+			}
+			filename := filepath.Join(t.TempDir(), "config.json")
+			if err = os.WriteFile(filename, raw, 0600); err != nil {
+				t.Fatalf("Could not write config: %v", err)
+			}
+			res, err := ParseConfig(filename)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(res.Controller.Cooldowns, tt.cooldowns) {
+				t.Errorf("ParseConfig() = %v, want %v", res.Controller.Cooldowns, tt.cooldowns)
+			}
+		})
+	}
+}
 
 func TestCheckURL(t *testing.T) {
 	type args struct {
@@ -199,14 +435,17 @@ func TestParseConfig(t *testing.T) {
 						Workers: 2,
 					},
 					Profile: struct {
-						Workers int    "json:\"workers\""
-						Queries string "json:\"queries\""
+						Workers   int                 "json:\"workers\""
+						Queries   string              "json:\"queries\""
+						ConfigMap string              "json:\"config_map,omitempty\""
+						Types     []ProfileTypeConfig "json:\"types,omitempty\""
 					}{
 						Workers: 2,
 						Queries: "artefacts/examples/default_queries.json",
 					},
 					Intent: struct {
-						Workers int "json:\"workers\""
+						Workers      int "json:\"workers\""
+						ResyncPeriod int "json:\"resync_period\""
 					}{
 						Workers: 2,
 					},
@@ -260,14 +499,17 @@ func TestParseConfig(t *testing.T) {
 						Workers: 2,
 					},
 					Profile: struct {
-						Workers int    "json:\"workers\""
-						Queries string "json:\"queries\""
+						Workers   int                 "json:\"workers\""
+						Queries   string              "json:\"queries\""
+						ConfigMap string              "json:\"config_map,omitempty\""
+						Types     []ProfileTypeConfig "json:\"types,omitempty\""
 					}{
 						Workers: 2,
 						Queries: "artefacts/examples/default_queries.json",
 					},
 					Intent: struct {
-						Workers int "json:\"workers\""
+						Workers      int "json:\"workers\""
+						ResyncPeriod int "json:\"resync_period\""
 					}{
 						Workers: 2,
 					},
@@ -321,14 +563,17 @@ func TestParseConfig(t *testing.T) {
 						Workers: 2,
 					},
 					Profile: struct {
-						Workers int    "json:\"workers\""
-						Queries string "json:\"queries\""
+						Workers   int                 "json:\"workers\""
+						Queries   string              "json:\"queries\""
+						ConfigMap string              "json:\"config_map,omitempty\""
+						Types     []ProfileTypeConfig "json:\"types,omitempty\""
 					}{
 						Workers: 2,
 						Queries: "artefacts/examples/default_queries.json",
 					},
 					Intent: struct {
-						Workers int "json:\"workers\""
+						Workers      int "json:\"workers\""
+						ResyncPeriod int "json:\"resync_period\""
 					}{
 						Workers: 2,
 					},
@@ -382,14 +627,17 @@ func TestParseConfig(t *testing.T) {
 						Workers: 2,
 					},
 					Profile: struct {
-						Workers int    "json:\"workers\""
-						Queries string "json:\"queries\""
+						Workers   int                 "json:\"workers\""
+						Queries   string              "json:\"queries\""
+						ConfigMap string              "json:\"config_map,omitempty\""
+						Types     []ProfileTypeConfig "json:\"types,omitempty\""
 					}{
 						Workers: 2,
 						Queries: "artefacts/examples/default_queries.json",
 					},
 					Intent: struct {
-						Workers int "json:\"workers\""
+						Workers      int "json:\"workers\""
+						ResyncPeriod int "json:\"resync_period\""
 					}{
 						Workers: 2,
 					},
@@ -486,14 +734,17 @@ func SetupTestConfigFile(
 				Workers: podWorker,
 			},
 			Profile: struct {
-				Workers int    "json:\"workers\""
-				Queries string "json:\"queries\""
+				Workers   int                 "json:\"workers\""
+				Queries   string              "json:\"queries\""
+				ConfigMap string              "json:\"config_map,omitempty\""
+				Types     []ProfileTypeConfig "json:\"types,omitempty\""
 			}{
 				Workers: profileWorker,
 				Queries: profileQuery,
 			},
 			Intent: struct {
-				Workers int "json:\"workers\""
+				Workers      int "json:\"workers\""
+				ResyncPeriod int "json:\"resync_period\""
 			}{
 				Workers: intentWorker,
 			},
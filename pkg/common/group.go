@@ -0,0 +1,130 @@
+package common
+
+import (
+	"math"
+	"strings"
+)
+
+// Aggregation defines how the values observed for the members of a group combine into the end-to-end value.
+type Aggregation int
+
+const (
+	// SumAggregation adds up the values of the members - e.g. the latencies along a chain of services.
+	SumAggregation Aggregation = iota
+	// MaxAggregation uses the largest value of all members.
+	MaxAggregation
+	// MinAggregation uses the smallest value of all members - e.g. the throughput of a chain of services.
+	MinAggregation
+	// ProductAggregation multiplies the values of the members - e.g. the availabilities along a chain of services.
+	ProductAggregation
+)
+
+// AggregationFromText converts string into the right int.
+func AggregationFromText(text string) Aggregation {
+	switch strings.ToLower(text) {
+	default:
+		return SumAggregation
+	case "max":
+		return MaxAggregation
+	case "min":
+		return MinAggregation
+	case "product":
+		return ProductAggregation
+	}
+}
+
+// Shares normalizes the shares of the members of a group so they add up to 1.0; members without a share - zero or
+// less - split the remainder evenly. If the shares do not add up to 1.0, they are scaled accordingly.
+func Shares(shares []float64) []float64 {
+	res := make([]float64, len(shares))
+	total := 0.0
+	unset := 0
+	for _, share := range shares {
+		if share > 0 {
+			total += share
+		} else {
+			unset++
+		}
+	}
+	remainder := 0.0
+	if unset > 0 && total < 1.0 {
+		remainder = (1.0 - total) / float64(unset)
+	} else if unset > 0 {
+		// nothing left to split; treat the members as if they had the average share.
+		remainder = total / float64(len(shares)-unset)
+	}
+	sum := 0.0
+	for i, share := range shares {
+		if share > 0 {
+			res[i] = share
+		} else {
+			res[i] = remainder
+		}
+		sum += res[i]
+	}
+	if sum > 0 {
+		for i := range res {
+			res[i] /= sum
+		}
+	}
+	return res
+}
+
+// SplitTarget returns the target value for a member with the given share of the end-to-end target value.
+func (a Aggregation) SplitTarget(target float64, share float64) float64 {
+	switch a {
+	case SumAggregation:
+		return target * share
+	case ProductAggregation:
+		return math.Pow(target, share)
+	default:
+		// each member needs to meet the end-to-end target on its own.
+		return target
+	}
+}
+
+// Combine combines the values observed for the members of a group into the end-to-end value.
+func (a Aggregation) Combine(values []float64) float64 {
+	if len(values) == 0 {
+		return 0.0
+	}
+	res := values[0]
+	for _, value := range values[1:] {
+		switch a {
+		case SumAggregation:
+			res += value
+		case MaxAggregation:
+			res = math.Max(res, value)
+		case MinAggregation:
+			res = math.Min(res, value)
+		case ProductAggregation:
+			res *= value
+		}
+	}
+	return res
+}
+
+// Bottleneck returns the index of the member whose observed value is furthest off - relative to its target value -
+// in the wrong direction; returns -1 if there are no members with valid values and targets.
+func Bottleneck(values []float64, targets []float64, minimize bool) int {
+	res := -1
+	worst := 0.0
+	for i, value := range values {
+		if i >= len(targets) || value < 0 || targets[i] <= 0 {
+			continue
+		}
+		ratio := value / targets[i]
+		if !minimize {
+			if value == 0 {
+				ratio = math.Inf(1)
+			} else {
+				ratio = targets[i] / value
+			}
+		}
+		if res < 0 || ratio > worst {
+			res = i
+			worst = ratio
+		}
+	}
+	return res
+}
@@ -0,0 +1,108 @@
+package common
+
+import (
+	"math"
+	"testing"
+)
+
+// Tests for success.
+
+// TestSharesForSuccess tests for success.
+func TestSharesForSuccess(t *testing.T) {
+	res := Shares([]float64{0.5, 0, 0})
+	if res[0] != 0.5 || res[1] != 0.25 || res[2] != 0.25 {
+		t.Errorf("Unexpected shares: %v.", res)
+	}
+}
+
+// Tests for sanity.
+
+// TestAggregationFromTextForSanity tests for sanity.
+func TestAggregationFromTextForSanity(t *testing.T) {
+	tests := map[string]Aggregation{"": SumAggregation, "sum": SumAggregation, "Max": MaxAggregation, "min": MinAggregation, "product": ProductAggregation, "foo": SumAggregation}
+	for text, want := range tests {
+		if got := AggregationFromText(text); got != want {
+			t.Errorf("AggregationFromText(%s) = %v, want %v", text, got, want)
+		}
+	}
+}
+
+// TestSharesForSanity tests for sanity.
+func TestSharesForSanity(t *testing.T) {
+	tests := []struct {
+		name   string
+		shares []float64
+		want   []float64
+	}{
+		{name: "even", shares: []float64{0, 0, 0, 0}, want: []float64{0.25, 0.25, 0.25, 0.25}},
+		{name: "explicit", shares: []float64{0.2, 0.8}, want: []float64{0.2, 0.8}},
+		{name: "scaled", shares: []float64{0.1, 0.3}, want: []float64{0.25, 0.75}},
+		{name: "nothing-left", shares: []float64{0.6, 0.2, 0.2, 0}, want: []float64{0.45, 0.15, 0.15, 0.25}},
+		{name: "none", shares: nil, want: []float64{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Shares(tt.shares)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Shares() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if math.Abs(got[i]-tt.want[i]) > 1e-9 {
+					t.Errorf("Shares() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// TestAggregationForSanity tests for sanity.
+func TestAggregationForSanity(t *testing.T) {
+	tests := []struct {
+		aggregation Aggregation
+		target      float64
+		share       float64
+		split       float64
+		values      []float64
+		combined    float64
+	}{
+		{aggregation: SumAggregation, target: 100, share: 0.25, split: 25, values: []float64{10, 20, 30}, combined: 60},
+		{aggregation: MaxAggregation, target: 100, share: 0.25, split: 100, values: []float64{10, 30, 20}, combined: 30},
+		{aggregation: MinAggregation, target: 100, share: 0.25, split: 100, values: []float64{20, 10, 30}, combined: 10},
+		{aggregation: ProductAggregation, target: 0.81, share: 0.5, split: 0.9, values: []float64{0.9, 0.9}, combined: 0.81},
+	}
+	for _, tt := range tests {
+		if got := tt.aggregation.SplitTarget(tt.target, tt.share); math.Abs(got-tt.split) > 1e-9 {
+			t.Errorf("SplitTarget() = %v, want %v", got, tt.split)
+		}
+		if got := tt.aggregation.Combine(tt.values); math.Abs(got-tt.combined) > 1e-9 {
+			t.Errorf("Combine() = %v, want %v", got, tt.combined)
+		}
+	}
+	if SumAggregation.Combine(nil) != 0.0 {
+		t.Error("Combining no values should return 0.0.")
+	}
+}
+
+// TestBottleneckForSanity tests for sanity.
+func TestBottleneckForSanity(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		targets  []float64
+		minimize bool
+		want     int
+	}{
+		{name: "latency", values: []float64{10, 30, 20}, targets: []float64{20, 20, 10}, minimize: true, want: 2},
+		{name: "throughput", values: []float64{100, 50, 80}, targets: []float64{100, 100, 100}, minimize: false, want: 1},
+		{name: "zero-throughput", values: []float64{100, 0}, targets: []float64{100, 100}, minimize: false, want: 1},
+		{name: "invalid", values: []float64{-1, 10}, targets: []float64{10, 0}, minimize: true, want: -1},
+		{name: "none", minimize: true, want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Bottleneck(tt.values, tt.targets, tt.minimize); got != tt.want {
+				t.Errorf("Bottleneck() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
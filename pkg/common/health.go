@@ -0,0 +1,114 @@
+package common
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"k8s.io/klog/v2"
+)
+
+// Check is a function returning an error if whatever it checks is not in order.
+type Check func() error
+
+// namedCheck is a check with a name used when reporting.
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Probes holds the checks which determine whether a component is healthy (/healthz) and ready to serve (/readyz).
+type Probes struct {
+	mu        sync.Mutex
+	health    []namedCheck
+	readiness []namedCheck
+}
+
+// NewProbes returns a new set of probes without any checks - so the component is healthy & ready by default.
+func NewProbes() *Probes {
+	return &Probes{}
+}
+
+// AddHealthCheck adds a check which is run for both the liveness and the readiness probe.
+func (p *Probes) AddHealthCheck(name string, check Check) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.health = append(p.health, namedCheck{name: name, check: check})
+}
+
+// AddReadinessCheck adds a check which is only run for the readiness probe.
+func (p *Probes) AddReadinessCheck(name string, check Check) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.readiness = append(p.readiness, namedCheck{name: name, check: check})
+}
+
+// Healthy runs all health checks; returns an error for the first one failing.
+func (p *Probes) Healthy() error {
+	_, err := run(p.checks(false))
+	return err
+}
+
+// Ready runs all health & readiness checks; returns an error for the first one failing.
+func (p *Probes) Ready() error {
+	_, err := run(p.checks(true))
+	return err
+}
+
+// checks returns a copy of the checks for the liveness or readiness probe.
+func (p *Probes) checks(readiness bool) []namedCheck {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	res := append([]namedCheck{}, p.health...)
+	if readiness {
+		res = append(res, p.readiness...)
+	}
+	return res
+}
+
+// run runs the given checks and returns a report listing the outcome of each.
+func run(checks []namedCheck) (string, error) {
+	var report strings.Builder
+	var res error
+	for _, item := range checks {
+		if err := item.check(); err != nil {
+			fmt.Fprintf(&report, "[-]%s failed: %v\n", item.name, err)
+			if res == nil {
+				res = fmt.Errorf("check %s failed: %w", item.name, err)
+			}
+			continue
+		}
+		fmt.Fprintf(&report, "[+]%s ok\n", item.name)
+	}
+	return report.String(), res
+}
+
+// handler returns an HTTP handler for the liveness or readiness probe.
+func (p *Probes) handler(readiness bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		report, err := run(p.checks(readiness))
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		if err != nil {
+			klog.V(2).Infof("Probe failed: %v.", err)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = fmt.Fprintf(w, "%s%s\n", report, "failed")
+			return
+		}
+		_, _ = fmt.Fprintf(w, "%s%s\n", report, "ok")
+	})
+}
+
+// ProbesHandler returns the handler serving /healthz and /readyz.
+func ProbesHandler(probes *Probes) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", probes.handler(false))
+	mux.Handle("/readyz", probes.handler(true))
+	return mux
+}
+
+// ServeProbes exposes the probes on /healthz and /readyz until the stopper channel is closed.
+func ServeProbes(address string, probes *Probes, stopper <-chan struct{}) error {
+	klog.Infof("Serving health & readiness probes on %s.", address)
+	return serveHTTP(address, ProbesHandler(probes), stopper)
+}
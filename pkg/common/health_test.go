@@ -0,0 +1,115 @@
+package common
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// probe returns the status code & body of a request against the probes handler.
+func probe(probes *Probes, path string) (int, string) {
+	recorder := httptest.NewRecorder()
+	ProbesHandler(probes).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	return recorder.Code, recorder.Body.String()
+}
+
+// Tests for success.
+
+// TestProbesHandlerForSuccess tests for success.
+func TestProbesHandlerForSuccess(t *testing.T) {
+	probes := NewProbes()
+	probes.AddHealthCheck("foo", func() error { return nil })
+	for _, path := range []string{"/healthz", "/readyz"} {
+		if code, body := probe(probes, path); code != http.StatusOK || body != "[+]foo ok\nok\n" {
+			t.Errorf("Expected %s to succeed - got: %d - %s.", path, code, body)
+		}
+	}
+}
+
+// Tests for failure.
+
+// TestProbesHandlerForFailure tests for failure.
+func TestProbesHandlerForFailure(t *testing.T) {
+	probes := NewProbes()
+	probes.AddHealthCheck("foo", func() error { return errors.New("broken") })
+	for _, path := range []string{"/healthz", "/readyz"} {
+		if code, body := probe(probes, path); code != http.StatusServiceUnavailable || body != "[-]foo failed: broken\nfailed\n" {
+			t.Errorf("Expected %s to fail - got: %d - %s.", path, code, body)
+		}
+	}
+	if err := probes.Healthy(); err == nil {
+		t.Error("Should not be healthy.")
+	}
+
+	stopper := make(chan struct{})
+	defer close(stopper)
+	if err := ServeProbes("foo", probes, stopper); err == nil {
+		t.Error("Should have failed - invalid address.")
+	}
+}
+
+// Tests for sanity.
+
+// TestProbesForSanity tests for sanity.
+func TestProbesForSanity(t *testing.T) {
+	ready := errors.New("not synced")
+	probes := NewProbes()
+	probes.AddHealthCheck("alive", func() error { return nil })
+	probes.AddReadinessCheck("synced", func() error { return ready })
+
+	// healthy but not ready.
+	if code, _ := probe(probes, "/healthz"); code != http.StatusOK || probes.Healthy() != nil {
+		t.Errorf("Expected to be healthy - got: %d.", code)
+	}
+	code, body := probe(probes, "/readyz")
+	if code != http.StatusServiceUnavailable || !strings.Contains(body, "[+]alive ok\n[-]synced failed: not synced\n") {
+		t.Errorf("Expected not to be ready - got: %d - %s.", code, body)
+	}
+	if err := probes.Ready(); err == nil || !errors.Is(err, ready) {
+		t.Errorf("Expected readiness check to fail - got: %v.", err)
+	}
+
+	// ready.
+	ready = nil
+	if code, _ = probe(probes, "/readyz"); code != http.StatusOK || probes.Ready() != nil {
+		t.Errorf("Expected to be ready - got: %d.", code)
+	}
+}
+
+// TestServeProbesForSanity tests for sanity.
+func TestServeProbesForSanity(t *testing.T) {
+	stopper := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- ServeProbes("127.0.0.1:33381", NewProbes(), stopper)
+	}()
+
+	var body string
+	for i := 0; i < 50 && body == ""; i++ {
+		time.Sleep(10 * time.Millisecond)
+		response, err := http.Get("http://127.0.0.1:33381/readyz")
+		if err != nil {
+			continue
+		}
+		tmp, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+		body = string(tmp)
+	}
+	if body != "ok\n" {
+		t.Errorf("Expected to be ready - got: %s.", body)
+	}
+
+	close(stopper)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Should have stopped without an error: %v.", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Timed out waiting for the server to stop.")
+	}
+}
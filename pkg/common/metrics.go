@@ -0,0 +1,174 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/klog/v2"
+)
+
+// metricsNamespace is the prefix of all metrics exposed.
+const metricsNamespace = "ido"
+
+// MetricsRegistry holds all the metrics about the internals of the planner.
+var MetricsRegistry = prometheus.NewRegistry()
+
+var (
+	// PlanningDuration captures how long it took to create a plan per intent.
+	PlanningDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "planning_duration_seconds",
+		Help:      "Time it took to create a plan for an intent.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+	}, []string{"intent"})
+	// StateGraphNodes captures the number of nodes in the state graphs created by the planner.
+	StateGraphNodes = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "state_graph_nodes",
+		Help:      "Number of nodes in the state graph created while planning.",
+		Buckets:   prometheus.ExponentialBuckets(2, 2, 14),
+	})
+	// StateGraphEdges captures the number of edges in the state graphs created by the planner.
+	StateGraphEdges = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "state_graph_edges",
+		Help:      "Number of edges in the state graph created while planning.",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 16),
+	})
+	// MaxStatesReached counts how often the planner stopped expanding the state graph as the max states were reached.
+	MaxStatesReached = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "state_graph_max_states_reached_total",
+		Help:      "Number of times the state graph hit the maximum number of states.",
+	})
+	// PlanLength captures the number of actions in the plans created.
+	PlanLength = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "plan_length",
+		Help:      "Number of actions in a plan.",
+		Buckets:   []float64{0, 1, 2, 3, 4, 5, 10, 20},
+	})
+	// ActionsPerformed counts the actions of the plans each actuator was asked to perform.
+	ActionsPerformed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "actions_performed_total",
+		Help:      "Number of actions performed per actuator.",
+	}, []string{"actuator"})
+	// NextStateDuration captures how long the actuators took to determine the follow-up states.
+	NextStateDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "next_state_duration_seconds",
+		Help:      "Time it took an actuator to determine the follow-up states.",
+		Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 16),
+	}, []string{"actuator"})
+	// NextStateErrors counts the failed calls to determine the follow-up states.
+	NextStateErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "next_state_errors_total",
+		Help:      "Number of failed calls to determine the follow-up states per actuator.",
+	}, []string{"actuator"})
+	// QueryDuration captures how long telemetry queries took.
+	QueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "telemetry_query_duration_seconds",
+		Help:      "Time it took to query the telemetry system.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+	}, []string{"source"})
+	// QueryFailures counts the failed telemetry queries.
+	QueryFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "telemetry_query_failures_total",
+		Help:      "Number of failed queries to the telemetry system.",
+	}, []string{"source"})
+	// TaskQueueDepth captures how many intents are waiting to be looked at by the workers.
+	TaskQueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "task_queue_depth",
+		Help:      "Number of intents waiting to be processed.",
+	})
+	// PlanRollbacks counts the executed plans which were rolled back as the objectives got worse than predicted.
+	PlanRollbacks = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "plan_rollbacks_total",
+		Help:      "Number of executed plans rolled back as the objectives got worse than predicted.",
+	})
+	// ActuatorCooldown captures for how long an actuator is still not used for an intent after it changed the target.
+	ActuatorCooldown = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "actuator_cooldown_remaining_seconds",
+		Help:      "Remaining time an actuator is cooling down for an intent; the full period while waiting for a rollout.",
+	}, []string{"intent", "actuator"})
+	// ArbitrationLosses counts the plans trimmed or rejected as they did not fit the capacity of the nodes.
+	ArbitrationLosses = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "arbitration_losses_total",
+		Help:      "Number of plans trimmed or rejected as they did not fit the capacity of the nodes.",
+	}, []string{"outcome"})
+	// PlanCacheLookups counts the lookups in the plan cache - labeled by whether the intent was in the cache or not.
+	PlanCacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "plan_cache_lookups_total",
+		Help:      "Number of lookups in the plan cache.",
+	}, []string{"result"})
+)
+
+func init() {
+	MetricsRegistry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		PlanningDuration,
+		StateGraphNodes,
+		StateGraphEdges,
+		MaxStatesReached,
+		PlanLength,
+		ActionsPerformed,
+		NextStateDuration,
+		NextStateErrors,
+		QueryDuration,
+		QueryFailures,
+		TaskQueueDepth,
+		PlanCacheLookups,
+		PlanRollbacks,
+		ActuatorCooldown,
+		ArbitrationLosses,
+	)
+}
+
+// MetricsHandler returns the handler exposing the metrics.
+func MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(MetricsRegistry, promhttp.HandlerOpts{})
+}
+
+// ServeMetrics exposes the metrics on /metrics until the stopper channel is closed.
+func ServeMetrics(address string, stopper <-chan struct{}) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", MetricsHandler())
+	klog.Infof("Serving metrics on %s.", address)
+	return serveHTTP(address, mux, stopper)
+}
+
+// serveHTTP runs an HTTP server for the given handler until the stopper channel is closed.
+func serveHTTP(address string, handler http.Handler, stopper <-chan struct{}) error {
+	server := &http.Server{
+		Addr:              address,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-stopper
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			klog.Errorf("Error shutting down server on %s: %v.", address, err)
+		}
+	}()
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package common
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Tests for success.
+
+// TestMetricsHandlerForSuccess tests for success.
+func TestMetricsHandlerForSuccess(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	MetricsHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), "ido_task_queue_depth") {
+		t.Errorf("Expected metrics to be exposed - got: %d - %s.", recorder.Code, recorder.Body.String())
+	}
+}
+
+// Tests for failure.
+
+// TestServeMetricsForFailure tests for failure.
+func TestServeMetricsForFailure(t *testing.T) {
+	stopper := make(chan struct{})
+	defer close(stopper)
+	if err := ServeMetrics("foo", stopper); err == nil {
+		t.Error("Should have failed - invalid address.")
+	}
+}
+
+// Tests for sanity.
+
+// TestServeMetricsForSanity tests for sanity.
+func TestServeMetricsForSanity(t *testing.T) {
+	stopper := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- ServeMetrics("127.0.0.1:33380", stopper)
+	}()
+
+	// scrape.
+	PlanLength.Observe(2)
+	var body string
+	for i := 0; i < 50 && body == ""; i++ {
+		time.Sleep(10 * time.Millisecond)
+		response, err := http.Get("http://127.0.0.1:33380/metrics")
+		if err != nil {
+			continue
+		}
+		tmp, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+		body = string(tmp)
+	}
+	for _, name := range []string{"ido_plan_length_count", "ido_state_graph_nodes", "go_goroutines"} {
+		if !strings.Contains(body, name) {
+			t.Errorf("Expected metric %s in: %s.", name, body)
+		}
+	}
+
+	// server stops.
+	close(stopper)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Should have stopped without an error: %v.", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Timed out waiting for the server to stop.")
+	}
+}
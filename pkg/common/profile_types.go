@@ -0,0 +1,133 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ProfileType defines the type of KPIProfiles.
+type ProfileType int
+
+const (
+	Obsolete ProfileType = iota
+	Latency
+	Availability
+	Throughput
+	Power
+	ErrorRate
+	QueueDepth
+	Cost
+	Saturation
+)
+
+// profileTypeFormat defines the format of the names of profile types.
+var profileTypeFormat = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// profileTypeInfo holds the information on a known profile type.
+type profileTypeInfo struct {
+	name     string
+	minimize bool
+}
+
+// profileTypeRegistry holds the known profile types - indexed by their ProfileType.
+type profileTypeRegistry struct {
+	lock   sync.RWMutex
+	types  []profileTypeInfo
+	byName map[string]ProfileType
+}
+
+// profileTypes is the registry of all known profile types; the built-in types are always known.
+var profileTypes = newProfileTypeRegistry()
+
+// newProfileTypeRegistry returns a registry with the built-in profile types.
+func newProfileTypeRegistry() *profileTypeRegistry {
+	registry := &profileTypeRegistry{byName: map[string]ProfileType{}}
+	builtin := []profileTypeInfo{
+		{name: "obsolete"},
+		{name: "latency", minimize: true},
+		{name: "availability", minimize: false},
+		{name: "throughput", minimize: false},
+		{name: "power", minimize: true},
+		{name: "error_rate", minimize: true},
+		{name: "queue_depth", minimize: true},
+		{name: "cost", minimize: true},
+		{name: "saturation", minimize: true},
+	}
+	for i, item := range builtin {
+		registry.types = append(registry.types, item)
+		registry.byName[item.name] = ProfileType(i)
+	}
+	return registry
+}
+
+// RegisterProfileType adds a profile type - with the direction in which it is optimized by default - to the known
+// types. Registering a type which is already known returns the existing type.
+func RegisterProfileType(name string, minimize bool) (ProfileType, error) {
+	name = strings.ToLower(name)
+	if !profileTypeFormat.MatchString(name) {
+		return Obsolete, fmt.Errorf("invalid name for a profile type: '%s'", name)
+	}
+	profileTypes.lock.Lock()
+	defer profileTypes.lock.Unlock()
+	if item, ok := profileTypes.byName[name]; ok {
+		return item, nil
+	}
+	item := ProfileType(len(profileTypes.types))
+	profileTypes.types = append(profileTypes.types, profileTypeInfo{name: name, minimize: minimize})
+	profileTypes.byName[name] = item
+	return item, nil
+}
+
+// ProfileTypeFromText converts string into the right int; unknown types are obsolete.
+func ProfileTypeFromText(text string) ProfileType {
+	profileTypes.lock.RLock()
+	defer profileTypes.lock.RUnlock()
+	if item, ok := profileTypes.byName[strings.ToLower(text)]; ok {
+		return item
+	}
+	return Obsolete
+}
+
+// KnownProfileTypes returns the sorted names of all known profile types.
+func KnownProfileTypes() []string {
+	profileTypes.lock.RLock()
+	defer profileTypes.lock.RUnlock()
+	var res []string
+	for _, item := range profileTypes.types[1:] {
+		res = append(res, item.name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// info returns the information on a profile type.
+func (t ProfileType) info() (profileTypeInfo, bool) {
+	profileTypes.lock.RLock()
+	defer profileTypes.lock.RUnlock()
+	if t < 0 || int(t) >= len(profileTypes.types) {
+		return profileTypeInfo{}, false
+	}
+	return profileTypes.types[t], true
+}
+
+// String returns the name of the profile type.
+func (t ProfileType) String() string {
+	if item, ok := t.info(); ok {
+		return item.name
+	}
+	return fmt.Sprintf("unknown(%d)", int(t))
+}
+
+// Minimize returns if profiles of this type are minimized by default.
+func (t ProfileType) Minimize() bool {
+	item, _ := t.info()
+	return item.minimize
+}
+
+// IsBuiltin checks if the profile type is one of the built-in types - those are known to all components.
+func (t ProfileType) IsBuiltin() bool {
+	return t > Obsolete && t <= Saturation
+}
@@ -0,0 +1,82 @@
+package common
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Tests for success.
+
+// TestRegisterProfileTypeForSuccess tests for success.
+func TestRegisterProfileTypeForSuccess(t *testing.T) {
+	res, err := RegisterProfileType("gpu_utilization", false)
+	if err != nil || res == Obsolete {
+		t.Errorf("Should have been registered - got: %v - %v.", res, err)
+	}
+}
+
+// Tests for failure.
+
+// TestRegisterProfileTypeForFailure tests for failure.
+func TestRegisterProfileTypeForFailure(t *testing.T) {
+	for _, name := range []string{"", "1st", "queue-depth", "queue depth"} {
+		if _, err := RegisterProfileType(name, true); err == nil {
+			t.Errorf("Expected an error for: '%s'.", name)
+		}
+	}
+}
+
+// Tests for sanity.
+
+// TestRegisterProfileTypeForSanity tests for sanity.
+func TestRegisterProfileTypeForSanity(t *testing.T) {
+	if ProfileTypeFromText("cache_misses") != Obsolete {
+		t.Error("Type should not be known yet.")
+	}
+	res, _ := RegisterProfileType("Cache_Misses", true)
+	if ProfileTypeFromText("cache_misses") != res || res.String() != "cache_misses" || !res.Minimize() || res.IsBuiltin() {
+		t.Errorf("Unexpected type: %v.", res)
+	}
+
+	// registering again returns the existing type - including its default direction.
+	again, _ := RegisterProfileType("cache_misses", false)
+	if again != res || !again.Minimize() {
+		t.Errorf("Expected existing type - got: %v.", again)
+	}
+	latency, _ := RegisterProfileType("latency", false)
+	if latency != Latency || !latency.Minimize() {
+		t.Errorf("Expected built-in type - got: %v.", latency)
+	}
+
+	found := false
+	for _, name := range KnownProfileTypes() {
+		if name == "obsolete" {
+			t.Error("Obsolete should not be a known type.")
+		}
+		found = found || name == "cache_misses"
+	}
+	if !found {
+		t.Errorf("Expected type to be known - got: %v.", KnownProfileTypes())
+	}
+}
+
+// TestBuiltinProfileTypesForSanity tests for sanity.
+func TestBuiltinProfileTypesForSanity(t *testing.T) {
+	minimized := map[ProfileType]bool{}
+	for _, item := range []ProfileType{Latency, Availability, Throughput, Power, ErrorRate, QueueDepth, Cost, Saturation} {
+		if !item.IsBuiltin() || ProfileTypeFromText(item.String()) != item {
+			t.Errorf("Unexpected built-in type: %v.", item)
+		}
+		minimized[item] = item.Minimize()
+	}
+	expected := map[ProfileType]bool{
+		Latency: true, Availability: false, Throughput: false, Power: true,
+		ErrorRate: true, QueueDepth: true, Cost: true, Saturation: true,
+	}
+	if !reflect.DeepEqual(minimized, expected) {
+		t.Errorf("Unexpected default directions: %v.", minimized)
+	}
+	if Obsolete.IsBuiltin() || ProfileType(-1).String() != "unknown(-1)" {
+		t.Error("Obsolete & invalid types are not built-in.")
+	}
+}
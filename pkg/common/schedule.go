@@ -0,0 +1,117 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dayLength defines the length of a day.
+const dayLength = 24 * time.Hour
+
+// weekdays maps the abbreviations used in schedule windows to weekdays.
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ScheduleWindow defines a recurring time window during which a different target value applies for an objective.
+type ScheduleWindow struct {
+	Name string
+	// Days the window starts on; the window applies on all days if none are given.
+	Days []time.Weekday
+	// Start and End of the window as offset from midnight; windows which end before they start span midnight.
+	Start    time.Duration
+	End      time.Duration
+	Location *time.Location
+	Value    float64
+}
+
+// parseTimeOfDay parses a time of day in the format "HH:MM"; "24:00" denotes the end of a day.
+func parseTimeOfDay(text string) (time.Duration, error) {
+	if text == "24:00" {
+		return dayLength, nil
+	}
+	t, err := time.Parse("15:04", text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day '%s' - expected format is HH:MM", text)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// ParseScheduleWindow parses a schedule window from its textual representation; days are given as e.g. "Mon", times
+// of day as "HH:MM" and the timezone as IANA name - defaulting to UTC.
+func ParseScheduleWindow(name string, days []string, start string, end string, timeZone string, value float64) (ScheduleWindow, error) {
+	window := ScheduleWindow{Name: name, Value: value}
+	for _, day := range days {
+		weekday, ok := weekdays[strings.ToLower(day)]
+		if !ok {
+			return window, fmt.Errorf("invalid day '%s' in window '%s'", day, name)
+		}
+		window.Days = append(window.Days, weekday)
+	}
+	var err error
+	if window.Start, err = parseTimeOfDay(start); err != nil {
+		return window, fmt.Errorf("invalid start of window '%s': %w", name, err)
+	}
+	if window.End, err = parseTimeOfDay(end); err != nil {
+		return window, fmt.Errorf("invalid end of window '%s': %w", name, err)
+	}
+	if window.Start == window.End || window.Start == dayLength {
+		return window, fmt.Errorf("window '%s' from %s to %s is empty", name, start, end)
+	}
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	if window.Location, err = time.LoadLocation(timeZone); err != nil {
+		return window, fmt.Errorf("invalid timezone of window '%s': %w", name, err)
+	}
+	return window, nil
+}
+
+// startsOn checks if the window starts on the given day.
+func (w ScheduleWindow) startsOn(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, item := range w.Days {
+		if item == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains checks if the given point in time is within the window.
+func (w ScheduleWindow) Contains(t time.Time) bool {
+	location := w.Location
+	if location == nil {
+		location = time.UTC
+	}
+	local := t.In(location)
+	offset := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute +
+		time.Duration(local.Second())*time.Second
+	if w.Start < w.End {
+		return w.startsOn(local.Weekday()) && offset >= w.Start && offset < w.End
+	}
+	// window spanning midnight - either it started today, or the day before.
+	if offset >= w.Start {
+		return w.startsOn(local.Weekday())
+	}
+	return offset < w.End && w.startsOn((local.Weekday()+6)%7)
+}
+
+// ActiveWindow returns the first of the windows which contains the given point in time.
+func ActiveWindow(windows []ScheduleWindow, t time.Time) (ScheduleWindow, bool) {
+	for _, window := range windows {
+		if window.Contains(t) {
+			return window, true
+		}
+	}
+	return ScheduleWindow{}, false
+}
@@ -0,0 +1,120 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+// Tests for success.
+
+// TestParseScheduleWindowForSuccess tests for success.
+func TestParseScheduleWindowForSuccess(t *testing.T) {
+	window, err := ParseScheduleWindow("business-hours", []string{"Mon", "fri"}, "08:00", "18:30", "Europe/Berlin", 10.0)
+	if err != nil {
+		t.Fatalf("Should have been parsed: %v.", err)
+	}
+	if len(window.Days) != 2 || window.Days[0] != time.Monday || window.Days[1] != time.Friday {
+		t.Errorf("Unexpected days: %v.", window.Days)
+	}
+	if window.Start != 8*time.Hour || window.End != 18*time.Hour+30*time.Minute {
+		t.Errorf("Unexpected start & end: %v - %v.", window.Start, window.End)
+	}
+	if window.Location.String() != "Europe/Berlin" || window.Value != 10.0 {
+		t.Errorf("Unexpected window: %v.", window)
+	}
+}
+
+// TestActiveWindowForSuccess tests for success.
+func TestActiveWindowForSuccess(t *testing.T) {
+	window, _ := ParseScheduleWindow("day", nil, "08:00", "20:00", "", 10.0)
+	res, ok := ActiveWindow([]ScheduleWindow{window}, time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC))
+	if !ok || res.Name != "day" {
+		t.Errorf("Expected window to be active - got: %v.", res)
+	}
+}
+
+// Tests for failure.
+
+// TestParseScheduleWindowForFailure tests for failure.
+func TestParseScheduleWindowForFailure(t *testing.T) {
+	tests := []struct {
+		days     []string
+		start    string
+		end      string
+		timeZone string
+	}{
+		{days: []string{"Monday"}, start: "08:00", end: "18:00"},
+		{start: "8am", end: "18:00"},
+		{start: "08:00", end: "25:00"},
+		{start: "08:00", end: "08:00"},
+		{start: "24:00", end: "08:00"},
+		{start: "08:00", end: "18:00", timeZone: "Mars/Olympus_Mons"},
+	}
+	for _, tt := range tests {
+		if _, err := ParseScheduleWindow("foo", tt.days, tt.start, tt.end, tt.timeZone, 1.0); err == nil {
+			t.Errorf("Expected an error for: %v.", tt)
+		}
+	}
+}
+
+// Tests for sanity.
+
+// TestScheduleWindowContainsForSanity tests for sanity.
+func TestScheduleWindowContainsForSanity(t *testing.T) {
+	business, _ := ParseScheduleWindow("business", []string{"Mon", "Tue", "Wed", "Thu", "Fri"}, "08:00", "18:00", "America/New_York", 10.0)
+	night, _ := ParseScheduleWindow("night", []string{"Fri"}, "22:00", "06:00", "", 50.0)
+	allDay, _ := ParseScheduleWindow("all-day", []string{"Sun"}, "00:00", "24:00", "", 100.0)
+
+	tests := []struct {
+		name   string
+		window ScheduleWindow
+		time   time.Time
+		want   bool
+	}{
+		// 2024-05-06 is a Monday; New York is UTC-4 at that time.
+		{name: "business-start", window: business, time: time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC), want: true},
+		{name: "business-before", window: business, time: time.Date(2024, 5, 6, 11, 59, 59, 0, time.UTC), want: false},
+		{name: "business-end", window: business, time: time.Date(2024, 5, 6, 22, 0, 0, 0, time.UTC), want: false},
+		{name: "business-weekend", window: business, time: time.Date(2024, 5, 11, 14, 0, 0, 0, time.UTC), want: false},
+		// Monday in UTC, but still Sunday in New York.
+		{name: "business-timezone", window: business, time: time.Date(2024, 5, 6, 3, 0, 0, 0, time.UTC), want: false},
+		{name: "night-start", window: night, time: time.Date(2024, 5, 10, 23, 0, 0, 0, time.UTC), want: true},
+		{name: "night-next-day", window: night, time: time.Date(2024, 5, 11, 5, 59, 0, 0, time.UTC), want: true},
+		{name: "night-after", window: night, time: time.Date(2024, 5, 11, 6, 0, 0, 0, time.UTC), want: false},
+		{name: "night-wrong-day", window: night, time: time.Date(2024, 5, 10, 5, 0, 0, 0, time.UTC), want: false},
+		{name: "all-day", window: allDay, time: time.Date(2024, 5, 12, 23, 59, 59, 0, time.UTC), want: true},
+		{name: "all-day-next", window: allDay, time: time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.window.Contains(tt.time); got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestActiveWindowForSanity tests for sanity.
+func TestActiveWindowForSanity(t *testing.T) {
+	first, _ := ParseScheduleWindow("first", nil, "08:00", "12:00", "", 10.0)
+	second, _ := ParseScheduleWindow("second", nil, "10:00", "14:00", "", 20.0)
+	windows := []ScheduleWindow{first, second}
+
+	// first matching window wins.
+	res, ok := ActiveWindow(windows, time.Date(2024, 5, 6, 11, 0, 0, 0, time.UTC))
+	if !ok || res.Name != "first" {
+		t.Errorf("Expected first window to be active - got: %v.", res)
+	}
+	res, ok = ActiveWindow(windows, time.Date(2024, 5, 6, 13, 0, 0, 0, time.UTC))
+	if !ok || res.Name != "second" {
+		t.Errorf("Expected second window to be active - got: %v.", res)
+	}
+	_, ok = ActiveWindow(windows, time.Date(2024, 5, 6, 15, 0, 0, 0, time.UTC))
+	if ok {
+		t.Error("Expected no window to be active.")
+	}
+	_, ok = ActiveWindow(nil, time.Date(2024, 5, 6, 15, 0, 0, 0, time.UTC))
+	if ok {
+		t.Error("Expected no window to be active.")
+	}
+}
@@ -6,30 +6,23 @@ import (
 	"time"
 )
 
-// ProfileType defines the type of KPIProfiles.
-type ProfileType int
+// Normalization defines how the deviation of an objective from its target value is normalized.
+type Normalization int
 
 const (
-	Obsolete ProfileType = iota
-	Latency
-	Availability
-	Throughput
-	Power
+	// NoNormalization uses the absolute deviation from the target value.
+	NoNormalization Normalization = iota
+	// TargetNormalization uses the deviation relative to the target value; this makes objectives with different units comparable.
+	TargetNormalization
 )
 
-// ProfileTypeFromText converts string into the right int.
-func ProfileTypeFromText(text string) ProfileType {
+// NormalizationFromText converts string into the right int.
+func NormalizationFromText(text string) Normalization {
 	switch strings.ToLower(text) {
 	default:
-		return Obsolete
-	case "latency":
-		return Latency
-	case "availability":
-		return Availability
-	case "throughput":
-		return Throughput
-	case "power":
-		return Power
+		return NoNormalization
+	case "target":
+		return TargetNormalization
 	}
 }
 
@@ -50,17 +43,69 @@ type Profile struct {
 	Minimize    bool
 	External    bool
 	Address     string
+	// Window is the time window used in the query; defaults to 30s.
+	Window string
 }
 
 // Intent holds information about an intent in the system.
 type Intent struct {
 	Key             string
+	ParentKey       string
+	UID             string
 	Priority        float64
 	TargetKey       string
 	TargetKind      string
 	ActivelyManaged bool
 	Objectives      map[string]float64
 	Tolerations     map[string]float64
+	Weights         map[string]float64
+	Normalizations  map[string]Normalization
+	Schedules       map[string][]ScheduleWindow
+	Budget          *Budget
+	// AllowedActuators and DeniedActuators restrict the actuators - by name or group - used for this intent.
+	AllowedActuators []string
+	DeniedActuators  []string
+	// ActiveWindows holds the names of the schedule windows the target values of the desired state are taken from.
+	ActiveWindows map[string]string
+	// ParentKind is the kind of the object the intent is derived from - an Intent using a selector, or an IntentGroup.
+	ParentKind string
+	// Cooldowns holds the actuators - by name or group - which are not used for now as they recently changed the target,
+	// and when their cooldown ends; it is zero while the cooldown waits for the change to be rolled out.
+	Cooldowns map[string]time.Time
+}
+
+// weight returns the weight of an objective - defaults to 1.0.
+func (intent *Intent) weight(key string) float64 {
+	if w, ok := intent.Weights[key]; ok && w > 0 {
+		return w
+	}
+	return 1.0
+}
+
+// PermitsActuator checks if an actuator with the given name and group can be used for this intent. Denied actuators are
+// never used; if actuators are explicitly allowed, only those can be used.
+func (intent *Intent) PermitsActuator(name string, group string) bool {
+	for _, item := range intent.DeniedActuators {
+		if item == name || item == group {
+			return false
+		}
+	}
+	if len(intent.AllowedActuators) == 0 {
+		return true
+	}
+	for _, item := range intent.AllowedActuators {
+		if item == name || item == group {
+			return true
+		}
+	}
+	return false
+}
+
+// CoolingDown checks if an actuator with the given name and group is cooling down for this intent.
+func (intent *Intent) CoolingDown(name string, group string) bool {
+	_, byName := intent.Cooldowns[name]
+	_, byGroup := intent.Cooldowns[group]
+	return byName || byGroup
 }
 
 // PodState represents the state of an POD.
@@ -78,6 +123,9 @@ type State struct {
 	CurrentData map[string]map[string]float64
 	Resources   map[string]int64
 	Annotations map[string]string
+	// Nodes holds the capacity of the nodes the PODs can run on - nil if unknown; Headroom sums it up.
+	Nodes    map[string]NodeCapacity
+	Headroom NodeCapacity
 }
 
 // DeepCopy creates a deep copy of a state.
@@ -85,15 +133,24 @@ func (one *State) DeepCopy() State {
 	// make sure we capture the objectives.
 	objective := Intent{
 		Key:        one.Intent.Key,
+		ParentKey:  one.Intent.ParentKey,
+		ParentKind: one.Intent.ParentKind,
+		UID:        one.Intent.UID,
 		Priority:   one.Intent.Priority,
 		TargetKey:  one.Intent.TargetKey,
 		TargetKind: one.Intent.TargetKind,
 		Objectives: map[string]float64{},
-		// omitting activelyManaged and tolerations on purpose to save some data; only needed for goal state anyhow.
+		// omitting activelyManaged, weights and normalizations on purpose to save some data; only needed for goal state anyhow.
 	}
 	for k, v := range one.Intent.Objectives {
 		objective.Objectives[k] = v
 	}
+	if one.Intent.Tolerations != nil {
+		objective.Tolerations = make(map[string]float64, len(one.Intent.Tolerations))
+		for k, v := range one.Intent.Tolerations {
+			objective.Tolerations[k] = v
+		}
+	}
 
 	// new state.
 	tmp := State{
@@ -102,6 +159,8 @@ func (one *State) DeepCopy() State {
 		map[string]map[string]float64{},
 		map[string]int64{},
 		map[string]string{},
+		nil,
+		one.Headroom,
 	}
 
 	// copy over pod states.
@@ -133,6 +192,13 @@ func (one *State) DeepCopy() State {
 		}
 	}
 
+	if one.Nodes != nil {
+		tmp.Nodes = make(map[string]NodeCapacity, len(one.Nodes))
+		for name, node := range one.Nodes {
+			tmp.Nodes[name] = node
+		}
+	}
+
 	// and all the rest.
 	for k := range one.CurrentData {
 		subMap := map[string]float64{}
@@ -144,11 +210,26 @@ func (one *State) DeepCopy() State {
 	return tmp
 }
 
-// Distance calculates the euclidean Distance between two states. Potentially we can add weights here maybe - or another way to calculate the Distance so e.g. the Distance for P99 is more important than the Distance in P50 latency.
+// deviation returns how far a value deviates from a target value; deviations within the tolerance band around the target are ignored.
+func deviation(value float64, target float64, tolerance float64) float64 {
+	diff := math.Abs(value - target)
+	band := math.Abs(target * tolerance)
+	if diff <= band {
+		return 0.0
+	}
+	return diff - band
+}
+
+// Distance calculates the weighted euclidean Distance between two states - taking the tolerances, weights and normalizations of the other (typically the desired) state into account. This allows e.g. the Distance for P99 to be more important than the Distance in P50 latency, and objectives with different units to be compared.
 func (one *State) Distance(another *State, profiles map[string]Profile) float64 {
 	squaresSum := 0.0
 	for key, val := range one.Intent.Objectives {
-		squaresSum += math.Pow(val-another.Intent.Objectives[key], 2)
+		target := another.Intent.Objectives[key]
+		dev := deviation(val, target, another.Intent.Tolerations[key])
+		if another.Intent.Normalizations[key] == TargetNormalization && target != 0 {
+			dev /= math.Abs(target)
+		}
+		squaresSum += another.Intent.weight(key) * math.Pow(dev, 2)
 	}
 	if one.IsBetter(another, profiles) && squaresSum != 0.0 {
 		// we should favor states which are closer to the goal...
@@ -157,21 +238,22 @@ func (one *State) Distance(another *State, profiles map[string]Profile) float64
 	return math.Sqrt(squaresSum)
 }
 
-// IsBetter compares the objectives of one state to another - returns true if all objectives that need to be minimized are smaller or equal, and all others are larger or equal.
+// IsBetter compares the objectives of one state to another - returns true if all objectives that need to be minimized are smaller or equal, and all others are larger or equal. The tolerances of the other (typically the desired) state are taken into account.
 func (one *State) IsBetter(another *State, profiles map[string]Profile) bool {
 	if len(one.Intent.Objectives) != len(another.Intent.Objectives) {
 		return false
 	}
 	res := false
 	for k, v := range one.Intent.Objectives {
+		tolerance := another.Intent.Tolerations[k]
 		if profiles[k].Minimize {
-			if v <= another.Intent.Objectives[k] {
+			if v <= another.Intent.Objectives[k]*(1+tolerance) {
 				res = true
 			} else {
 				return false
 			}
 		} else {
-			if v >= another.Intent.Objectives[k] {
+			if v >= another.Intent.Objectives[k]*(1-tolerance) {
 				res = true
 			} else {
 				return false
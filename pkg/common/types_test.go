@@ -1,8 +1,10 @@
 package common
 
 import (
+	"math"
 	"reflect"
 	"testing"
+	"time"
 )
 
 // TestProfileTypeFromTextForSanity tests for success.
@@ -122,6 +124,10 @@ func TestProfileTypeFromTextForSanity(t *testing.T) {
 	if ProfileTypeFromText("power") != Power {
 		t.Error("Conversion failed!")
 	}
+	if ProfileTypeFromText("error_rate") != ErrorRate || ProfileTypeFromText("queue_depth") != QueueDepth ||
+		ProfileTypeFromText("cost") != Cost || ProfileTypeFromText("Saturation") != Saturation {
+		t.Error("Conversion failed!")
+	}
 	if ProfileTypeFromText("steadfastness") != Obsolete {
 		t.Error("Conversion failed!")
 	}
@@ -328,6 +334,75 @@ func TestIsBetterStateForSanity(t *testing.T) {
 	}
 }
 
+// TestTolerancesForSanity tests for sanity.
+func TestTolerancesForSanity(t *testing.T) {
+	goal := State{
+		Intent: Intent{
+			Objectives:  map[string]float64{"p95": 4, "availability": 0.99},
+			Tolerations: map[string]float64{"p95": 0.1, "availability": 0.01},
+		},
+	}
+	profiles := map[string]Profile{
+		"p95":          {ProfileType: ProfileTypeFromText("latency"), Minimize: true},
+		"availability": {ProfileType: ProfileTypeFromText("availability"), Minimize: false},
+	}
+
+	// in-band noise is good enough.
+	noisy := State{Intent: Intent{Objectives: map[string]float64{"p95": 4.01, "availability": 0.985}}}
+	if !noisy.IsBetter(&goal, profiles) || noisy.Distance(&goal, profiles) != 0.0 {
+		t.Errorf("Values within the tolerance should be treated as met: %v.", noisy.Distance(&goal, profiles))
+	}
+
+	// only the deviation outside the band counts.
+	off := State{Intent: Intent{Objectives: map[string]float64{"p95": 5.4, "availability": 0.99}}}
+	if off.IsBetter(&goal, profiles) || math.Abs(off.Distance(&goal, profiles)-1.0) > 1e-9 {
+		t.Errorf("Expected a distance of 1.0 - got: %v.", off.Distance(&goal, profiles))
+	}
+
+	// w/o tolerances exact values are needed.
+	goal.Intent.Tolerations = nil
+	if noisy.IsBetter(&goal, profiles) || noisy.Distance(&goal, profiles) <= 0.0 {
+		t.Errorf("Values should not be treated as met w/o tolerances.")
+	}
+
+	// tolerances are kept by deep copies.
+	goal.Intent.Tolerations = map[string]float64{"p95": 0.1}
+	tmp := goal.DeepCopy()
+	goal.Intent.Tolerations["p95"] = 0.2
+	if tmp.Intent.Tolerations["p95"] != 0.1 {
+		t.Errorf("Expected a deep copy of the tolerances - got: %v.", tmp.Intent.Tolerations)
+	}
+}
+
+// TestWeightedDistanceForSanity tests for sanity.
+func TestWeightedDistanceForSanity(t *testing.T) {
+	goal := State{Intent: Intent{Objectives: map[string]float64{"p99": 10, "rps": 2000}}}
+	state := State{Intent: Intent{Objectives: map[string]float64{"p99": 13, "rps": 1996}}}
+	profiles := map[string]Profile{
+		"p99": {ProfileType: ProfileTypeFromText("latency"), Minimize: true},
+		"rps": {ProfileType: ProfileTypeFromText("throughput"), Minimize: false},
+	}
+	if state.Distance(&goal, profiles) != 5.0 {
+		t.Errorf("Expected a distance of 5.0 - got: %v.", state.Distance(&goal, profiles))
+	}
+
+	// weights scale the squared deviations; invalid weights default to 1.0.
+	goal.Intent.Weights = map[string]float64{"p99": 4, "rps": -1}
+	if state.Distance(&goal, profiles) != math.Sqrt(4*9+16) {
+		t.Errorf("Expected a weighted distance - got: %v.", state.Distance(&goal, profiles))
+	}
+
+	// normalization relative to the target value.
+	goal.Intent.Weights = nil
+	goal.Intent.Normalizations = map[string]Normalization{"p99": TargetNormalization, "rps": TargetNormalization}
+	if math.Abs(state.Distance(&goal, profiles)-math.Sqrt(0.09+0.000004)) > 1e-9 {
+		t.Errorf("Expected a normalized distance - got: %v.", state.Distance(&goal, profiles))
+	}
+	if NormalizationFromText("Target") != TargetNormalization || NormalizationFromText("foo") != NoNormalization {
+		t.Error("Unexpected normalization conversion.")
+	}
+}
+
 // TestLessResourcesForSanity tests for sanity.
 func TestLessResourcesForSanity(t *testing.T) {
 	s0 := State{
@@ -368,3 +443,52 @@ func TestLessResourcesForSanity(t *testing.T) {
 		t.Errorf("Should be false, was true: %+v - %+v.", s0, s1)
 	}
 }
+
+// TestPermitsActuatorForSanity tests for sanity.
+func TestPermitsActuatorForSanity(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		denied  []string
+		want    bool
+	}{
+		{name: "no-filter", want: true},
+		{name: "allowed-by-name", allowed: []string{"rdt"}, want: true},
+		{name: "allowed-by-group", allowed: []string{"platform"}, want: true},
+		{name: "not-allowed", allowed: []string{"scaling"}, want: false},
+		{name: "denied-by-name", denied: []string{"rdt"}, want: false},
+		{name: "denied-by-group", denied: []string{"platform"}, want: false},
+		{name: "not-denied", denied: []string{"scaling"}, want: true},
+		{name: "denied-wins", allowed: []string{"platform"}, denied: []string{"rdt"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			intent := Intent{AllowedActuators: tt.allowed, DeniedActuators: tt.denied}
+			if got := intent.PermitsActuator("rdt", "platform"); got != tt.want {
+				t.Errorf("PermitsActuator() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCoolingDownForSanity tests for sanity.
+func TestCoolingDownForSanity(t *testing.T) {
+	tests := []struct {
+		name      string
+		cooldowns map[string]time.Time
+		want      bool
+	}{
+		{name: "no-cooldowns", want: false},
+		{name: "by-name", cooldowns: map[string]time.Time{"rdt": time.Now()}, want: true},
+		{name: "by-group", cooldowns: map[string]time.Time{"platform": {}}, want: true},
+		{name: "other-actuator", cooldowns: map[string]time.Time{"scaleOut": time.Now()}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			intent := Intent{Cooldowns: tt.cooldowns}
+			if got := intent.CoolingDown("rdt", "platform"); got != tt.want {
+				t.Errorf("CoolingDown() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,457 @@
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/intel/intent-driven-orchestration/pkg/api/intents/v1alpha1"
+	"github.com/intel/intent-driven-orchestration/pkg/common"
+	clientSet "github.com/intel/intent-driven-orchestration/pkg/generated/clientset/versioned"
+
+	admissionV1 "k8s.io/api/admission/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// maxAdmissionRequestSize is the max size (bytes) of an admission review we are willing to read.
+const maxAdmissionRequestSize = 1 << 20
+
+// AdmissionWebhook validates Intents, IntentGroups and KPIProfiles before they are persisted.
+type AdmissionWebhook struct {
+	profileClient   clientSet.Interface
+	defaultProfiles *DefaultProfiles
+	queriesFile     string
+}
+
+// NewAdmissionWebhook returns a new webhook instance.
+func NewAdmissionWebhook(cfg common.MonitorConfig, profileClient clientSet.Interface) (*AdmissionWebhook, error) {
+	wh := &AdmissionWebhook{
+		profileClient:   profileClient,
+		defaultProfiles: NewDefaultProfiles(nil),
+	}
+	if cfg.Profile.ConfigMap == "" {
+		wh.queriesFile = cfg.Profile.Queries
+		if err := wh.defaultProfiles.LoadFile(wh.queriesFile); err != nil {
+			return nil, err
+		}
+	}
+	return wh, nil
+}
+
+// DefaultProfiles returns the default profile definitions used by the webhook.
+func (wh *AdmissionWebhook) DefaultProfiles() *DefaultProfiles {
+	return wh.defaultProfiles
+}
+
+// ServeHTTP handles the admission reviews send by the API server.
+func (wh *AdmissionWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		http.Error(w, "only content type application/json is supported", http.StatusUnsupportedMediaType)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxAdmissionRequestSize))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("unable to read request: %v", err), http.StatusBadRequest)
+		return
+	}
+	review := admissionV1.AdmissionReview{}
+	if err = json.Unmarshal(body, &review); err != nil || review.Request == nil {
+		http.Error(w, "unable to parse admission review", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = wh.review(r.Context(), review.Request)
+	review.Response.UID = review.Request.UID
+	review.Request = nil
+	res, err := json.Marshal(review)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("unable to marshal admission review: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err = w.Write(res); err != nil {
+		klog.Errorf("Unable to write admission review response: %v.", err)
+	}
+}
+
+// review decides whether an object is allowed or not.
+func (wh *AdmissionWebhook) review(ctx context.Context, request *admissionV1.AdmissionRequest) *admissionV1.AdmissionResponse {
+	if request.Operation != admissionV1.Create && request.Operation != admissionV1.Update {
+		return &admissionV1.AdmissionResponse{Allowed: true}
+	}
+
+	var problems []string
+	var err error
+	switch request.Kind.Kind {
+	case "Intent":
+		intent := v1alpha1.Intent{}
+		if err = json.Unmarshal(request.Object.Raw, &intent); err != nil {
+			return denied(http.StatusBadRequest, metaV1.StatusReasonBadRequest, fmt.Sprintf("unable to parse intent: %v", err))
+		}
+		problems, err = wh.validateIntent(ctx, &intent)
+	case "IntentGroup":
+		group := v1alpha1.IntentGroup{}
+		if err = json.Unmarshal(request.Object.Raw, &group); err != nil {
+			return denied(http.StatusBadRequest, metaV1.StatusReasonBadRequest, fmt.Sprintf("unable to parse intent group: %v", err))
+		}
+		problems, err = wh.validateGroup(ctx, &group)
+	case "KPIProfile":
+		profile := v1alpha1.KPIProfile{}
+		if err = json.Unmarshal(request.Object.Raw, &profile); err != nil {
+			return denied(http.StatusBadRequest, metaV1.StatusReasonBadRequest, fmt.Sprintf("unable to parse KPI profile: %v", err))
+		}
+		if profile.Namespace == "" {
+			profile.Namespace = request.Namespace
+		}
+		problems = wh.validateProfile(&profile)
+	default:
+		return &admissionV1.AdmissionResponse{Allowed: true}
+	}
+	if err != nil {
+		return denied(http.StatusInternalServerError, metaV1.StatusReasonInternalError, err.Error())
+	}
+	if len(problems) > 0 {
+		klog.Infof("Rejected %s '%s/%s': %s.", request.Kind.Kind, request.Namespace, request.Name, strings.Join(problems, "; "))
+		return denied(http.StatusUnprocessableEntity, metaV1.StatusReasonInvalid, strings.Join(problems, "; "))
+	}
+	return &admissionV1.AdmissionResponse{Allowed: true}
+}
+
+// denied returns a response rejecting the admission request.
+func denied(code int32, reason metaV1.StatusReason, message string) *admissionV1.AdmissionResponse {
+	return &admissionV1.AdmissionResponse{
+		Allowed: false,
+		Result: &metaV1.Status{
+			Status:  metaV1.StatusFailure,
+			Code:    code,
+			Reason:  reason,
+			Message: message,
+		},
+	}
+}
+
+// validateIntent returns a list of problems with the given intent; an error is returned if the validation could not be performed.
+func (wh *AdmissionWebhook) validateIntent(ctx context.Context, intent *v1alpha1.Intent) ([]string, error) {
+	var problems []string
+
+	target := intent.Spec.TargetRef
+	if target.Selector != nil {
+		if target.Name != "" {
+			problems = append(problems, "only one of targetRef.name and targetRef.selector can be set")
+		}
+		if _, err := metaV1.LabelSelectorAsSelector(target.Selector); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid targetRef.selector: %v", err))
+		}
+		if _, err := metaV1.LabelSelectorAsSelector(target.NamespaceSelector); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid targetRef.namespaceSelector: %v", err))
+		}
+	} else {
+		namespace, name, err := cache.SplitMetaNamespaceKey(target.Name)
+		if err != nil || namespace == "" || name == "" {
+			problems = append(problems, fmt.Sprintf("targetRef.name '%s' must be of the form namespace/name", target.Name))
+		}
+		if target.NamespaceSelector != nil {
+			problems = append(problems, "targetRef.namespaceSelector can only be used together with targetRef.selector")
+		}
+	}
+
+	problems = append(problems, checkBudget(intent.Spec.Budget, "budget")...)
+
+	denied := make(map[string]bool, len(intent.Spec.DeniedActuators))
+	for _, item := range intent.Spec.DeniedActuators {
+		denied[item] = true
+	}
+	for _, item := range intent.Spec.AllowedActuators {
+		if denied[item] {
+			problems = append(problems, fmt.Sprintf("actuator '%s' cannot be both allowed and denied", item))
+		}
+	}
+
+	seen := make(map[string]string)
+	for _, objective := range intent.Spec.Objectives {
+		if other, ok := seen[objective.MeasuredBy]; ok {
+			problems = append(problems, fmt.Sprintf("objectives '%s' and '%s' are both measured by profile '%s'", other, objective.Name, objective.MeasuredBy))
+			continue
+		}
+		seen[objective.MeasuredBy] = objective.Name
+		for _, window := range objective.Schedule {
+			if _, err := common.ParseScheduleWindow(window.Name, window.Days, window.Start, window.End, window.TimeZone, window.Value); err != nil {
+				problems = append(problems, fmt.Sprintf("invalid schedule of objective '%s': %v", objective.Name, err))
+			}
+		}
+
+		profile, err := wh.getProfile(ctx, objective.MeasuredBy)
+		if err != nil {
+			return nil, err
+		}
+		if profile == nil {
+			problems = append(problems, fmt.Sprintf("objective '%s' references unknown profile '%s'", objective.Name, objective.MeasuredBy))
+			continue
+		}
+		if problem := checkTolerance(objective, profile); problem != "" {
+			problems = append(problems, problem)
+		}
+		for _, window := range objective.Schedule {
+			scheduled := objective
+			scheduled.Value = window.Value
+			if problem := checkTolerance(scheduled, profile); problem != "" {
+				problems = append(problems, problem+" in window '"+window.Name+"'")
+			}
+		}
+	}
+	return problems, nil
+}
+
+// checkBudget returns a list of problems with the given budget.
+func checkBudget(budget *v1alpha1.Budget, field string) []string {
+	if budget == nil {
+		return nil
+	}
+	var problems []string
+	if budget.MinReplicas < 0 || budget.MaxReplicas < 0 || budget.MaxContainerCPU < 0 || budget.MaxTotalCPU < 0 {
+		problems = append(problems, field+" must not contain negative values")
+	}
+	if budget.MaxReplicas > 0 && budget.MinReplicas > budget.MaxReplicas {
+		problems = append(problems, fmt.Sprintf("%s.minReplicas %d must not be larger than %s.maxReplicas %d", field, budget.MinReplicas, field, budget.MaxReplicas))
+	}
+	return problems
+}
+
+// validateGroup returns a list of problems with the given intent group; an error is returned if the validation could not be performed.
+func (wh *AdmissionWebhook) validateGroup(ctx context.Context, group *v1alpha1.IntentGroup) ([]string, error) {
+	var problems []string
+
+	switch strings.ToLower(group.Spec.Aggregation) {
+	case "", "sum", "max", "min", "product":
+	default:
+		problems = append(problems, fmt.Sprintf("unknown aggregation '%s' - known aggregations are: sum, max, min, product", group.Spec.Aggregation))
+	}
+	if len(group.Spec.Members) == 0 {
+		problems = append(problems, "at least one member needs to be defined")
+	}
+
+	objective := group.Spec.Objective
+	for _, window := range objective.Schedule {
+		if _, err := common.ParseScheduleWindow(window.Name, window.Days, window.Start, window.End, window.TimeZone, window.Value); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid schedule of objective '%s': %v", objective.Name, err))
+		}
+	}
+	profiles := []string{objective.MeasuredBy}
+	seen := make(map[string]bool, len(group.Spec.Members))
+	total := 0.0
+	for i, member := range group.Spec.Members {
+		field := fmt.Sprintf("members[%d]", i)
+		target := member.TargetRef
+		namespace, name, err := cache.SplitMetaNamespaceKey(target.Name)
+		if target.Selector != nil || target.NamespaceSelector != nil {
+			problems = append(problems, field+".targetRef must reference a workload by name - selectors are not supported")
+		} else if err != nil || namespace == "" || name == "" {
+			problems = append(problems, fmt.Sprintf("%s.targetRef.name '%s' must be of the form namespace/name", field, target.Name))
+		} else if seen[target.Name] {
+			problems = append(problems, fmt.Sprintf("workload '%s' is a member more than once", target.Name))
+		}
+		seen[target.Name] = true
+		if member.Share < 0 || member.Share > 1 {
+			problems = append(problems, fmt.Sprintf("%s.share %v must be between 0 and 1", field, member.Share))
+		}
+		total += member.Share
+		problems = append(problems, checkBudget(member.Budget, field+".budget")...)
+		if member.MeasuredBy != "" {
+			profiles = append(profiles, member.MeasuredBy)
+		}
+	}
+	if total > 1 {
+		problems = append(problems, fmt.Sprintf("shares of the members add up to %v - which is more than 1", total))
+	}
+
+	for i, key := range profiles {
+		profile, err := wh.getProfile(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if profile == nil {
+			problems = append(problems, fmt.Sprintf("objective '%s' references unknown profile '%s'", objective.Name, key))
+			continue
+		}
+		if i > 0 {
+			// members' profiles are only used to measure their contribution.
+			continue
+		}
+		if problem := checkTolerance(objective, profile); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	return problems, nil
+}
+
+// getProfile returns the KPI profile for a given key - or nil if it does not exist.
+func (wh *AdmissionWebhook) getProfile(ctx context.Context, key string) (*v1alpha1.KPIProfile, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil || namespace == "" || name == "" {
+		return nil, nil
+	}
+	profile, err := wh.profileClient.IdoV1alpha1().KPIProfiles(namespace).Get(ctx, name, metaV1.GetOptions{})
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to look up profile '%s': %v", key, err)
+	}
+	return profile, nil
+}
+
+// checkTolerance makes sure that the target value of an objective - taking the tolerance into account - can be reached.
+func checkTolerance(objective v1alpha1.TargetObjective, profile *v1alpha1.KPIProfile) string {
+	if objective.Tolerance < 0 {
+		return fmt.Sprintf("tolerance %v of objective '%s' must not be negative", objective.Tolerance, objective.Name)
+	}
+	if minimize(profile) {
+		if objective.Value*(1+objective.Tolerance) <= 0 {
+			return fmt.Sprintf("objective '%s' can never be reached: profile '%s' is minimized and the target value %v is not positive", objective.Name, objective.MeasuredBy, objective.Value)
+		}
+		return ""
+	}
+	bound := objective.Value * (1 - objective.Tolerance)
+	if objective.Value > 0 && bound <= 0 {
+		return fmt.Sprintf("tolerance %v of objective '%s' is out of reach: it renders the target value %v void", objective.Tolerance, objective.Name, objective.Value)
+	}
+	if common.ProfileTypeFromText(profile.Spec.KPIType) == common.Availability && bound > 1.0 {
+		return fmt.Sprintf("objective '%s' can never be reached: availability of %v exceeds 1.0", objective.Name, bound)
+	}
+	return ""
+}
+
+// validateProfile returns a list of problems with the given KPI profile.
+func (wh *AdmissionWebhook) validateProfile(profile *v1alpha1.KPIProfile) []string {
+	var problems []string
+	if common.ProfileTypeFromText(profile.Spec.KPIType) == common.Obsolete {
+		problems = append(problems, fmt.Sprintf("unknown profile type '%s' - known types are: %s", profile.Spec.KPIType, strings.Join(common.KnownProfileTypes(), ", ")))
+	}
+	if _, found := wh.defaultProfiles.Get(profile.Namespace + "/" + profile.Name); found {
+		if err := ValidateQuery("", profile.Spec.Props["window"], true); err != nil {
+			problems = append(problems, err.Error())
+		}
+		return problems
+	}
+	endpoint, found := profile.Spec.Props["endpoint"]
+	if !found || profile.Spec.Query == "" {
+		return append(problems, "both an endpoint and a query need to be defined")
+	}
+	if tmp, err := url.ParseRequestURI(endpoint); err != nil || (tmp.Scheme != "http" && tmp.Scheme != "https") || tmp.Host == "" {
+		problems = append(problems, fmt.Sprintf("endpoint '%s' is not a valid http(s) URL", endpoint))
+	}
+	if err := ValidateQuery(profile.Spec.Query, profile.Spec.Props["window"], true); err != nil {
+		problems = append(problems, err.Error())
+	}
+	return problems
+}
+
+// Run the webhook server until the stopper channel is closed.
+func (wh *AdmissionWebhook) Run(address string, keyPair *KeyPairReloader, stopper <-chan struct{}) error {
+	mux := http.NewServeMux()
+	mux.Handle("/validate", wh)
+	server := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig: &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: keyPair.GetCertificate,
+		},
+	}
+	if wh.queriesFile != "" {
+		go wh.defaultProfiles.WatchFile(wh.queriesFile, defaultProfilesReloadPeriod, stopper)
+	}
+	go func() {
+		<-stopper
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			klog.Errorf("Error shutting down webhook server: %v.", err)
+		}
+	}()
+	klog.Infof("Starting webhook server on %s.", address)
+	if err := server.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// KeyPairReloader loads a TLS key pair from disk, and reloads it whenever the files get updated.
+type KeyPairReloader struct {
+	certFile string
+	keyFile  string
+	lock     sync.Mutex
+	cert     *tls.Certificate
+	modTime  time.Time
+}
+
+// NewKeyPairReloader returns a new reloader; fails if the key pair cannot be loaded.
+func NewKeyPairReloader(certFile string, keyFile string) (*KeyPairReloader, error) {
+	res := &KeyPairReloader{certFile: certFile, keyFile: keyFile}
+	if err := res.reload(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// reload (re)loads the key pair from disk.
+func (k *KeyPairReloader) reload() error {
+	modTime, err := k.latestModTime()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(k.certFile, k.keyFile)
+	if err != nil {
+		return fmt.Errorf("unable to load key pair: %v", err)
+	}
+	k.cert = &cert
+	k.modTime = modTime
+	return nil
+}
+
+// latestModTime returns the latest modification time of the certificate & key file.
+func (k *KeyPairReloader) latestModTime() (time.Time, error) {
+	var res time.Time
+	for _, file := range []string{k.certFile, k.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return res, fmt.Errorf("unable to stat file: %v", err)
+		}
+		if info.ModTime().After(res) {
+			res = info.ModTime()
+		}
+	}
+	return res, nil
+}
+
+// GetCertificate returns the current certificate; can be used in a tls.Config.
+func (k *KeyPairReloader) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	modTime, err := k.latestModTime()
+	if err == nil && modTime.After(k.modTime) {
+		if err = k.reload(); err != nil {
+			klog.Warningf("Will continue to use old key pair: %v.", err)
+		} else {
+			klog.Info("Reloaded key pair for webhook server.")
+		}
+	}
+	return k.cert, nil
+}
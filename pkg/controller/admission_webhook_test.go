@@ -0,0 +1,505 @@
+package controller
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/intel/intent-driven-orchestration/pkg/api/intents/v1alpha1"
+	"github.com/intel/intent-driven-orchestration/pkg/common"
+	"github.com/intel/intent-driven-orchestration/pkg/generated/clientset/versioned/fake"
+
+	admissionV1 "k8s.io/api/admission/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/cert"
+)
+
+// newTestProfile returns a KPI profile for testing.
+func newTestProfile(name string, kpiType string, minimize bool) *v1alpha1.KPIProfile {
+	return &v1alpha1.KPIProfile{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: metaV1.NamespaceDefault},
+		Spec:       v1alpha1.KPIProfileSpec{KPIType: kpiType, Minimize: minimize},
+	}
+}
+
+// newTestWebhook returns a webhook ready for testing.
+func newTestWebhook(t *testing.T) *AdmissionWebhook {
+	cfg := common.MonitorConfig{}
+	cfg.Profile.Queries = "../../artefacts/examples/default_queries.json"
+	client := fake.NewSimpleClientset(
+		newTestProfile("p99latency", "latency", true),
+		newTestProfile("availability", "availability", false),
+		newTestProfile("throughput", "throughput", false))
+	wh, err := NewAdmissionWebhook(cfg, client)
+	if err != nil {
+		t.Fatalf("Could not create webhook: %v", err)
+	}
+	return wh
+}
+
+// newWebhookIntent returns a valid intent for testing.
+func newWebhookIntent() *v1alpha1.Intent {
+	intent := newIntent("my-intent", 10)
+	intent.Spec.TargetRef.Name = "default/my-deployment"
+	intent.Spec.Objectives[0].MeasuredBy = "default/p99latency"
+	return intent
+}
+
+// newReview returns an admission review for a given object.
+func newReview(t *testing.T, kind string, obj runtime.Object) []byte {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("Could not marshal object: %v", err)
+	}
+	review := admissionV1.AdmissionReview{
+		TypeMeta: metaV1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+		Request: &admissionV1.AdmissionRequest{
+			UID:       types.UID("abc"),
+			Kind:      metaV1.GroupVersionKind{Group: "ido.intel.com", Version: "v1alpha1", Kind: kind},
+			Namespace: metaV1.NamespaceDefault,
+			Operation: admissionV1.Create,
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+	res, err := json.Marshal(review)
+	if err != nil {
+		t.Fatalf("Could not marshal review: %v", err)
+	}
+	return res
+}
+
+// doReview sends a review to the webhook and returns the response.
+func doReview(t *testing.T, wh *AdmissionWebhook, body []byte) *admissionV1.AdmissionResponse {
+	req := httptest.NewRequest(http.MethodPost, "/validate", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	wh.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status code 200 - got: %d.", rec.Code)
+	}
+	review := admissionV1.AdmissionReview{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &review); err != nil {
+		t.Fatalf("Could not unmarshal response: %v", err)
+	}
+	if review.Response == nil || review.Response.UID != "abc" {
+		t.Fatalf("Invalid response: %v.", review.Response)
+	}
+	return review.Response
+}
+
+// writeKeyPair writes a self-signed key pair to the given directory.
+func writeKeyPair(t *testing.T, dir string, host string) (string, string) {
+	certData, keyData, err := cert.GenerateSelfSignedCertKey(host, nil, nil)
+	if err != nil {
+		t.Fatalf("Could not generate key pair: %v", err)
+	}
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	if err = os.WriteFile(certFile, certData, 0600); err != nil {
+		t.Fatalf("Could not write cert: %v", err)
+	}
+	if err = os.WriteFile(keyFile, keyData, 0600); err != nil {
+		t.Fatalf("Could not write key: %v", err)
+	}
+	return certFile, keyFile
+}
+
+// Tests for success.
+
+// TestValidateIntentForSuccess tests for success.
+func TestValidateIntentForSuccess(t *testing.T) {
+	wh := newTestWebhook(t)
+	intent := newWebhookIntent()
+	res := doReview(t, wh, newReview(t, "Intent", intent))
+	if !res.Allowed {
+		t.Errorf("Intent should have been allowed: %v.", res.Result)
+	}
+
+	// selector instead of a name.
+	intent.Spec.TargetRef.Name = ""
+	intent.Spec.TargetRef.Selector = &metaV1.LabelSelector{MatchLabels: map[string]string{"tier": "web"}}
+	intent.Spec.TargetRef.NamespaceSelector = &metaV1.LabelSelector{}
+	res = doReview(t, wh, newReview(t, "Intent", intent))
+	if !res.Allowed {
+		t.Errorf("Intent should have been allowed: %v.", res.Result)
+	}
+
+	// schedule windows.
+	intent = newWebhookIntent()
+	intent.Spec.Objectives[0].Schedule = []v1alpha1.ScheduleWindow{
+		{Name: "night", Days: []string{"Mon", "Tue"}, Start: "22:00", End: "06:00", TimeZone: "Europe/Berlin", Value: 100},
+	}
+	res = doReview(t, wh, newReview(t, "Intent", intent))
+	if !res.Allowed {
+		t.Errorf("Intent should have been allowed: %v.", res.Result)
+	}
+
+	// budget.
+	intent = newWebhookIntent()
+	intent.Spec.Budget = &v1alpha1.Budget{MinReplicas: 2, MaxReplicas: 4, MaxContainerCPU: 2000}
+	res = doReview(t, wh, newReview(t, "Intent", intent))
+	if !res.Allowed {
+		t.Errorf("Intent should have been allowed: %v.", res.Result)
+	}
+
+	// actuator filters.
+	intent = newWebhookIntent()
+	intent.Spec.AllowedActuators = []string{"scaling"}
+	intent.Spec.DeniedActuators = []string{"rmpod"}
+	res = doReview(t, wh, newReview(t, "Intent", intent))
+	if !res.Allowed {
+		t.Errorf("Intent should have been allowed: %v.", res.Result)
+	}
+}
+
+// TestValidateGroupForSuccess tests for success.
+func TestValidateGroupForSuccess(t *testing.T) {
+	wh := newTestWebhook(t)
+	group := newIntentGroup("chain", 100, "default/a", "default/b")
+	group.Spec.Members[0].Share = 0.6
+	group.Spec.Members[1].MeasuredBy = "default/p99latency"
+	res := doReview(t, wh, newReview(t, "IntentGroup", group))
+	if !res.Allowed {
+		t.Errorf("Intent group should have been allowed: %v.", res.Result)
+	}
+}
+
+// TestValidateProfileForSuccess tests for success.
+func TestValidateProfileForSuccess(t *testing.T) {
+	wh := newTestWebhook(t)
+	profile := newTestProfile("my-profile", "latency", true)
+	profile.Spec.Query = "foo"
+	profile.Spec.Props = map[string]string{"endpoint": "http://prometheus:9090/api/v1/query"}
+	res := doReview(t, wh, newReview(t, "KPIProfile", profile))
+	if !res.Allowed {
+		t.Errorf("Profile should have been allowed: %v.", res.Result)
+	}
+
+	// query using named placeholders.
+	profile.Spec.Query = "foo{namespace=\"{{.Namespace}}\",pod=~\"{{.Pods}}\"}[{{.Window}}]"
+	profile.Spec.Props["window"] = "1m"
+	res = doReview(t, wh, newReview(t, "KPIProfile", profile))
+	if !res.Allowed {
+		t.Errorf("Profile should have been allowed: %v.", res.Result)
+	}
+}
+
+// TestKeyPairReloaderForSuccess tests for success.
+func TestKeyPairReloaderForSuccess(t *testing.T) {
+	certFile, keyFile := writeKeyPair(t, t.TempDir(), "localhost")
+	reloader, err := NewKeyPairReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("Should have loaded key pair: %v", err)
+	}
+	res, err := reloader.GetCertificate(&tls.ClientHelloInfo{})
+	if err != nil || res == nil {
+		t.Errorf("Should have returned a certificate: %v", err)
+	}
+}
+
+// Tests for failure.
+
+// TestNewAdmissionWebhookForFailure tests for failure.
+func TestNewAdmissionWebhookForFailure(t *testing.T) {
+	cfg := common.MonitorConfig{}
+	cfg.Profile.Queries = "foo/bar.json"
+	if _, err := NewAdmissionWebhook(cfg, fake.NewSimpleClientset()); err == nil {
+		t.Error("Should have failed - file does not exist.")
+	}
+}
+
+// TestServeHTTPForFailure tests for failure.
+func TestServeHTTPForFailure(t *testing.T) {
+	wh := newTestWebhook(t)
+
+	// wrong method.
+	rec := httptest.NewRecorder()
+	wh.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/validate", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 - got: %d.", rec.Code)
+	}
+
+	// wrong content type.
+	rec = httptest.NewRecorder()
+	wh.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader("{}")))
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("Expected 415 - got: %d.", rec.Code)
+	}
+
+	// garbage & missing request.
+	for _, body := range []string{"foo", "{}"} {
+		rec = httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/validate", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		wh.ServeHTTP(rec, req)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 - got: %d.", rec.Code)
+		}
+	}
+}
+
+// TestValidateIntentForFailure tests for failure.
+func TestValidateIntentForFailure(t *testing.T) {
+	wh := newTestWebhook(t)
+
+	// duplicate profiles, unknown profile & malformed target.
+	intent := newWebhookIntent()
+	intent.Spec.TargetRef.Name = "my-deployment"
+	intent.Spec.Objectives = append(intent.Spec.Objectives,
+		v1alpha1.TargetObjective{Name: "again", Value: 20, MeasuredBy: "default/p99latency"},
+		v1alpha1.TargetObjective{Name: "foo", Value: 20, MeasuredBy: "default/foo"})
+	res := doReview(t, wh, newReview(t, "Intent", intent))
+	if res.Allowed || res.Result.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Intent should have been rejected: %v.", res)
+	}
+	for _, item := range []string{"namespace/name", "both measured by", "unknown profile 'default/foo'"} {
+		if !strings.Contains(res.Result.Message, item) {
+			t.Errorf("Expected '%s' in message: %s.", item, res.Result.Message)
+		}
+	}
+
+	// both name & (invalid) selector.
+	intent = newWebhookIntent()
+	intent.Spec.TargetRef.Selector = &metaV1.LabelSelector{MatchExpressions: []metaV1.LabelSelectorRequirement{{Key: "tier", Operator: "Foo"}}}
+	res = doReview(t, wh, newReview(t, "Intent", intent))
+	if res.Allowed || !strings.Contains(res.Result.Message, "only one of") || !strings.Contains(res.Result.Message, "invalid targetRef.selector") {
+		t.Errorf("Intent should have been rejected: %v.", res.Result)
+	}
+
+	// namespace selector w/o a selector.
+	intent = newWebhookIntent()
+	intent.Spec.TargetRef.NamespaceSelector = &metaV1.LabelSelector{}
+	res = doReview(t, wh, newReview(t, "Intent", intent))
+	if res.Allowed || !strings.Contains(res.Result.Message, "namespaceSelector") {
+		t.Errorf("Intent should have been rejected: %v.", res.Result)
+	}
+
+	// invalid budget.
+	intent = newWebhookIntent()
+	intent.Spec.Budget = &v1alpha1.Budget{MinReplicas: 4, MaxReplicas: 2, MaxTotalCPU: -1}
+	res = doReview(t, wh, newReview(t, "Intent", intent))
+	if res.Allowed || !strings.Contains(res.Result.Message, "negative values") || !strings.Contains(res.Result.Message, "must not be larger") {
+		t.Errorf("Intent should have been rejected: %v.", res.Result)
+	}
+
+	// actuator both allowed and denied.
+	intent = newWebhookIntent()
+	intent.Spec.AllowedActuators = []string{"scaling", "rdt"}
+	intent.Spec.DeniedActuators = []string{"rdt"}
+	res = doReview(t, wh, newReview(t, "Intent", intent))
+	if res.Allowed || !strings.Contains(res.Result.Message, "actuator 'rdt' cannot be both allowed and denied") {
+		t.Errorf("Intent should have been rejected: %v.", res.Result)
+	}
+
+	// invalid schedule windows.
+	intent = newWebhookIntent()
+	intent.Spec.Objectives[0].Tolerance = 0.5
+	intent.Spec.Objectives[0].Schedule = []v1alpha1.ScheduleWindow{
+		{Name: "night", Start: "22:00", End: "06:00", TimeZone: "Mars/Olympus_Mons", Value: 100},
+		{Name: "day", Days: []string{"Someday"}, Start: "08:00", End: "22:00", Value: 100},
+		{Name: "weekend", Days: []string{"Sat"}, Start: "00:00", End: "24:00", Value: -1},
+	}
+	res = doReview(t, wh, newReview(t, "Intent", intent))
+	for _, item := range []string{"invalid timezone of window 'night'", "invalid day 'Someday'", "in window 'weekend'"} {
+		if res.Allowed || !strings.Contains(res.Result.Message, item) {
+			t.Errorf("Expected '%s' in message: %v.", item, res.Result)
+		}
+	}
+
+	// unparsable object.
+	body := newReview(t, "Intent", intent)
+	body = bytes.Replace(body, []byte(`"spec":{`), []byte(`"spec":{"priority":"foo",`), 1)
+	res = doReview(t, wh, body)
+	if res.Allowed || res.Result.Code != http.StatusBadRequest {
+		t.Errorf("Intent should have been rejected: %v.", res)
+	}
+}
+
+// TestValidateGroupForFailure tests for failure.
+func TestValidateGroupForFailure(t *testing.T) {
+	wh := newTestWebhook(t)
+
+	// no members & unknown aggregation.
+	group := newIntentGroup("chain", 100)
+	group.Spec.Aggregation = "avg"
+	res := doReview(t, wh, newReview(t, "IntentGroup", group))
+	for _, item := range []string{"unknown aggregation 'avg'", "at least one member"} {
+		if res.Allowed || !strings.Contains(res.Result.Message, item) {
+			t.Errorf("Expected '%s' in message: %v.", item, res.Result)
+		}
+	}
+
+	// malformed, duplicate & selected members; invalid shares & budget; unknown profile.
+	group = newIntentGroup("chain", 100, "a", "default/b", "default/b", "")
+	group.Spec.Members[1].Share = 0.8
+	group.Spec.Members[2].Share = 1.2
+	group.Spec.Members[2].Budget = &v1alpha1.Budget{MaxReplicas: -1}
+	group.Spec.Members[3].TargetRef.Selector = &metaV1.LabelSelector{}
+	group.Spec.Members[3].MeasuredBy = "default/foo"
+	res = doReview(t, wh, newReview(t, "IntentGroup", group))
+	for _, item := range []string{"members[0].targetRef.name 'a'", "'default/b' is a member more than once", "members[2].share",
+		"add up to 2", "members[2].budget", "selectors are not supported", "unknown profile 'default/foo'"} {
+		if res.Allowed || !strings.Contains(res.Result.Message, item) {
+			t.Errorf("Expected '%s' in message: %v.", item, res.Result)
+		}
+	}
+
+	// unreachable end-to-end objective.
+	group = newIntentGroup("chain", 0, "default/a")
+	res = doReview(t, wh, newReview(t, "IntentGroup", group))
+	if res.Allowed || !strings.Contains(res.Result.Message, "can never be reached") {
+		t.Errorf("Intent group should have been rejected: %v.", res.Result)
+	}
+}
+
+// TestValidateProfileForFailure tests for failure.
+func TestValidateProfileForFailure(t *testing.T) {
+	wh := newTestWebhook(t)
+
+	profile := newTestProfile("my-profile", "foo", true)
+	res := doReview(t, wh, newReview(t, "KPIProfile", profile))
+	if res.Allowed || !strings.Contains(res.Result.Message, "unknown profile type") ||
+		!strings.Contains(res.Result.Message, "endpoint and a query") {
+		t.Errorf("Profile should have been rejected: %v.", res.Result)
+	}
+
+	profile = newTestProfile("my-profile", "latency", true)
+	profile.Spec.Query = "foo"
+	profile.Spec.Props = map[string]string{"endpoint": "foo"}
+	res = doReview(t, wh, newReview(t, "KPIProfile", profile))
+	if res.Allowed || !strings.Contains(res.Result.Message, "not a valid http(s) URL") {
+		t.Errorf("Profile should have been rejected: %v.", res.Result)
+	}
+
+	// invalid query template & window.
+	profile = newTestProfile("my-profile", "latency", true)
+	profile.Spec.Query = "foo{pod=~\"{{.Pod}}\"}"
+	profile.Spec.Props = map[string]string{"endpoint": "http://prometheus:9090/api/v1/query", "window": "5 minutes"}
+	res = doReview(t, wh, newReview(t, "KPIProfile", profile))
+	if res.Allowed || !strings.Contains(res.Result.Message, "invalid time window") {
+		t.Errorf("Profile should have been rejected: %v.", res.Result)
+	}
+	profile.Spec.Props["window"] = "5m"
+	res = doReview(t, wh, newReview(t, "KPIProfile", profile))
+	if res.Allowed || !strings.Contains(res.Result.Message, "invalid query template") {
+		t.Errorf("Profile should have been rejected: %v.", res.Result)
+	}
+}
+
+// TestKeyPairReloaderForFailure tests for failure.
+func TestKeyPairReloaderForFailure(t *testing.T) {
+	if _, err := NewKeyPairReloader("foo/tls.crt", "foo/tls.key"); err == nil {
+		t.Error("Should have failed - files do not exist.")
+	}
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	_ = os.WriteFile(certFile, []byte("foo"), 0600)
+	_ = os.WriteFile(keyFile, []byte("bar"), 0600)
+	if _, err := NewKeyPairReloader(certFile, keyFile); err == nil {
+		t.Error("Should have failed - files are not valid.")
+	}
+}
+
+// Tests for sanity.
+
+// TestCheckToleranceForSanity tests for sanity.
+func TestCheckToleranceForSanity(t *testing.T) {
+	latency := newTestProfile("p99latency", "latency", true)
+	availability := newTestProfile("availability", "availability", false)
+	throughput := newTestProfile("throughput", "throughput", false)
+	tests := []struct {
+		name      string
+		objective v1alpha1.TargetObjective
+		profile   *v1alpha1.KPIProfile
+		valid     bool
+	}{
+		{name: "tc-0", objective: v1alpha1.TargetObjective{Value: 10, Tolerance: 0.1}, profile: latency, valid: true},
+		{name: "tc-1", objective: v1alpha1.TargetObjective{Value: 10, Tolerance: -0.1}, profile: latency, valid: false},
+		{name: "tc-2", objective: v1alpha1.TargetObjective{Value: 0, Tolerance: 0.5}, profile: latency, valid: false},
+		{name: "tc-3", objective: v1alpha1.TargetObjective{Value: 0.99, Tolerance: 0.01}, profile: availability, valid: true},
+		{name: "tc-4", objective: v1alpha1.TargetObjective{Value: 1.2, Tolerance: 0.1}, profile: availability, valid: false},
+		{name: "tc-5", objective: v1alpha1.TargetObjective{Value: 0.99, Tolerance: 1.0}, profile: availability, valid: false},
+		{name: "tc-6", objective: v1alpha1.TargetObjective{Value: 0, Tolerance: 0}, profile: throughput, valid: true},
+		{name: "tc-7", objective: v1alpha1.TargetObjective{Value: 100, Tolerance: 2}, profile: throughput, valid: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkTolerance(tt.objective, tt.profile); (got == "") != tt.valid {
+				t.Errorf("checkTolerance() = '%v', expected valid: %v", got, tt.valid)
+			}
+		})
+	}
+}
+
+// TestReviewForSanity tests for sanity.
+func TestReviewForSanity(t *testing.T) {
+	wh := newTestWebhook(t)
+
+	// default profiles need no endpoint.
+	res := doReview(t, wh, newReview(t, "KPIProfile", newTestProfile("p99latency", "latency", true)))
+	if !res.Allowed {
+		t.Errorf("Default profile should have been allowed: %v.", res.Result)
+	}
+
+	// unreachable tolerance.
+	intent := newWebhookIntent()
+	intent.Spec.Objectives = append(intent.Spec.Objectives, v1alpha1.TargetObjective{Name: "avail", Value: 0.999, Tolerance: 1.5, MeasuredBy: "default/availability"})
+	res = doReview(t, wh, newReview(t, "Intent", intent))
+	if res.Allowed || !strings.Contains(res.Result.Message, "out of reach") {
+		t.Errorf("Intent should have been rejected: %v.", res.Result)
+	}
+
+	// deletes & other kinds are always allowed.
+	review := admissionV1.AdmissionReview{Request: &admissionV1.AdmissionRequest{UID: "abc", Operation: admissionV1.Delete, Kind: metaV1.GroupVersionKind{Kind: "Intent"}}}
+	body, _ := json.Marshal(review)
+	if res = doReview(t, wh, body); !res.Allowed {
+		t.Errorf("Delete should have been allowed: %v.", res.Result)
+	}
+	review.Request.Operation = admissionV1.Create
+	review.Request.Kind.Kind = "Pod"
+	body, _ = json.Marshal(review)
+	if res = doReview(t, wh, body); !res.Allowed {
+		t.Errorf("Other kinds should have been allowed: %v.", res.Result)
+	}
+}
+
+// TestKeyPairReloaderForSanity tests for sanity.
+func TestKeyPairReloaderForSanity(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeKeyPair(t, dir, "foo.example.com")
+	reloader, err := NewKeyPairReloader(certFile, keyFile)
+	if err != nil {
+		t.Fatalf("Should have loaded key pair: %v", err)
+	}
+	first, _ := reloader.GetCertificate(nil)
+
+	// broken files --> stick with old one.
+	later := time.Now().Add(time.Minute)
+	_ = os.WriteFile(certFile, []byte("foo"), 0600)
+	_ = os.Chtimes(certFile, later, later)
+	res, _ := reloader.GetCertificate(nil)
+	if res != first {
+		t.Error("Should have kept the old certificate.")
+	}
+
+	// rotated files --> new one.
+	writeKeyPair(t, dir, "bar.example.com")
+	later = later.Add(time.Minute)
+	_ = os.Chtimes(certFile, later, later)
+	_ = os.Chtimes(keyFile, later, later)
+	res, _ = reloader.GetCertificate(nil)
+	if res == first {
+		t.Error("Should have reloaded the certificate.")
+	}
+}
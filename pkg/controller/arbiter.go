@@ -0,0 +1,414 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/intel/intent-driven-orchestration/pkg/common"
+	"github.com/intel/intent-driven-orchestration/pkg/planner"
+
+	coreV1 "k8s.io/api/core/v1"
+	v1 "k8s.io/client-go/informers/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// nodeResources holds per node the amount - in milli units - of each resource.
+type nodeResources map[string]map[string]int64
+
+// copy returns a deep copy.
+func (n nodeResources) copy() nodeResources {
+	res := make(nodeResources, len(n))
+	for node, resources := range n {
+		res[node] = make(map[string]int64, len(resources))
+		for name, value := range resources {
+			res[node][name] = value
+		}
+	}
+	return res
+}
+
+// candidate represents a plan waiting for the arbitration.
+type candidate struct {
+	intent  common.Intent
+	current common.State
+	plan    []planner.Action
+	// steps holds the state each action of the plan is predicted to lead to.
+	steps   []common.State
+	verdict chan verdict
+}
+
+// verdict is the outcome of the arbitration for a plan.
+type verdict struct {
+	// admitted is the number of actions of the plan which fit the capacity of the nodes.
+	admitted int
+	// reason why the plan was trimmed or rejected - empty if it was admitted as is.
+	reason string
+}
+
+// reservation is the capacity reserved on a node for the plans admitted in earlier rounds.
+type reservation struct {
+	amount map[string]int64
+	// baseline holds the requests of the PODs on the node when the capacity was first reserved.
+	baseline map[string]int64
+	expires  time.Time
+}
+
+// arbiter collects the plans created for different intents within a window - e.g. during one tick - and checks them
+// against the capacity of the nodes. Plans of higher priority intents are admitted first; plans which do not fit
+// anymore are trimmed to the actions which still fit, or rejected. The capacity admitted plans need is reserved until
+// the informer reflects it - so plans arbitrated in later rounds do not get admitted for the same capacity.
+type arbiter struct {
+	window       time.Duration
+	ttl          time.Duration
+	nodeInformer v1.NodeInformer
+	informer     v1.PodInformer
+	lock         sync.Mutex
+	round        []*candidate
+	// generation of the current round - so a timer firing late does not close the next round.
+	generation int
+	timer      *time.Timer
+	// decisions serializes deciding the rounds - as they share the reservations.
+	decisions sync.Mutex
+	// reserved holds per node the capacity reserved for admitted plans - guarded by decisions.
+	reserved map[string]*reservation
+}
+
+// newArbiter initializes a new arbiter; rounds close after the window has passed since the first plan was added, and
+// reservations are held for at most the given ttl.
+func newArbiter(window time.Duration, ttl time.Duration, nodeInformer v1.NodeInformer, informer v1.PodInformer) *arbiter {
+	indexPodsByNode(informer)
+	return &arbiter{window: window, ttl: ttl, nodeInformer: nodeInformer, informer: informer, reserved: map[string]*reservation{}}
+}
+
+// arbitrate adds a plan to the current round and waits for the round to be decided.
+func (a *arbiter) arbitrate(item *candidate) verdict {
+	item.verdict = make(chan verdict, 1)
+	a.lock.Lock()
+	a.round = append(a.round, item)
+	if len(a.round) == 1 {
+		generation := a.generation
+		a.timer = time.AfterFunc(a.window, func() {
+			a.close(generation)
+		})
+	}
+	a.lock.Unlock()
+	return <-item.verdict
+}
+
+// close decides the round of the given generation - if not done already.
+func (a *arbiter) close(generation int) {
+	a.lock.Lock()
+	if generation != a.generation {
+		a.lock.Unlock()
+		return
+	}
+	round := a.take()
+	a.lock.Unlock()
+	a.decide(round)
+}
+
+// take returns the plans of the current round and starts a new one; needs to be called holding the lock.
+func (a *arbiter) take() []*candidate {
+	if a.timer != nil {
+		a.timer.Stop()
+		a.timer = nil
+	}
+	res := a.round
+	a.round = nil
+	a.generation++
+	return res
+}
+
+// decide checks the plans of a round - ordered by the priority of their intents - against the free capacity of the
+// nodes, minus the capacity reserved for plans admitted in earlier rounds.
+func (a *arbiter) decide(round []*candidate) {
+	if len(round) == 0 {
+		return
+	}
+	a.decisions.Lock()
+	defer a.decisions.Unlock()
+	free, requested, err := nodeFree(a.nodeInformer, a.informer)
+	if err != nil || len(free) == 0 {
+		klog.Warningf("Could not determine the free capacity of the nodes - admitting all plans: %v.", err)
+		for _, item := range round {
+			item.verdict <- verdict{admitted: len(item.plan)}
+		}
+		return
+	}
+	now := time.Now()
+	a.withhold(free, requested, now)
+	before := free.copy()
+	sort.SliceStable(round, func(i, j int) bool {
+		if round[i].intent.Priority != round[j].intent.Priority {
+			return round[i].intent.Priority > round[j].intent.Priority
+		}
+		return round[i].intent.Key < round[j].intent.Key
+	})
+	consumers := map[string][]string{}
+	for _, item := range round {
+		item.verdict <- admit(item, free, consumers)
+	}
+	a.reserve(before, free, requested, now)
+}
+
+// withhold subtracts the reserved capacity not yet reflected by the requests of the PODs from the free capacity of the
+// nodes; reservations which are reflected, or expired, are dropped. Needs to be called holding the decisions lock.
+func (a *arbiter) withhold(free nodeResources, requested nodeResources, now time.Time) {
+	for node, item := range a.reserved {
+		outstanding := map[string]int64{}
+		for name, value := range item.amount {
+			reflected := requested[node][name] - item.baseline[name]
+			if reflected < 0 {
+				reflected = 0
+			}
+			if value > reflected {
+				outstanding[name] = value - reflected
+			}
+		}
+		if len(outstanding) == 0 || now.After(item.expires) || free[node] == nil {
+			delete(a.reserved, node)
+			continue
+		}
+		for name, value := range outstanding {
+			free[node][name] -= value
+		}
+	}
+}
+
+// reserve records the capacity the plans admitted in a round took from the free capacity of the nodes. Needs to be
+// called holding the decisions lock.
+func (a *arbiter) reserve(before nodeResources, after nodeResources, requested nodeResources, now time.Time) {
+	for node, resources := range before {
+		for name, value := range resources {
+			taken := value - after[node][name]
+			if taken <= 0 {
+				continue
+			}
+			item, ok := a.reserved[node]
+			if !ok {
+				item = &reservation{amount: map[string]int64{}, baseline: requested[node]}
+				a.reserved[node] = item
+			}
+			item.amount[name] += taken
+			item.expires = now.Add(a.ttl)
+		}
+	}
+}
+
+// admit returns how many actions of a plan fit the free capacity of the nodes - and reserves the capacity they need.
+// The consumers hold per node the intents whose plans were admitted before and needed capacity on it.
+func admit(item *candidate, free nodeResources, consumers map[string][]string) verdict {
+	if len(item.steps) != len(item.plan) {
+		// capacity needed is unknown if the planner does not predict the states.
+		return verdict{admitted: len(item.plan)}
+	}
+	var reason string
+	for n := len(item.plan); n > 0; n-- {
+		perNode, pending := demand(item.current, item.steps[n-1])
+		placed, nodes, node, resource := fit(free, perNode, pending)
+		if placed != nil {
+			for k, v := range placed {
+				free[k] = v
+			}
+			for _, name := range nodes {
+				consumers[name] = append(consumers[name], item.intent.Key)
+			}
+			return verdict{admitted: n, reason: reason}
+		}
+		if reason == "" {
+			reason = insufficient(node, resource, consumers)
+		}
+	}
+	return verdict{reason: reason}
+}
+
+// insufficient describes why a plan did not fit.
+func insufficient(node string, resource string, consumers map[string][]string) string {
+	var res string
+	var lostTo []string
+	if node != "" {
+		res = fmt.Sprintf("insufficient %s on node %s", resource, node)
+		lostTo = consumers[node]
+	} else {
+		res = fmt.Sprintf("no node with enough free %s for the new POD(s)", resource)
+		for _, keys := range consumers {
+			lostTo = append(lostTo, keys...)
+		}
+	}
+	if len(lostTo) > 0 {
+		sort.Strings(lostTo)
+		res += " - lost to " + strings.Join(lostTo, ", ")
+	}
+	return res
+}
+
+// fit checks if the additional resources needed per node, and those of the PODs yet to be scheduled, fit the free
+// capacity of the nodes. Returns the free capacity left and the nodes which provided capacity; otherwise the node - empty
+// for PODs yet to be scheduled - and the resource which did not fit.
+func fit(free nodeResources, perNode nodeResources, pending []map[string]int64) (nodeResources, []string, string, string) {
+	res := free.copy()
+	var used []string
+	for _, node := range sortedKeys(perNode) {
+		if _, ok := res[node]; !ok {
+			// PODs not scheduled yet, or running on nodes unknown to us.
+			continue
+		}
+		needed := false
+		for _, name := range sortedKeys(perNode[node]) {
+			value := perNode[node][name]
+			res[node][name] -= value
+			if value > 0 {
+				needed = true
+				if res[node][name] < 0 {
+					return nil, nil, node, name
+				}
+			}
+		}
+		if needed {
+			used = append(used, node)
+		}
+	}
+	nodes := sortedKeys(res)
+	for _, requests := range pending {
+		placed := false
+		var missing string
+		for _, node := range nodes {
+			if name := lacking(res[node], requests); name != "" {
+				if missing == "" {
+					missing = name
+				}
+				continue
+			}
+			for name, value := range requests {
+				res[node][name] -= value
+			}
+			used = append(used, node)
+			placed = true
+			break
+		}
+		if !placed {
+			if missing == "" {
+				missing = "resources"
+			}
+			return nil, nil, "", missing
+		}
+	}
+	return res, used, "", ""
+}
+
+// lacking returns the first resource of which not enough is free - or an empty string if the requests fit.
+func lacking(free map[string]int64, requests map[string]int64) string {
+	for _, name := range sortedKeys(requests) {
+		if requests[name] > 0 && free[name] < requests[name] {
+			return name
+		}
+	}
+	return ""
+}
+
+// demand returns the resources the target of an intent needs in addition - per node - in the predicted state compared
+// to the current one, and the requests of the PODs yet to be scheduled. Resources freed are returned as negative values.
+func demand(current common.State, predicted common.State) (nodeResources, []map[string]int64) {
+	before := podRequests(current.Resources)
+	after := podRequests(predicted.Resources)
+	res := nodeResources{}
+	add := func(node string, requests map[string]int64, sign int64) {
+		if res[node] == nil {
+			res[node] = map[string]int64{}
+		}
+		for name, value := range requests {
+			res[node][name] += sign * value
+		}
+	}
+	var pending []map[string]int64
+	for name := range predicted.CurrentPods {
+		if pod, ok := current.CurrentPods[name]; ok {
+			add(pod.NodeName, after, 1)
+			add(pod.NodeName, before, -1)
+		} else {
+			pending = append(pending, after)
+		}
+	}
+	for name, pod := range current.CurrentPods {
+		if _, ok := predicted.CurrentPods[name]; !ok {
+			add(pod.NodeName, before, -1)
+		}
+	}
+	return res, pending
+}
+
+// podRequests sums up the requests of the containers of a POD per resource - based on the resources of a state.
+func podRequests(resources map[string]int64) map[string]int64 {
+	res := map[string]int64{}
+	suffix := resourceDelimiter + "requests"
+	for key, value := range resources {
+		if !strings.HasSuffix(key, suffix) {
+			continue
+		}
+		// keys hold the container index, the resource name and the type.
+		index := strings.Index(key, resourceDelimiter)
+		if index < 0 || index+1 > len(key)-len(suffix) {
+			continue
+		}
+		res[key[index+1:len(key)-len(suffix)]] += value
+	}
+	return res
+}
+
+// nodeFree returns the resources allocatable on each node minus the requests of the PODs running on it - and those
+// requests.
+func nodeFree(nodeInformer v1.NodeInformer, informer v1.PodInformer) (nodeResources, nodeResources, error) {
+	nodes, requested, err := nodeUsage(nodeInformer, informer, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	res := nodeResources{}
+	for _, node := range nodes {
+		res[node.Name] = map[string]int64{}
+		for name, quantity := range node.Status.Allocatable {
+			res[node.Name][name.String()] = quantity.MilliValue()
+		}
+		for name, value := range requested[node.Name] {
+			res[node.Name][name] -= value
+		}
+	}
+	return res, requested, nil
+}
+
+// sortedKeys returns the keys of a map in order.
+func sortedKeys[V any](items map[string]V) []string {
+	res := make([]string, 0, len(items))
+	for key := range items {
+		res = append(res, key)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// arbitrate checks a plan against the capacity of the nodes - together with the plans created for other intents at the
+// same time. Returns the actions admitted, the states they are predicted to lead to, and - if the plan was trimmed or
+// rejected - why.
+func (c *IntentController) arbitrate(key string, current common.State, desired common.State, plan []planner.Action, steps []common.State) ([]planner.Action, []common.State, string) {
+	if c.arbiter == nil {
+		return plan, steps, ""
+	}
+	c.lendWorker()
+	res := c.arbiter.arbitrate(&candidate{intent: desired.Intent, current: current, plan: plan, steps: steps})
+	if res.reason == "" {
+		return plan, steps, ""
+	}
+	outcome := "trimmed"
+	if res.admitted == 0 {
+		outcome = "rejected"
+	}
+	klog.Warningf("Plan for %s %s during the arbitration: %s.", key, outcome, res.reason)
+	common.ArbitrationLosses.WithLabelValues(outcome).Inc()
+	c.IntentEvent(desired.Intent, coreV1.EventTypeWarning, ReasonArbitrationLost, "Plan %s %s: %s.", planSummary(plan), outcome, res.reason)
+	if len(steps) == len(plan) {
+		steps = steps[:res.admitted]
+	}
+	return plan[:res.admitted], steps, res.reason
+}
@@ -0,0 +1,316 @@
+package controller
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/intel/intent-driven-orchestration/pkg/common"
+	"github.com/intel/intent-driven-orchestration/pkg/planner"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+)
+
+// constrainedNode returns a node with the given amount of allocatable CPU.
+func constrainedNode(name string, cpu string) *coreV1.Node {
+	return &coreV1.Node{
+		ObjectMeta: metaV1.ObjectMeta{Name: name},
+		Status: coreV1.NodeStatus{
+			Allocatable: coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse(cpu)},
+		},
+	}
+}
+
+// cpuPod returns a POD on a node requesting the given amount of CPU.
+func cpuPod(name string, node string, cpu string) *coreV1.Pod {
+	return &coreV1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: metaV1.NamespaceDefault},
+		Spec: coreV1.PodSpec{
+			NodeName: node,
+			Containers: []coreV1.Container{
+				{Resources: coreV1.ResourceRequirements{Requests: coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse(cpu)}}},
+			},
+		},
+	}
+}
+
+// newTestArbiter returns an arbiter for a cluster with a node with 4 & one with 1 CPU; PODs on the first one request 3
+// CPUs. Reservations are held for the given ttl.
+func newTestArbiter(ttl time.Duration) *arbiter {
+	pods := []*coreV1.Pod{cpuPod("a-0", "node0", "2"), cpuPod("b-0", "node0", "1")}
+	informer := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0)
+	for _, pod := range pods {
+		_ = informer.Core().V1().Pods().Informer().GetIndexer().Add(pod)
+	}
+	nodes := nodeShim(constrainedNode("node0", "4"), constrainedNode("node1", "1"))
+	return newArbiter(50*time.Millisecond, ttl, nodes, informer.Core().V1().Pods())
+}
+
+// cpuState returns a state with the given PODs on node0 - each requesting the given amount of CPU in millicores.
+func cpuState(key string, priority float64, cpu int64, pods ...string) common.State {
+	res := common.State{
+		Intent:      common.Intent{Key: key, Priority: priority},
+		CurrentPods: map[string]common.PodState{},
+		Resources:   map[string]int64{"0_cpu_requests": cpu, "0_cpu_limits": cpu},
+	}
+	for _, pod := range pods {
+		res.CurrentPods[pod] = common.PodState{NodeName: "node0"}
+	}
+	return res
+}
+
+// scaleOutPlanner for testing - plans to add a POD.
+type scaleOutPlanner struct {
+	dummyPlanner
+}
+
+func (d scaleOutPlanner) CreatePredictedPlan(current common.State, _ common.State, _ map[string]common.Profile) ([]planner.Action, []common.State) {
+	predicted := current.DeepCopy()
+	predicted.CurrentPods["my-deployment-1"] = common.PodState{}
+	return []planner.Action{{Name: "scaleOut"}}, []common.State{predicted}
+}
+
+// executingPlanner for testing - plans to add a POD & reports the intents whose plans were executed.
+type executingPlanner struct {
+	scaleOutPlanner
+	executed chan string
+}
+
+func (d executingPlanner) ExecutePlan(state common.State, _ []planner.Action) []planner.Result {
+	d.executed <- state.Intent.Key
+	return []planner.Result{{Actuator: "scaleOut", Status: planner.ResultApplied}}
+}
+
+// podCandidate returns a candidate adding a POD requesting the given amount of CPU in millicores.
+func podCandidate(key string, cpu int64, pod string) *candidate {
+	res := &candidate{intent: common.Intent{Key: key}, current: cpuState(key, 0, cpu), plan: []planner.Action{{Name: "scaleOut"}}}
+	res.steps = []common.State{cpuState(key, 0, cpu, pod)}
+	return res
+}
+
+// Tests for sanity.
+
+// TestNodeFreeForSanity tests for sanity.
+func TestNodeFreeForSanity(t *testing.T) {
+	a := newTestArbiter(time.Minute)
+	res, requested, err := nodeFree(a.nodeInformer, a.informer)
+	if err != nil || res["node0"]["cpu"] != 1000 || res["node1"]["cpu"] != 1000 {
+		t.Errorf("Expected 1 CPU to be free on each node - got: %v, %v.", res, err)
+	}
+	if requested["node0"]["cpu"] != 3000 || requested["node1"]["cpu"] != 0 {
+		t.Errorf("Expected 3 CPUs to be requested on node0 - got: %v.", requested)
+	}
+	if requests := podRequests(map[string]int64{"0_cpu_requests": 500, "1_cpu_requests": 250, "0_cpu_limits": 1000}); requests["cpu"] != 750 || len(requests) != 1 {
+		t.Errorf("Expected the requests of the containers to be summed up - got: %v.", requests)
+	}
+}
+
+// TestArbitrationForSanity tests for sanity.
+func TestArbitrationForSanity(t *testing.T) {
+	a := newTestArbiter(time.Minute)
+
+	// high priority intent scales up a-0 by 0.8 CPU.
+	high := &candidate{intent: common.Intent{Key: "default/a", Priority: 1.0}, current: cpuState("default/a", 1.0, 2000, "a-0"), plan: []planner.Action{{Name: "scaleCPU"}}}
+	high.steps = []common.State{cpuState("default/a", 1.0, 2800, "a-0")}
+	// lower priority intent adds a POD - fits on node1 - & then scales up b-0 by 0.5 CPU - which no longer fits.
+	low := &candidate{intent: common.Intent{Key: "default/b", Priority: 0.5}, current: cpuState("default/b", 0.5, 1000, "b-0"), plan: []planner.Action{{Name: "scaleOut"}, {Name: "scaleCPU"}}}
+	low.steps = []common.State{cpuState("default/b", 0.5, 1000, "b-0", "b-1"), cpuState("default/b", 0.5, 1500, "b-0", "b-1")}
+	// lowest priority intent adds a POD - for which no capacity is left.
+	lowest := &candidate{intent: common.Intent{Key: "default/c", Priority: 0.1}, current: cpuState("default/c", 0.1, 500), plan: []planner.Action{{Name: "scaleOut"}}}
+	lowest.steps = []common.State{cpuState("default/c", 0.1, 500, "c-0")}
+
+	var tests = []struct {
+		name     string
+		item     *candidate
+		admitted int
+		reason   string
+	}{
+		{"lowest priority", lowest, 0, "no node with enough free cpu for the new POD(s) - lost to default/a, default/b"},
+		{"low priority", low, 1, "insufficient cpu on node node0 - lost to default/a"},
+		{"high priority", high, 1, ""},
+	}
+	res := make([]verdict, len(tests))
+	var wg sync.WaitGroup
+	for i, tt := range tests {
+		wg.Add(1)
+		go func(i int, item *candidate) {
+			defer wg.Done()
+			res[i] = a.arbitrate(item)
+		}(i, tt.item)
+	}
+	wg.Wait()
+	for i, tt := range tests {
+		if res[i].admitted != tt.admitted || res[i].reason != tt.reason {
+			t.Errorf("Expected %s to get %d actions admitted & reason '%s' - got: %v.", tt.name, tt.admitted, tt.reason, res[i])
+		}
+	}
+
+	// plans from planners which do not predict the states are admitted.
+	unknown := &candidate{intent: common.Intent{Key: "default/d"}, plan: []planner.Action{{Name: "scaleOut"}}}
+	if v := admit(unknown, nodeResources{}, map[string][]string{}); v.admitted != 1 || v.reason != "" {
+		t.Errorf("Expected the plan to be admitted - got: %v.", v)
+	}
+}
+
+// TestReservationForSanity tests for sanity.
+func TestReservationForSanity(t *testing.T) {
+	a := newTestArbiter(time.Minute)
+
+	// PODs added in earlier rounds are not reflected by the informer yet - but their capacity is reserved.
+	var tests = []struct {
+		name     string
+		item     *candidate
+		admitted int
+		reason   string
+	}{
+		{"first round", podCandidate("default/c", 500, "c-0"), 1, ""},
+		{"second round", podCandidate("default/d", 800, "d-0"), 1, ""},
+		{"third round", podCandidate("default/e", 800, "e-0"), 0, "no node with enough free cpu for the new POD(s)"},
+	}
+	for _, tt := range tests {
+		if res := a.arbitrate(tt.item); res.admitted != tt.admitted || res.reason != tt.reason {
+			t.Errorf("Expected the %s to get %d actions admitted & reason '%s' - got: %v.", tt.name, tt.admitted, tt.reason, res)
+		}
+	}
+
+	// once the informer reflects the PODs, the reservations are dropped - and not subtracted twice.
+	_ = a.informer.Informer().GetIndexer().Add(cpuPod("c-0", "node0", "500m"))
+	_ = a.informer.Informer().GetIndexer().Add(cpuPod("d-0", "node1", "800m"))
+	if res := a.arbitrate(podCandidate("default/e", 500, "e-0")); res.admitted != 1 {
+		t.Errorf("Expected the plan to be admitted - got: %v.", res)
+	}
+	a.decisions.Lock()
+	if len(a.reserved) != 1 || a.reserved["node0"].amount["cpu"] != 500 {
+		t.Errorf("Expected only the capacity for the last plan to be reserved - got: %v.", a.reserved)
+	}
+	a.decisions.Unlock()
+
+	// reservations expire.
+	a = newTestArbiter(0)
+	for i := 0; i < 2; i++ {
+		if res := a.arbitrate(podCandidate("default/c", 800, "c-0")); res.admitted != 1 {
+			t.Errorf("Expected the plan to be admitted once the reservation expired - got: %v.", res)
+		}
+	}
+}
+
+// TestArbitrationPerTickForSanity tests for sanity.
+func TestArbitrationPerTickForSanity(t *testing.T) {
+	c := newQueueTestController(t, func(cfg *common.ControllerConfig) {
+		cfg.ArbitrationWindow = 200
+		cfg.PlanCacheTTL = 10000
+		cfg.Workers = 1
+	})
+	recorder := record.NewFakeRecorder(20)
+	c.SetEventRecorder(recorder)
+	p := executingPlanner{executed: make(chan string, 3)}
+	c.SetPlanner(p)
+	// leaves room for one more POD.
+	node := &coreV1.Node{
+		ObjectMeta: metaV1.ObjectMeta{Name: "node0"},
+		Status:     coreV1.NodeStatus{Allocatable: coreV1.ResourceList{"foo": resource.MustParse("4"), "bar": resource.MustParse("1Gi")}},
+	}
+	_ = c.nodeInformer.Informer().GetIndexer().Add(node)
+	for key, priority := range map[string]float64{"default/low": 0.2, "default/high": 0.9, "default/medium": 0.5} {
+		c.intents[key] = common.Intent{Key: key, Priority: priority, TargetKey: "default/my-deployment", TargetKind: "Deployment", ActivelyManaged: true}
+	}
+
+	// more intents than workers - all plans of the tick are arbitrated together.
+	stopper := make(chan struct{})
+	defer close(stopper)
+	c.Run(1, stopper)
+	c.processIntents()
+	var lost []string
+	for _, e := range collectEvents(recorder, 6) {
+		if strings.Contains(e, ReasonArbitrationLost) {
+			lost = append(lost, e)
+		}
+	}
+	if len(lost) != 2 {
+		t.Errorf("Expected two plans to lose the arbitration - got: %v.", lost)
+	}
+	for _, e := range lost {
+		if !strings.Contains(e, "no node with enough free foo for the new POD(s) - lost to default/high") {
+			t.Errorf("Expected the plan to lose to the high priority intent - got: %s.", e)
+		}
+	}
+	if key := <-p.executed; key != "default/high" || len(p.executed) != 0 {
+		t.Errorf("Expected only the plan of the high priority intent to be executed - got: %s.", key)
+	}
+	if err := c.Shutdown(t.Context()); err != nil {
+		t.Errorf("Expected the controller to shut down - got: %v.", err)
+	}
+}
+
+// TestArbitrateForSanity tests for sanity.
+func TestArbitrateForSanity(t *testing.T) {
+	c := newQueueTestController(t, func(cfg *common.ControllerConfig) {
+		cfg.ArbitrationWindow = 10
+		cfg.Workers = 2
+	})
+	defer c.queue.ShutDown()
+	key := "default/my-intent"
+	desired := common.State{Intent: common.Intent{Key: key, Priority: 1.0, TargetKey: "default/my-deployment", TargetKind: "Deployment"}}
+	current := common.State{CurrentPods: map[string]common.PodState{"my-deployment-0": {NodeName: "node0"}}, Resources: map[string]int64{"0_foo_requests": 2000}}
+	predicted := current.DeepCopy()
+	predicted.CurrentPods["my-deployment-1"] = common.PodState{}
+	plan := []planner.Action{{Name: "scaleOut", Properties: map[string]int64{"factor": 1}}}
+
+	// without nodes the plan is admitted.
+	res, steps, lost := c.arbitrate(key, current, desired, plan, []common.State{predicted})
+	if len(res) != 1 || len(steps) != 1 || lost != "" {
+		t.Errorf("Expected the plan to be admitted - got: %v, %s.", res, lost)
+	}
+
+	// the POD to add does not fit - the round closes once the window passed.
+	node := &coreV1.Node{
+		ObjectMeta: metaV1.ObjectMeta{Name: "node0"},
+		Status:     coreV1.NodeStatus{Allocatable: coreV1.ResourceList{"foo": resource.MustParse("3")}},
+	}
+	_ = c.nodeInformer.Informer().GetIndexer().Add(node)
+	res, steps, lost = c.arbitrate(key, current, desired, plan, []common.State{predicted})
+	if len(res) != 0 || len(steps) != 0 || lost != "no node with enough free foo for the new POD(s)" {
+		t.Errorf("Expected the plan to be rejected - got: %v, %s.", res, lost)
+	}
+	if metricValue(scrapeMetrics(t), `ido_arbitration_losses_total{outcome="rejected"}`) == "" {
+		t.Error("Expected the rejected plan in the metrics.")
+	}
+}
+
+// TestRejectedPlanForSanity tests for sanity.
+func TestRejectedPlanForSanity(t *testing.T) {
+	c := newQueueTestController(t, func(cfg *common.ControllerConfig) {
+		cfg.ArbitrationWindow = 10
+		cfg.Workers = 1
+	})
+	defer c.queue.ShutDown()
+	key := "default/my-intent"
+	c.intents[key] = common.Intent{Key: key, Priority: 1.0, TargetKey: "default/my-deployment", TargetKind: "Deployment", ActivelyManaged: true}
+	c.SetPlanner(scaleOutPlanner{})
+	node := &coreV1.Node{
+		ObjectMeta: metaV1.ObjectMeta{Name: "node0"},
+		Status:     coreV1.NodeStatus{Allocatable: coreV1.ResourceList{"foo": resource.MustParse("3")}},
+	}
+	_ = c.nodeInformer.Informer().GetIndexer().Add(node)
+
+	// rejected plans are retried with a backoff - instead of losing again on every tick.
+	c.queue.Add(key)
+	c.processNextTask(0)
+	c.inFlight.Wait()
+	if c.queue.NumRequeues(key) != 1 {
+		t.Errorf("Expected the intent to be queued again - got: %d.", c.queue.NumRequeues(key))
+	}
+	if c.planCache.IsIn(key) {
+		t.Error("Rejected plan should not be cached.")
+	}
+	c.processIntents()
+	if c.queue.Len() != 0 {
+		t.Errorf("Expected the intent to back off - got %d queued.", c.queue.Len())
+	}
+}
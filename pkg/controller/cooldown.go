@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/intel/intent-driven-orchestration/pkg/common"
+	"github.com/intel/intent-driven-orchestration/pkg/planner"
+
+	"k8s.io/klog/v2"
+)
+
+// cooldown represents an actuator which is not used for an intent for now.
+type cooldown struct {
+	// until is zero while the cooldown waits for the change to be rolled out.
+	until  time.Time
+	period time.Duration
+}
+
+// startCooldowns starts the cooldowns configured for the actuators - by name, or else by group - which changed the
+// target of an intent. If cooldowns are configured, they replace the plan cache: actuators without a cooldown of their
+// own cool down for the plan cache's TTL - so only the actuators which ran are blocked, instead of the intent as a whole.
+func (c *IntentController) startCooldowns(key string, results []planner.Result) {
+	if len(c.cfg.Controller.Cooldowns) == 0 {
+		return
+	}
+	now := c.clock.Now()
+	c.intentsLock.Lock()
+	defer c.intentsLock.Unlock()
+	for _, result := range results {
+		if result.Status != planner.ResultApplied {
+			continue
+		}
+		name := result.Actuator
+		cfg := common.CooldownConfig{Period: c.cfg.Controller.PlanCacheTTL}
+		for _, item := range []string{result.Actuator, result.Group} {
+			if tmp, ok := c.cfg.Controller.Cooldowns[item]; ok && item != "" {
+				name, cfg = item, tmp
+				break
+			}
+		}
+		if name == "" || cfg.Period <= 0 {
+			continue
+		}
+		entry := cooldown{period: time.Duration(cfg.Period) * time.Millisecond}
+		if !cfg.AfterRollout {
+			entry.until = now.Add(entry.period)
+		}
+		if c.cooldowns[key] == nil {
+			c.cooldowns[key] = make(map[string]cooldown)
+		}
+		c.cooldowns[key][name] = entry
+	}
+}
+
+// activeCooldowns returns the actuators cooling down for an intent and when their cooldowns end; cooldowns waiting for
+// a rollout start once the target is rolled out. Ended cooldowns are dropped.
+func (c *IntentController) activeCooldowns(key string, desired common.State) map[string]time.Time {
+	c.intentsLock.Lock()
+	pending := make(map[string]cooldown, len(c.cooldowns[key]))
+	for name, entry := range c.cooldowns[key] {
+		pending[name] = entry
+	}
+	c.intentsLock.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	now := c.clock.Now()
+	var rolledOut *bool
+	res := make(map[string]time.Time, len(pending))
+	for name, entry := range pending {
+		if entry.until.IsZero() {
+			if rolledOut == nil {
+				done, err := RolledOut(c.clientSet, desired.Intent.TargetKind, desired.Intent.TargetKey)
+				if err != nil {
+					klog.Warningf("Could not determine if %s %s is rolled out: %v.", desired.Intent.TargetKind, desired.Intent.TargetKey, err)
+				}
+				rolledOut = &done
+			}
+			if *rolledOut {
+				entry.until = now.Add(entry.period)
+				pending[name] = entry
+			}
+		}
+		if !entry.until.IsZero() && !now.Before(entry.until) {
+			delete(pending, name)
+			common.ActuatorCooldown.DeleteLabelValues(key, name)
+			continue
+		}
+		remaining := entry.period
+		if !entry.until.IsZero() {
+			remaining = entry.until.Sub(now)
+		}
+		common.ActuatorCooldown.WithLabelValues(key, name).Set(remaining.Seconds())
+		res[name] = entry.until
+	}
+
+	c.intentsLock.Lock()
+	if len(pending) > 0 {
+		c.cooldowns[key] = pending
+	} else {
+		delete(c.cooldowns, key)
+	}
+	c.intentsLock.Unlock()
+	return res
+}
@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/intel/intent-driven-orchestration/pkg/common"
+	"github.com/intel/intent-driven-orchestration/pkg/planner"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingClock "k8s.io/utils/clock/testing"
+)
+
+// cooldownPlanner for testing - reports the cooldowns of the desired state.
+type cooldownPlanner struct {
+	dummyPlanner
+	cooldowns chan map[string]time.Time
+}
+
+func (d cooldownPlanner) CreatePlan(_ common.State, desired common.State, _ map[string]common.Profile) []planner.Action {
+	d.cooldowns <- desired.Intent.Cooldowns
+	return []planner.Action{{Name: "test"}}
+}
+
+// Tests for sanity.
+
+// TestCooldownsForSanity tests for sanity.
+func TestCooldownsForSanity(t *testing.T) {
+	c := newQueueTestController(t, func(cfg *common.ControllerConfig) {
+		cfg.Cooldowns = map[string]common.CooldownConfig{
+			"scaleOut": {Period: 60000},
+			"platform": {Period: 300000, AfterRollout: true},
+			"rmPod":    {Period: 60000},
+		}
+	})
+	defer c.queue.ShutDown()
+	clock := testingClock.NewFakeClock(time.Now())
+	c.SetClock(clock)
+	key := "default/my-intent"
+	desired := common.State{Intent: common.Intent{Key: key, TargetKey: "default/my-deployment", TargetKind: "Deployment"}}
+	c.startCooldowns(key, []planner.Result{
+		{Actuator: "scaleOut", Group: "scaling", Status: planner.ResultApplied},
+		{Actuator: "rdt", Group: "platform", Status: planner.ResultApplied},
+		{Actuator: "rmPod", Group: "scaling", Status: planner.ResultFailed},
+	})
+
+	// cooldown of the platform actuators waits for the rollout.
+	res := c.activeCooldowns(key, desired)
+	if len(res) != 2 || !res["scaleOut"].Equal(clock.Now().Add(time.Minute)) || !res["platform"].IsZero() {
+		t.Errorf("Expected scaleOut & platform to cool down - got: %v.", res)
+	}
+	metrics := scrapeMetrics(t)
+	if metricValue(metrics, `ido_actuator_cooldown_remaining_seconds{actuator="scaleOut",intent="default/my-intent"}`) != "60" ||
+		metricValue(metrics, `ido_actuator_cooldown_remaining_seconds{actuator="platform",intent="default/my-intent"}`) != "300" {
+		t.Errorf("Expected the remaining cooldowns in the metrics - got: %s.", metrics)
+	}
+
+	// rollout finished - so the cooldown starts.
+	clock.Step(30 * time.Second)
+	deployment, _ := c.clientSet.AppsV1().Deployments("default").Get(t.Context(), "my-deployment", metaV1.GetOptions{})
+	deployment.Status.Replicas = 1
+	deployment.Status.UpdatedReplicas = 1
+	deployment.Status.AvailableReplicas = 1
+	_, _ = c.clientSet.AppsV1().Deployments("default").UpdateStatus(t.Context(), deployment, metaV1.UpdateOptions{})
+	res = c.activeCooldowns(key, desired)
+	if len(res) != 2 || !res["platform"].Equal(clock.Now().Add(5*time.Minute)) {
+		t.Errorf("Expected the platform cooldown to have started - got: %v.", res)
+	}
+
+	// cooldowns end.
+	clock.Step(time.Minute)
+	res = c.activeCooldowns(key, desired)
+	if _, ok := res["scaleOut"]; ok || len(res) != 1 {
+		t.Errorf("Expected only platform to cool down - got: %v.", res)
+	}
+	if metricValue(scrapeMetrics(t), `ido_actuator_cooldown_remaining_seconds{actuator="scaleOut",intent="default/my-intent"}`) != "" {
+		t.Error("Expected the ended cooldown to be removed from the metrics.")
+	}
+	clock.Step(5 * time.Minute)
+	if res = c.activeCooldowns(key, desired); len(res) != 0 || len(c.cooldowns) != 0 {
+		t.Errorf("Expected no cooldowns - got: %v.", res)
+	}
+}
+
+// TestCooldownPlanningForSanity tests for sanity.
+func TestCooldownPlanningForSanity(t *testing.T) {
+	c := newQueueTestController(t, func(cfg *common.ControllerConfig) {
+		cfg.Cooldowns = map[string]common.CooldownConfig{"test": {Period: 60000}}
+	})
+	defer c.queue.ShutDown()
+	p := cooldownPlanner{cooldowns: make(chan map[string]time.Time, 2)}
+	c.SetPlanner(p)
+	key := "default/my-intent"
+	c.intents[key] = common.Intent{Key: key, Priority: 1.0, TargetKey: "default/my-deployment", TargetKind: "Deployment", ActivelyManaged: true}
+
+	_ = c.processIntent(0, key)
+	if res := <-p.cooldowns; len(res) != 0 {
+		t.Errorf("Expected no cooldowns - got: %v.", res)
+	}
+	_ = c.processIntent(0, key)
+	if res := <-p.cooldowns; len(res) != 1 || res["test"].IsZero() {
+		t.Errorf("Expected the actuator to cool down - got: %v.", res)
+	}
+	c.inFlight.Wait()
+	// the cooldowns replace the plan cache.
+	if c.planCache.IsIn(key) {
+		t.Error("Intent should not be blocked as a whole.")
+	}
+
+	// cooldowns are shown in the status.
+	intent := statusIntent()
+	setIntentStatus(intent, common.State{}, common.State{Intent: common.Intent{Cooldowns: map[string]time.Time{"test": {}, "rdt": time.Now()}}}, nil, statusProfiles(), time.Now())
+	if len(intent.Status.Cooldowns) != 2 || intent.Status.Cooldowns[0].Actuator != "rdt" || intent.Status.Cooldowns[1].Until != nil {
+		t.Errorf("Expected the cooldowns in the status - got: %v.", intent.Status.Cooldowns)
+	}
+}
+
+// TestCooldownFallbackForSanity tests for sanity.
+func TestCooldownFallbackForSanity(t *testing.T) {
+	// without cooldowns, the plan cache is used.
+	c := newQueueTestController(t, func(_ *common.ControllerConfig) {})
+	key := "default/my-intent"
+	c.startCooldowns(key, []planner.Result{{Actuator: "rmPod", Group: "scaling", Status: planner.ResultApplied}})
+	if len(c.cooldowns) != 0 {
+		t.Errorf("Expected no cooldowns - got: %v.", c.cooldowns)
+	}
+	c.queue.ShutDown()
+
+	// with cooldowns, actuators without one of their own cool down for the TTL of the plan cache.
+	c = newQueueTestController(t, func(cfg *common.ControllerConfig) {
+		cfg.Cooldowns = map[string]common.CooldownConfig{"scaleOut": {Period: 60000}}
+	})
+	defer c.queue.ShutDown()
+	clock := testingClock.NewFakeClock(time.Now())
+	c.SetClock(clock)
+	c.startCooldowns(key, []planner.Result{
+		{Actuator: "scaleOut", Group: "scaling", Status: planner.ResultApplied},
+		{Actuator: "rmPod", Group: "scaling", Status: planner.ResultApplied},
+	})
+	desired := common.State{Intent: common.Intent{Key: key, TargetKey: "default/my-deployment", TargetKind: "Deployment"}}
+	res := c.activeCooldowns(key, desired)
+	if len(res) != 2 || !res["scaleOut"].Equal(clock.Now().Add(time.Minute)) || !res["rmPod"].Equal(clock.Now().Add(10*time.Millisecond)) {
+		t.Errorf("Expected scaleOut & rmPod to cool down - got: %v.", res)
+	}
+}
@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreInformers "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// defaultProfilesReloadPeriod defines how often the file with the default profile definitions is checked for changes.
+const defaultProfilesReloadPeriod = 10 * time.Second
+
+// DefaultProfiles holds the default profile definitions, and reloads them whenever their source - a file or a
+// ConfigMap - changes. If the source is (temporarily) missing, the last known definitions are kept.
+type DefaultProfiles struct {
+	lock        sync.RWMutex
+	definitions map[string]map[string]string
+	filename    string
+	modTime     time.Time
+	onChange    func(keys []string)
+}
+
+// NewDefaultProfiles returns a new set of default profile definitions; the given function is called with the keys of
+// the definitions that were added, changed or removed during a reload.
+func NewDefaultProfiles(onChange func(keys []string)) *DefaultProfiles {
+	return &DefaultProfiles{definitions: map[string]map[string]string{}, onChange: onChange}
+}
+
+// Get returns the default profile definition for the given key.
+func (d *DefaultProfiles) Get(key string) (map[string]string, bool) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	res, found := d.definitions[key]
+	return res, found
+}
+
+// parseDefaultProfiles parses default profile definitions.
+func parseDefaultProfiles(data []byte) (map[string]map[string]string, error) {
+	var result map[string]map[string]string
+	err := json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse default profile definitions: %v", err)
+	}
+	return result, nil
+}
+
+// set replaces the current definitions, and notifies about the changed keys.
+func (d *DefaultProfiles) set(definitions map[string]map[string]string) {
+	d.lock.Lock()
+	var changed []string
+	for key, value := range definitions {
+		if old, found := d.definitions[key]; !found || !reflect.DeepEqual(old, value) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range d.definitions {
+		if _, found := definitions[key]; !found {
+			changed = append(changed, key)
+		}
+	}
+	d.definitions = definitions
+	d.lock.Unlock()
+
+	if len(changed) == 0 {
+		return
+	}
+	sort.Strings(changed)
+	klog.Infof("Default profile definitions changed for: %v.", changed)
+	if d.onChange != nil {
+		d.onChange(changed)
+	}
+}
+
+// LoadFile (re)loads the default profile definitions from a file, if it was modified since it was last loaded.
+func (d *DefaultProfiles) LoadFile(filename string) error {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return fmt.Errorf("unable to read config file with the default profile definitions: %v", err)
+	}
+	if filename == d.filename && info.ModTime().Equal(d.modTime) {
+		return nil
+	}
+	tmp, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("unable to read config file with the default profile definitions: %v", err)
+	}
+	result, err := parseDefaultProfiles(tmp)
+	if err != nil {
+		return err
+	}
+	d.filename = filename
+	d.modTime = info.ModTime()
+	d.set(result)
+	return nil
+}
+
+// WatchFile periodically reloads the default profile definitions from a file until stopped.
+func (d *DefaultProfiles) WatchFile(filename string, period time.Duration, stopper <-chan struct{}) {
+	wait.Until(func() {
+		if err := d.LoadFile(filename); err != nil {
+			klog.Warningf("Keeping last known default profile definitions: %v.", err)
+		}
+	}, period, stopper)
+}
+
+// loadConfigMap loads the default profile definitions from all entries of a ConfigMap.
+func (d *DefaultProfiles) loadConfigMap(configMap *coreV1.ConfigMap) {
+	result := map[string]map[string]string{}
+	for entry, data := range configMap.Data {
+		tmp, err := parseDefaultProfiles([]byte(data))
+		if err != nil {
+			klog.Warningf("Keeping last known default profile definitions - entry '%s' of ConfigMap '%s/%s' is invalid: %v.", entry, configMap.Namespace, configMap.Name, err)
+			return
+		}
+		for key, value := range tmp {
+			result[key] = value
+		}
+	}
+	d.set(result)
+}
+
+// WatchConfigMap loads the default profile definitions from a ConfigMap, whenever it is added or updated.
+func (d *DefaultProfiles) WatchConfigMap(informer coreInformers.ConfigMapInformer, namespace string, name string) {
+	handle := func(obj interface{}) {
+		configMap, ok := obj.(*coreV1.ConfigMap)
+		if !ok || configMap.Namespace != namespace || configMap.Name != name {
+			return
+		}
+		d.loadConfigMap(configMap)
+	}
+	_, _ = informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: handle,
+		UpdateFunc: func(_, newVersion interface{}) {
+			handle(newVersion)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if configMap, ok := obj.(*coreV1.ConfigMap); ok && configMap.Namespace == namespace && configMap.Name == name {
+				klog.Warningf("ConfigMap '%s/%s' was removed - keeping last known default profile definitions.", namespace, name)
+			}
+		},
+	})
+}
@@ -0,0 +1,176 @@
+package controller
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/intel/intent-driven-orchestration/pkg/common"
+	"github.com/intel/intent-driven-orchestration/pkg/generated/clientset/versioned/fake"
+	informers "github.com/intel/intent-driven-orchestration/pkg/generated/informers/externalversions"
+
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeInformers "k8s.io/client-go/informers"
+	k8sFake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+// writeDefaultProfiles writes a file with default profile definitions, making sure its modification time changes.
+func writeDefaultProfiles(t *testing.T, filename string, content string, modTime time.Time) {
+	if err := os.WriteFile(filename, []byte(content), 0600); err != nil {
+		t.Fatalf("Unable to write file: %v", err)
+	}
+	if err := os.Chtimes(filename, modTime, modTime); err != nil {
+		t.Fatalf("Unable to set modification time: %v", err)
+	}
+}
+
+// Tests for success.
+
+// TestLoadFileForSuccess tests for success.
+func TestLoadFileForSuccess(t *testing.T) {
+	defaults := NewDefaultProfiles(nil)
+	if err := defaults.LoadFile("../../artefacts/examples/default_queries.json"); err != nil {
+		t.Fatalf("Should have loaded the file: %v", err)
+	}
+	if _, found := defaults.Get("default/p99latency"); !found {
+		t.Error("Should have found the default profile.")
+	}
+}
+
+// Tests for failure.
+
+// TestLoadFileForFailure tests for failure.
+func TestLoadFileForFailure(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "queries.json")
+	defaults := NewDefaultProfiles(nil)
+	if err := defaults.LoadFile(filename); err == nil {
+		t.Error("Should have failed - file does not exist.")
+	}
+	writeDefaultProfiles(t, filename, "foo", time.Now())
+	if err := defaults.LoadFile(filename); err == nil {
+		t.Error("Should have failed - file is invalid.")
+	}
+
+	// monitor should not bail out if the file is missing.
+	client := fake.NewSimpleClientset()
+	informer := informers.NewSharedInformerFactory(client, 0)
+	cfg := common.MonitorConfig{}
+	cfg.Profile.Queries = filename + ".missing"
+	mon := NewKPIProfileMonitor(cfg, client, informer.Ido().V1alpha1().KPIProfiles(), make(chan common.Profile))
+	if _, found := mon.DefaultProfiles().Get("default/p99latency"); found {
+		t.Error("Should not have found a default profile.")
+	}
+}
+
+// Tests for sanity.
+
+// TestLoadFileForSanity tests for sanity.
+func TestLoadFileForSanity(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "queries.json")
+	var changed []string
+	defaults := NewDefaultProfiles(func(keys []string) {
+		changed = keys
+	})
+	now := time.Now()
+
+	// initial load.
+	writeDefaultProfiles(t, filename, `{"default/a": {"query": "a"}, "default/b": {"query": "b"}}`, now)
+	if err := defaults.LoadFile(filename); err != nil {
+		t.Fatalf("Should have loaded the file: %v", err)
+	}
+	if !reflect.DeepEqual(changed, []string{"default/a", "default/b"}) {
+		t.Errorf("Unexpected changes: %v.", changed)
+	}
+
+	// unmodified file is not reloaded.
+	changed = nil
+	if err := defaults.LoadFile(filename); err != nil || changed != nil {
+		t.Errorf("Should not have reloaded the file: %v - %v.", err, changed)
+	}
+
+	// changed, added & removed definitions.
+	writeDefaultProfiles(t, filename, `{"default/a": {"query": "a"}, "default/b": {"query": "c"}, "default/d": {"query": "d"}}`, now.Add(time.Second))
+	if err := defaults.LoadFile(filename); err != nil {
+		t.Fatalf("Should have reloaded the file: %v", err)
+	}
+	if !reflect.DeepEqual(changed, []string{"default/b", "default/d"}) {
+		t.Errorf("Unexpected changes: %v.", changed)
+	}
+	writeDefaultProfiles(t, filename, `{"default/a": {"query": "a"}}`, now.Add(2*time.Second))
+	if err := defaults.LoadFile(filename); err != nil {
+		t.Fatalf("Should have reloaded the file: %v", err)
+	}
+	if !reflect.DeepEqual(changed, []string{"default/b", "default/d"}) {
+		t.Errorf("Unexpected changes: %v.", changed)
+	}
+
+	// missing file keeps last known definitions.
+	if err := os.Remove(filename); err != nil {
+		t.Fatalf("Unable to remove file: %v", err)
+	}
+	if err := defaults.LoadFile(filename); err == nil {
+		t.Error("Should have failed - file does not exist.")
+	}
+	if tmp, found := defaults.Get("default/a"); !found || tmp["query"] != "a" {
+		t.Errorf("Should have kept the last known definition - got: %v.", tmp)
+	}
+}
+
+// TestWatchConfigMapForSanity tests for sanity.
+func TestWatchConfigMapForSanity(t *testing.T) {
+	configMap := &coreV1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{Name: "queries", Namespace: "ido"},
+		Data:       map[string]string{"default_queries.json": `{"default/a": {"query": "a"}}`},
+	}
+	client := k8sFake.NewSimpleClientset(configMap)
+	factory := kubeInformers.NewSharedInformerFactory(client, 0)
+	changes := make(chan []string, 10)
+	defaults := NewDefaultProfiles(func(keys []string) {
+		changes <- keys
+	})
+	defaults.WatchConfigMap(factory.Core().V1().ConfigMaps(), "ido", "queries")
+
+	stopper := make(chan struct{})
+	defer close(stopper)
+	factory.Start(stopper)
+	cache.WaitForCacheSync(stopper, factory.Core().V1().ConfigMaps().Informer().HasSynced)
+
+	expectChange := func(want []string) {
+		select {
+		case got := <-changes:
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Unexpected changes: %v - expected: %v.", got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for changes: %v.", want)
+		}
+	}
+	expectChange([]string{"default/a"})
+
+	// updates are picked up; invalid ones are ignored.
+	configMap.Data["default_queries.json"] = `{"default/a": {"query": "b"}}`
+	if _, err := client.CoreV1().ConfigMaps("ido").Update(t.Context(), configMap, metaV1.UpdateOptions{}); err != nil {
+		t.Fatalf("Unable to update ConfigMap: %v", err)
+	}
+	expectChange([]string{"default/a"})
+	configMap.Data["default_queries.json"] = "foo"
+	if _, err := client.CoreV1().ConfigMaps("ido").Update(t.Context(), configMap, metaV1.UpdateOptions{}); err != nil {
+		t.Fatalf("Unable to update ConfigMap: %v", err)
+	}
+
+	// removal keeps the last known definitions.
+	if err := client.CoreV1().ConfigMaps("ido").Delete(t.Context(), "queries", metaV1.DeleteOptions{}); err != nil {
+		t.Fatalf("Unable to delete ConfigMap: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if tmp, found := defaults.Get("default/a"); !found || tmp["query"] != "b" {
+		t.Errorf("Should have kept the last known definition - got: %v.", tmp)
+	}
+	if len(changes) != 0 {
+		t.Errorf("Should not have seen further changes: %v.", <-changes)
+	}
+}
@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"strings"
+
+	"github.com/intel/intent-driven-orchestration/pkg/api/intents/v1alpha1"
+	"github.com/intel/intent-driven-orchestration/pkg/common"
+	intentScheme "github.com/intel/intent-driven-orchestration/pkg/generated/clientset/versioned/scheme"
+	"github.com/intel/intent-driven-orchestration/pkg/planner"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	k8sScheme "k8s.io/client-go/kubernetes/scheme"
+	typedCoreV1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+)
+
+// Reasons of the events emitted by the planning framework.
+const (
+	ReasonPlanCreated       = "PlanCreated"
+	ReasonPlanEmpty         = "PlanEmpty"
+	ReasonPlanExecuted      = "PlanExecuted"
+	ReasonPlanShadowed      = "PlanShadowed"
+	ReasonPlanRolledBack    = "PlanRolledBack"
+	ReasonRollbackFailed    = "RollbackFailed"
+	ReasonRollbackSkipped   = "RollbackSkipped"
+	ReasonArbitrationLost   = "ArbitrationLost"
+	ReasonPerformFailed     = "PerformFailed"
+	ReasonProfileUnresolved = "ProfileUnresolved"
+	ReasonTargetNotFound    = "TargetNotFound"
+	ReasonNoPathToGoal      = "NoPathToGoal"
+	ReasonBudgetBinding     = "BudgetBinding"
+)
+
+const (
+	// eventBurstSize is the number of events with the same reason that can be emitted for an object before being rate limited.
+	eventBurstSize = 5
+	// eventRefillQPS is the rate at which events with the same reason can be emitted for an object once the burst is used up - 1 every 5 minutes.
+	eventRefillQPS = 1. / 300.
+)
+
+// eventSpamKey rate limits events per object and reason - so e.g. frequent empty plans do not suppress other events.
+func eventSpamKey(event *coreV1.Event) string {
+	return event.Source.Component + "/" + event.Source.Host + "/" + event.InvolvedObject.Kind + "/" +
+		event.InvolvedObject.Namespace + "/" + event.InvolvedObject.Name + "/" + string(event.InvolvedObject.UID) + "/" +
+		event.InvolvedObject.APIVersion + "/" + event.Reason
+}
+
+// NewEventRecorder returns a recorder sending events for the given component to the API server; and a function to stop
+// it. Similar events are aggregated, and the rate at which events are sent is limited per object and reason.
+func NewEventRecorder(clientSet kubernetes.Interface, component string) (record.EventRecorder, func()) {
+	scheme := runtime.NewScheme()
+	if err := k8sScheme.AddToScheme(scheme); err != nil {
+		klog.Errorf("Unable to register Kubernetes types for events: %v.", err)
+	}
+	if err := intentScheme.AddToScheme(scheme); err != nil {
+		klog.Errorf("Unable to register intent types for events: %v.", err)
+	}
+	broadcaster := record.NewBroadcaster(record.WithCorrelatorOptions(record.CorrelatorOptions{
+		BurstSize:   eventBurstSize,
+		QPS:         eventRefillQPS,
+		SpamKeyFunc: eventSpamKey,
+	}))
+	broadcaster.StartStructuredLogging(2)
+	broadcaster.StartRecordingToSink(&typedCoreV1.EventSinkImpl{Interface: clientSet.CoreV1().Events("")})
+	return broadcaster.NewRecorder(scheme, coreV1.EventSource{Component: component}), broadcaster.Shutdown
+}
+
+// IntentReference returns a reference to the Intent object an intent is based on - for intents derived from a selector
+// this is the parent intent, and for the members of a group the IntentGroup.
+func IntentReference(intent common.Intent) *coreV1.ObjectReference {
+	key := intent.Key
+	kind := "Intent"
+	if intent.ParentKey != "" {
+		key = intent.ParentKey
+	}
+	if intent.ParentKind != "" {
+		kind = intent.ParentKind
+	}
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil || name == "" {
+		return nil
+	}
+	return &coreV1.ObjectReference{
+		Kind:       kind,
+		APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		Namespace:  namespace,
+		Name:       name,
+		UID:        types.UID(intent.UID),
+	}
+}
+
+// planSummary returns the names of the actions in a plan.
+func planSummary(plan []planner.Action) string {
+	names := make([]string, len(plan))
+	for i, action := range plan {
+		names[i] = action.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// EventEmitter emits Kubernetes events; it can be embedded by e.g. actuators. Events are dropped as long as no recorder
+// is set.
+type EventEmitter struct {
+	recorder record.EventRecorder
+}
+
+// SetEventRecorder sets the recorder used to emit events.
+func (e *EventEmitter) SetEventRecorder(recorder record.EventRecorder) {
+	e.recorder = recorder
+}
+
+// Event emits an event for the given object.
+func (e EventEmitter) Event(obj runtime.Object, eventType string, reason string, messageFmt string, args ...interface{}) {
+	if e.recorder == nil {
+		return
+	}
+	e.recorder.Eventf(obj, eventType, reason, messageFmt, args...)
+}
+
+// IntentEvent emits an event for the Intent object an intent is based on; for intents derived from a selector, the
+// message is prefixed with the workload the event relates to.
+func (e EventEmitter) IntentEvent(intent common.Intent, eventType string, reason string, messageFmt string, args ...interface{}) {
+	ref := IntentReference(intent)
+	if ref == nil {
+		return
+	}
+	if intent.ParentKey != "" {
+		messageFmt = "Workload " + intent.TargetKey + ": " + messageFmt
+	}
+	e.Event(ref, eventType, reason, messageFmt, args...)
+}
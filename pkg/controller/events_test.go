@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/intel/intent-driven-orchestration/pkg/common"
+	"github.com/intel/intent-driven-orchestration/pkg/planner"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+)
+
+// emptyPlanner for testing - never finds a plan.
+type emptyPlanner struct {
+	dummyPlanner
+}
+
+func (e emptyPlanner) CreatePlan(_ common.State, _ common.State, _ map[string]common.Profile) []planner.Action {
+	return nil
+}
+
+// collectEvents reads the given number of events from a fake recorder; returns what it got before the timeout.
+func collectEvents(recorder *record.FakeRecorder, n int) []string {
+	var events []string
+	timeout := time.After(2 * time.Second)
+	for len(events) < n {
+		select {
+		case e := <-recorder.Events:
+			events = append(events, e)
+		case <-timeout:
+			return events
+		}
+	}
+	return events
+}
+
+// eventReasons returns the reasons of the events recorded by a fake recorder.
+func eventReasons(events []string) map[string]string {
+	res := map[string]string{}
+	for _, e := range events {
+		fields := strings.SplitN(e, " ", 3)
+		if len(fields) == 3 {
+			res[fields[1]] = fields[2]
+		}
+	}
+	return res
+}
+
+// Tests for success.
+
+// TestIntentEventForSuccess tests for success.
+func TestIntentEventForSuccess(t *testing.T) {
+	recorder := record.NewFakeRecorder(1)
+	emitter := EventEmitter{}
+	emitter.SetEventRecorder(recorder)
+	emitter.IntentEvent(common.Intent{Key: "default/my-intent"}, coreV1.EventTypeNormal, ReasonPlanEmpty, "No actions needed.")
+	if len(collectEvents(recorder, 1)) != 1 {
+		t.Error("Expected an event to be recorded.")
+	}
+}
+
+// Tests for failure.
+
+// TestIntentEventForFailure tests for failure.
+func TestIntentEventForFailure(t *testing.T) {
+	// no recorder set.
+	emitter := EventEmitter{}
+	emitter.IntentEvent(common.Intent{Key: "default/my-intent"}, coreV1.EventTypeNormal, ReasonPlanEmpty, "No actions needed.")
+
+	// invalid key.
+	recorder := record.NewFakeRecorder(1)
+	emitter.SetEventRecorder(recorder)
+	emitter.IntentEvent(common.Intent{Key: "a/b/c"}, coreV1.EventTypeNormal, ReasonPlanEmpty, "No actions needed.")
+	if len(recorder.Events) != 0 {
+		t.Error("Should not record events for invalid intent keys.")
+	}
+}
+
+// Tests for sanity.
+
+// TestIntentReferenceForSanity tests for sanity.
+func TestIntentReferenceForSanity(t *testing.T) {
+	ref := IntentReference(common.Intent{Key: "default/my-intent", UID: "123"})
+	if ref.Kind != "Intent" || ref.Namespace != "default" || ref.Name != "my-intent" || ref.UID != types.UID("123") ||
+		ref.APIVersion != "ido.intel.com/v1alpha1" {
+		t.Errorf("Unexpected reference: %v.", ref)
+	}
+
+	// derived intents refer to the parent.
+	ref = IntentReference(common.Intent{
+		Key:       ChildIntentKey(ParentKindIntent, "default/my-intent", "default/frontend"),
+		ParentKey: "default/my-intent",
+		UID:       "123",
+	})
+	if ref.Namespace != "default" || ref.Name != "my-intent" {
+		t.Errorf("Expected reference to the parent intent - got: %v.", ref)
+	}
+
+	// members of a group refer to the group.
+	ref = IntentReference(common.Intent{
+		Key:        ChildIntentKey(ParentKindIntentGroup, "default/my-chain", "default/frontend"),
+		ParentKey:  "default/my-chain",
+		ParentKind: ParentKindIntentGroup,
+	})
+	if ref.Kind != "IntentGroup" || ref.Namespace != "default" || ref.Name != "my-chain" {
+		t.Errorf("Expected reference to the group - got: %v.", ref)
+	}
+}
+
+// TestIntentEventForSanity tests for sanity.
+func TestIntentEventForSanity(t *testing.T) {
+	recorder := record.NewFakeRecorder(2)
+	emitter := EventEmitter{}
+	emitter.SetEventRecorder(recorder)
+
+	emitter.IntentEvent(common.Intent{Key: "default/my-intent", TargetKey: "default/frontend"},
+		coreV1.EventTypeWarning, ReasonNoPathToGoal, "No path to goal state possible.")
+	emitter.IntentEvent(common.Intent{Key: "default/my-intent@Intent:default/frontend", ParentKey: "default/my-intent", TargetKey: "default/frontend"},
+		coreV1.EventTypeWarning, ReasonNoPathToGoal, "No path to goal state possible.")
+	events := collectEvents(recorder, 2)
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events - got: %v.", events)
+	}
+	if events[0] != "Warning NoPathToGoal No path to goal state possible." {
+		t.Errorf("Unexpected event: %s.", events[0])
+	}
+	if events[1] != "Warning NoPathToGoal Workload default/frontend: No path to goal state possible." {
+		t.Errorf("Expected the workload to be part of the message - got: %s.", events[1])
+	}
+}
+
+// TestWorkerEventsForSanity tests for sanity.
+func TestWorkerEventsForSanity(t *testing.T) {
+	c := newTestController()
+	recorder := record.NewFakeRecorder(10)
+	c.SetEventRecorder(recorder)
+	c.intents["default/my-intent"] = common.Intent{
+		Key:             "default/my-intent",
+		Priority:        1.0,
+		TargetKey:       "default/frontend",
+		TargetKind:      "bar",
+		ActivelyManaged: true,
+	}
+	go c.worker(0)
+	c.queue.Add("default/my-intent")
+
+	reasons := eventReasons(collectEvents(recorder, 3))
+	for _, reason := range []string{ReasonTargetNotFound, ReasonPlanCreated, ReasonPlanExecuted} {
+		if _, ok := reasons[reason]; !ok {
+			t.Errorf("Expected event with reason %s - got: %v.", reason, reasons)
+		}
+	}
+	if reasons[ReasonPlanCreated] != "Created plan: test, done." {
+		t.Errorf("Unexpected message: %s.", reasons[ReasonPlanCreated])
+	}
+
+	// not actively managed & no plan.
+	c.SetPlanner(emptyPlanner{})
+	c.intents["default/my-intent"] = common.Intent{Key: "default/my-intent", Priority: 1.0, TargetKey: "default/frontend", TargetKind: "bar"}
+	c.queue.Add("default/my-intent")
+	reasons = eventReasons(collectEvents(recorder, 2))
+	if _, ok := reasons[ReasonPlanEmpty]; !ok {
+		t.Errorf("Expected event with reason %s - got: %v.", ReasonPlanEmpty, reasons)
+	}
+	c.queue.ShutDown()
+}
@@ -0,0 +1,245 @@
+package controller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/intel/intent-driven-orchestration/pkg/api/intents/v1alpha1"
+	"github.com/intel/intent-driven-orchestration/pkg/common"
+	informers "github.com/intel/intent-driven-orchestration/pkg/generated/informers/externalversions/intents/v1alpha1"
+	lister "github.com/intel/intent-driven-orchestration/pkg/generated/listers/intents/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+// ParentKindIntentGroup is the parent kind of the intents derived for the members of an IntentGroup.
+const ParentKindIntentGroup = "IntentGroup"
+
+// IntentGroupMonitor is the part implementing the monitoring of IntentGroups.
+type IntentGroupMonitor struct {
+	groupLister lister.IntentGroupLister
+	groupSynced cache.InformerSynced
+	queue       workqueue.TypedRateLimitingInterface[string]
+	update      chan<- common.Intent
+	syncHandler func(key string) error // For testing purposes.
+	// members holds the keys of the member workloads (indexed by the key of the derived intent) of each group.
+	members     map[string]map[string]string
+	membersLock sync.Mutex
+}
+
+// NewIntentGroupMonitor returns a new monitor instance.
+func NewIntentGroupMonitor(groupInformer informers.IntentGroupInformer, ch chan<- common.Intent) *IntentGroupMonitor {
+	mon := &IntentGroupMonitor{
+		groupLister: groupInformer.Lister(),
+		groupSynced: groupInformer.Informer().HasSynced,
+		queue:       workqueue.NewTypedRateLimitingQueueWithConfig[string](workqueue.DefaultTypedControllerRateLimiter[string](), workqueue.TypedRateLimitingQueueConfig[string]{Name: "IntentGroups"}),
+		update:      ch,
+		members:     make(map[string]map[string]string),
+	}
+	mon.syncHandler = mon.processGroup
+
+	// functions handler.
+	_, _ = groupInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: mon.enqueueItem,
+		UpdateFunc: func(oldVersion, newVersion interface{}) {
+			if equality.Semantic.DeepEqual(oldVersion.(*v1alpha1.IntentGroup).Spec, newVersion.(*v1alpha1.IntentGroup).Spec) {
+				// status only updates (e.g. by the controller itself) do not need to be processed.
+				return
+			}
+			mon.enqueueItem(newVersion)
+		},
+		DeleteFunc: func(obj interface{}) {
+			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+			if err != nil {
+				runtime.HandleError(err)
+				return
+			}
+			klog.Infof("Will remove intent group: '%s'.", key)
+			mon.membersLock.Lock()
+			members := mon.members[key]
+			delete(mon.members, key)
+			mon.membersLock.Unlock()
+			for childKey := range members {
+				// no need to set the parent key; the group's status is gone anyhow.
+				mon.update <- common.Intent{Key: childKey, Priority: -1.0}
+			}
+		},
+	})
+
+	return mon
+}
+
+// enqueueItem adds items to the work queue.
+func (mon *IntentGroupMonitor) enqueueItem(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		runtime.HandleError(err)
+		return
+	}
+	mon.queue.Add(key)
+}
+
+// Run the basic monitor.
+func (mon *IntentGroupMonitor) Run(nWorkers int, stopper <-chan struct{}) {
+	defer runtime.HandleCrash()
+	defer mon.queue.ShutDown()
+
+	if ok := cache.WaitForCacheSync(stopper, mon.groupSynced); !ok {
+		return
+	}
+
+	for i := 0; i < nWorkers; i++ {
+		go wait.Until(mon.runWorker, time.Second, stopper)
+	}
+	klog.V(1).Infof("Started %d worker(s).", nWorkers)
+	<-stopper
+}
+
+// runWorker will run forever and process items of a queue.
+func (mon *IntentGroupMonitor) runWorker() {
+	for mon.processNextWorkItem() {
+	}
+}
+
+// processNextWorkItem will handle item in the queue.
+func (mon *IntentGroupMonitor) processNextWorkItem() bool {
+	obj, done := mon.queue.Get()
+	if done {
+		return false
+	}
+	defer mon.queue.Done(obj)
+
+	// process obj.
+	err := mon.syncHandler(obj)
+	if err == nil {
+		mon.queue.Forget(obj)
+		return true
+	}
+
+	// Failed --> add back to queue, but rate limited!
+	runtime.HandleError(fmt.Errorf("processing of %v failed with: %v", obj, err))
+	mon.queue.AddRateLimited(obj)
+
+	return true
+}
+
+// memberTargets returns the target values for the members of a group - splitting the end-to-end target value
+// according to the members' shares.
+func memberTargets(group *v1alpha1.IntentGroup, value float64) []float64 {
+	shares := make([]float64, len(group.Spec.Members))
+	for i, member := range group.Spec.Members {
+		shares[i] = member.Share
+	}
+	aggregation := common.AggregationFromText(group.Spec.Aggregation)
+	res := make([]float64, len(shares))
+	for i, share := range common.Shares(shares) {
+		res[i] = aggregation.SplitTarget(value, share)
+	}
+	return res
+}
+
+// memberProfile returns the KPI profile used to measure a member of a group.
+func memberProfile(group *v1alpha1.IntentGroup, member v1alpha1.GroupMember) string {
+	if member.MeasuredBy != "" {
+		return member.MeasuredBy
+	}
+	return group.Spec.Objective.MeasuredBy
+}
+
+// processGroup derives an intent for each member of a group, with its share of the end-to-end objective.
+func (mon *IntentGroupMonitor) processGroup(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("invalid resource key: '%s'", key))
+		//lint:ignore nilerr n.a.
+		return nil // ignore
+	}
+
+	group, err := mon.groupLister.IntentGroups(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			runtime.HandleError(fmt.Errorf("intent group '%s' does not longer exists", key))
+			return nil
+		}
+		return err
+	}
+
+	objective := group.Spec.Objective
+	targets := memberTargets(group, objective.Value)
+	windows := toScheduleWindows(key, objective)
+	windowTargets := make([][]float64, len(windows))
+	for i, window := range windows {
+		windowTargets[i] = memberTargets(group, window.Value)
+	}
+
+	intents := make(map[string]common.Intent, len(group.Spec.Members))
+	members := make(map[string]string, len(group.Spec.Members))
+	for i, member := range group.Spec.Members {
+		if member.TargetRef.Selector != nil || member.TargetRef.Name == "" {
+			klog.Warningf("Ignoring member %d of intent group '%s' - members need to reference a workload by name.", i, key)
+			continue
+		}
+		profile := memberProfile(group, member)
+		intent := common.Intent{
+			Key:             ChildIntentKey(ParentKindIntentGroup, key, member.TargetRef.Name),
+			ParentKey:       key,
+			ParentKind:      ParentKindIntentGroup,
+			UID:             string(group.UID),
+			Priority:        group.Spec.Priority,
+			TargetKey:       member.TargetRef.Name,
+			TargetKind:      member.TargetRef.Kind,
+			ActivelyManaged: group.Spec.ActivelyManaged,
+			Objectives:      map[string]float64{profile: targets[i]},
+			Tolerations:     map[string]float64{profile: objective.Tolerance},
+			Weights:         map[string]float64{},
+			Normalizations:  map[string]common.Normalization{profile: common.NormalizationFromText(objective.Normalization)},
+		}
+		if objective.Weight > 0 {
+			intent.Weights[profile] = objective.Weight
+		}
+		for j, window := range windows {
+			window.Value = windowTargets[j][i]
+			if intent.Schedules == nil {
+				intent.Schedules = map[string][]common.ScheduleWindow{}
+			}
+			intent.Schedules[profile] = append(intent.Schedules[profile], window)
+		}
+		if member.Budget != nil {
+			intent.Budget = &common.Budget{
+				MinReplicas:     int(member.Budget.MinReplicas),
+				MaxReplicas:     int(member.Budget.MaxReplicas),
+				MaxContainerCPU: member.Budget.MaxContainerCPU,
+				MaxTotalCPU:     member.Budget.MaxTotalCPU,
+			}
+		}
+		intents[intent.Key] = intent
+		members[intent.Key] = member.TargetRef.Name
+	}
+	mon.setMembers(key, members)
+	for _, intent := range intents {
+		mon.update <- intent
+	}
+	return nil
+}
+
+// setMembers stores the member workloads of a group, and removes the intents for those no longer part of the group.
+func (mon *IntentGroupMonitor) setMembers(key string, members map[string]string) {
+	mon.membersLock.Lock()
+	previous := mon.members[key]
+	mon.members[key] = members
+	mon.membersLock.Unlock()
+
+	for childKey, target := range previous {
+		if _, ok := members[childKey]; !ok {
+			klog.Infof("Workload '%s' is no longer a member of intent group '%s'.", target, key)
+			mon.update <- common.Intent{Key: childKey, ParentKey: key, ParentKind: ParentKindIntentGroup, TargetKey: target, Priority: -1.0}
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package controller
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/intel/intent-driven-orchestration/pkg/api/intents/v1alpha1"
+	"github.com/intel/intent-driven-orchestration/pkg/common"
+	"github.com/intel/intent-driven-orchestration/pkg/generated/clientset/versioned/fake"
+	informers "github.com/intel/intent-driven-orchestration/pkg/generated/informers/externalversions"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	core "k8s.io/client-go/testing"
+)
+
+// groupFixture represents a basic test case.
+type groupFixture struct {
+	test     *testing.T
+	client   *fake.Clientset
+	objects  []*v1alpha1.IntentGroup
+	updates  chan common.Intent
+	informer informers.SharedInformerFactory
+}
+
+// newGroupFixture initializes the test.
+func newGroupFixture(test *testing.T) *groupFixture {
+	f := &groupFixture{}
+	f.test = test
+	f.updates = make(chan common.Intent, 100)
+	return f
+}
+
+// newMonitor creates a new monitor tailored for testing.
+func (f *groupFixture) newMonitor(done chan struct{}) (*IntentGroupMonitor, *watch.FakeWatcher) {
+	var objects []runtime.Object
+	for _, item := range f.objects {
+		objects = append(objects, item)
+	}
+	f.client = fake.NewSimpleClientset(objects...)
+	faker := watch.NewFake()
+	f.client.PrependWatchReactor("intentgroups", core.DefaultWatchReactor(faker, nil))
+
+	informer := informers.NewSharedInformerFactory(f.client, 0)
+	mon := NewIntentGroupMonitor(informer.Ido().V1alpha1().IntentGroups(), f.updates)
+	mon.groupSynced = func() bool { return true }
+	for _, item := range f.objects {
+		f.addGroup(informer, item)
+	}
+
+	informer.Start(done)
+	f.informer = informer
+	return mon, faker
+}
+
+// addGroup adds or updates a group in the informer's cache.
+func (f *groupFixture) addGroup(informer informers.SharedInformerFactory, group *v1alpha1.IntentGroup) {
+	err := informer.Ido().V1alpha1().IntentGroups().Informer().GetIndexer().Update(group)
+	if err != nil {
+		f.test.Fatal(err)
+	}
+}
+
+// syncAndCollect runs the sync handler and returns the updates send - indexed by their key.
+func (f *groupFixture) syncAndCollect(mon *IntentGroupMonitor, key string) map[string]common.Intent {
+	if err := mon.syncHandler(key); err != nil {
+		f.test.Errorf("error running test: %s", err)
+	}
+	return f.collect()
+}
+
+// collect returns the updates send so far - indexed by their key.
+func (f *groupFixture) collect() map[string]common.Intent {
+	res := make(map[string]common.Intent)
+	for {
+		select {
+		case item := <-f.updates:
+			res[item.Key] = item
+		case <-time.After(timeout * time.Millisecond):
+			return res
+		}
+	}
+}
+
+// newIntentGroup creates an intent group for testing purposes.
+func newIntentGroup(name string, latency float64, members ...string) *v1alpha1.IntentGroup {
+	group := &v1alpha1.IntentGroup{
+		TypeMeta: metaV1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String()},
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:       name,
+			Namespace:  metaV1.NamespaceDefault,
+			Generation: 1,
+		},
+		Spec: v1alpha1.IntentGroupSpec{
+			Priority:        0.5,
+			ActivelyManaged: true,
+			Objective: v1alpha1.TargetObjective{
+				Name:       "e2e-latency",
+				Value:      latency,
+				MeasuredBy: "default/p99latency",
+			},
+		},
+	}
+	for _, member := range members {
+		group.Spec.Members = append(group.Spec.Members, v1alpha1.GroupMember{
+			TargetRef: v1alpha1.TargetRef{Kind: "Deployment", Name: member},
+		})
+	}
+	return group
+}
+
+// Tests for success.
+
+// TestProcessGroupForSuccess tests for success.
+func TestProcessGroupForSuccess(t *testing.T) {
+	f := newGroupFixture(t)
+	f.objects = append(f.objects, newIntentGroup("chain", 100, "default/a", "default/b"))
+	done := make(chan struct{})
+	defer close(done)
+	mon, _ := f.newMonitor(done)
+	updates := f.syncAndCollect(mon, "default/chain")
+	if len(updates) != 2 {
+		t.Errorf("Expected an intent per member - got: %v.", updates)
+	}
+}
+
+// Tests for failure.
+
+// TestProcessGroupForFailure tests for failure.
+func TestProcessGroupForFailure(t *testing.T) {
+	f := newGroupFixture(t)
+	group := newIntentGroup("chain", 100, "default/a")
+	group.Spec.Members = append(group.Spec.Members, v1alpha1.GroupMember{
+		TargetRef: v1alpha1.TargetRef{Kind: "Deployment", Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"tier": "web"}}},
+	})
+	f.objects = append(f.objects, group)
+	done := make(chan struct{})
+	defer close(done)
+	mon, _ := f.newMonitor(done)
+
+	// non-existing object & invalid key - no updates send.
+	for _, key := range []string{"default/foo", "default/foo/bar"} {
+		if updates := f.syncAndCollect(mon, key); len(updates) != 0 {
+			t.Errorf("Expected no updates - got: %v.", updates)
+		}
+	}
+
+	// members selected by label are ignored.
+	updates := f.syncAndCollect(mon, "default/chain")
+	if _, ok := updates[ChildIntentKey(ParentKindIntentGroup, "default/chain", "default/a")]; !ok || len(updates) != 1 {
+		t.Errorf("Expected a single intent - got: %v.", updates)
+	}
+}
+
+// Tests for sanity.
+
+// TestRunIntentGroupMonitorForSanity tests for sanity.
+func TestRunIntentGroupMonitorForSanity(t *testing.T) {
+	f := newGroupFixture(t)
+	done := make(chan struct{})
+	defer close(done)
+	mon, faker := f.newMonitor(done)
+	go mon.Run(1, done)
+
+	group := newIntentGroup("chain", 100, "default/a", "default/b")
+	faker.Add(group)
+	updates := f.collect()
+	if len(updates) != 2 {
+		t.Errorf("Expected an intent per member - got: %v.", updates)
+	}
+
+	// status only update is ignored.
+	updated := group.DeepCopy()
+	updated.Status.Bottleneck = "default/a"
+	faker.Modify(updated)
+	if updates = f.collect(); len(updates) != 0 {
+		t.Errorf("Expected no updates - got: %v.", updates)
+	}
+
+	// removal of the group removes all intents.
+	faker.Delete(updated)
+	updates = f.collect()
+	for _, member := range []string{"default/a", "default/b"} {
+		if item, ok := updates[ChildIntentKey(ParentKindIntentGroup, "default/chain", member)]; !ok || item.Priority != -1 {
+			t.Errorf("Expected removal of the intent for member %s - got: %v.", member, updates)
+		}
+	}
+}
+
+// TestProcessGroupForSanity tests for sanity.
+func TestProcessGroupForSanity(t *testing.T) {
+	f := newGroupFixture(t)
+	group := newIntentGroup("chain", 100, "default/a", "default/b", "default/c")
+	group.Spec.Members[0].Share = 0.5
+	group.Spec.Members[1].MeasuredBy = "default/p95latency"
+	group.Spec.Members[2].Budget = &v1alpha1.Budget{MaxReplicas: 4}
+	group.Spec.Objective.Tolerance = 0.1
+	group.Spec.Objective.Schedule = []v1alpha1.ScheduleWindow{{Name: "night", Start: "22:00", End: "06:00", Value: 200}}
+	f.objects = append(f.objects, group)
+	done := make(chan struct{})
+	defer close(done)
+	mon, _ := f.newMonitor(done)
+
+	// sum - targets are split according to the shares.
+	updates := f.syncAndCollect(mon, "default/chain")
+	tests := []struct {
+		member  string
+		profile string
+		target  float64
+		night   float64
+	}{
+		{member: "default/a", profile: "default/p99latency", target: 50, night: 100},
+		{member: "default/b", profile: "default/p95latency", target: 25, night: 50},
+		{member: "default/c", profile: "default/p99latency", target: 25, night: 50},
+	}
+	for _, tt := range tests {
+		item, ok := updates[ChildIntentKey(ParentKindIntentGroup, "default/chain", tt.member)]
+		if !ok || item.ParentKey != "default/chain" || item.ParentKind != ParentKindIntentGroup || item.TargetKey != tt.member ||
+			item.TargetKind != "Deployment" || item.Priority != 0.5 || !item.ActivelyManaged {
+			t.Errorf("Expected an intent for member %s - got: %v.", tt.member, item)
+		}
+		if item.Objectives[tt.profile] != tt.target || item.Tolerations[tt.profile] != 0.1 {
+			t.Errorf("Expected target %f for member %s - got: %v.", tt.target, tt.member, item.Objectives)
+		}
+		if len(item.Schedules[tt.profile]) != 1 || item.Schedules[tt.profile][0].Value != tt.night {
+			t.Errorf("Expected scheduled target %f for member %s - got: %v.", tt.night, tt.member, item.Schedules)
+		}
+	}
+	if item := updates[ChildIntentKey(ParentKindIntentGroup, "default/chain", "default/c")]; item.Budget == nil || item.Budget.MaxReplicas != 4 {
+		t.Errorf("Expected budget to be passed on - got: %v.", item.Budget)
+	}
+
+	// product - targets are split using the shares as exponents.
+	group = group.DeepCopy()
+	group.Spec.Aggregation = "product"
+	group.Spec.Objective.Value = 0.729
+	group.Spec.Objective.Schedule = nil
+	group.Spec.Members[0].Share = 0
+	f.addGroup(f.informer, group)
+	updates = f.syncAndCollect(mon, "default/chain")
+	for _, item := range updates {
+		for _, value := range item.Objectives {
+			if math.Abs(value-0.9) > 1e-9 {
+				t.Errorf("Expected target 0.9 - got: %v.", item.Objectives)
+			}
+		}
+	}
+
+	// member is removed from the group.
+	group = group.DeepCopy()
+	group.Spec.Members = group.Spec.Members[:2]
+	f.addGroup(f.informer, group)
+	updates = f.syncAndCollect(mon, "default/chain")
+	removed := updates[ChildIntentKey(ParentKindIntentGroup, "default/chain", "default/c")]
+	if len(updates) != 3 || removed.Priority != -1 || removed.ParentKey != "default/chain" || removed.ParentKind != ParentKindIntentGroup || removed.TargetKey != "default/c" {
+		t.Errorf("Expected removal of member c - got: %v.", updates)
+	}
+}
@@ -0,0 +1,191 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/intel/intent-driven-orchestration/pkg/api/intents/v1alpha1"
+	"github.com/intel/intent-driven-orchestration/pkg/common"
+	"github.com/intel/intent-driven-orchestration/pkg/planner"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// groupMember returns the member of a group referencing the given workload.
+func groupMember(group *v1alpha1.IntentGroup, targetKey string) (v1alpha1.GroupMember, bool) {
+	for _, member := range group.Spec.Members {
+		if member.TargetRef.Name == targetKey {
+			return member, true
+		}
+	}
+	return v1alpha1.GroupMember{}, false
+}
+
+// setMemberStatus updates the status of a member of a group, and aggregates the status of all members into the overall status of the group.
+func setMemberStatus(group *v1alpha1.IntentGroup, targetKey string, current common.State, desired common.State, plan []planner.Action, profiles map[string]common.Profile, now time.Time) {
+	member, found := groupMember(group, targetKey)
+	if !found {
+		return
+	}
+	index := -1
+	for i, item := range group.Status.Members {
+		if item.Name == targetKey {
+			index = i
+			break
+		}
+	}
+
+	// reuse the logic for a single workload on a scratch object; the target is the member's share of the objective.
+	profile := memberProfile(group, member)
+	objective := group.Spec.Objective
+	scratch := &v1alpha1.Intent{
+		ObjectMeta: group.ObjectMeta,
+		Spec: v1alpha1.IntentSpec{
+			TargetRef: member.TargetRef,
+			Objectives: []v1alpha1.TargetObjective{
+				{Name: objective.Name, MeasuredBy: profile, Value: desired.Intent.Objectives[profile], Tolerance: objective.Tolerance},
+			},
+		},
+	}
+	if index >= 0 {
+		previous := group.Status.Members[index]
+		scratch.Status = v1alpha1.IntentStatus{LastPlan: previous.LastPlan, LastPlanTime: previous.LastPlanTime, Conditions: previous.Conditions}
+	}
+	setIntentStatus(scratch, current, desired, plan, profiles, now)
+	workload := v1alpha1.WorkloadStatus{
+		Name:         targetKey,
+		Objectives:   scratch.Status.Objectives,
+		LastPlan:     scratch.Status.LastPlan,
+		LastPlanTime: scratch.Status.LastPlanTime,
+		Conditions:   scratch.Status.Conditions,
+	}
+	if index >= 0 {
+		group.Status.Members[index] = workload
+	} else {
+		group.Status.Members = append(group.Status.Members, workload)
+		sort.Slice(group.Status.Members, func(i, j int) bool {
+			return group.Status.Members[i].Name < group.Status.Members[j].Name
+		})
+	}
+	aggregateGroupStatus(group, profiles)
+}
+
+// removeMemberStatus removes the status of a workload resource no longer part of a group.
+func removeMemberStatus(group *v1alpha1.IntentGroup, targetKey string, profiles map[string]common.Profile) {
+	var members []v1alpha1.WorkloadStatus
+	for _, item := range group.Status.Members {
+		if item.Name != targetKey {
+			members = append(members, item)
+		}
+	}
+	group.Status.Members = members
+	aggregateGroupStatus(group, profiles)
+}
+
+// memberObserved checks if the objective of a member could be observed.
+func memberObserved(member v1alpha1.WorkloadStatus) bool {
+	if len(member.Objectives) == 0 || meta.IsStatusConditionTrue(member.Conditions, v1alpha1.ConditionProfileMissing) {
+		return false
+	}
+	degraded := meta.FindStatusCondition(member.Conditions, v1alpha1.ConditionDegraded)
+	return degraded == nil || degraded.Status != metaV1.ConditionTrue ||
+		(degraded.Reason != "MeasurementFailed" && degraded.Reason != "TargetNotFound")
+}
+
+// aggregateGroupStatus sets the overall status of a group based on the status of its members: the end-to-end value is
+// combined from the values observed for all members, and the member furthest off its share of the objective is
+// reported as the bottleneck.
+func aggregateGroupStatus(group *v1alpha1.IntentGroup, profiles map[string]common.Profile) {
+	status := &group.Status
+	generation := group.Generation
+	objective := group.Spec.Objective
+	profile, found := profiles[objective.MeasuredBy]
+
+	// the end-to-end objective.
+	item := &v1alpha1.ObjectiveStatus{
+		Name:       objective.Name,
+		MeasuredBy: objective.MeasuredBy,
+		Target:     objective.Value,
+		Tolerance:  objective.Tolerance,
+	}
+	var names []string
+	var values, targets []float64
+	for _, member := range status.Members {
+		if !memberObserved(member) {
+			continue
+		}
+		names = append(names, member.Name)
+		values = append(values, member.Objectives[0].Value)
+		targets = append(targets, member.Objectives[0].Target)
+		if window := member.Objectives[0].ActiveWindow; window != "" {
+			// all members share the same schedule windows.
+			for _, other := range objective.Schedule {
+				if other.Name == window {
+					item.Target = other.Value
+					item.ActiveWindow = window
+				}
+			}
+		}
+	}
+	complete := len(names) > 0 && len(names) == len(group.Spec.Members)
+	if complete {
+		item.Value = common.AggregationFromText(group.Spec.Aggregation).Combine(values)
+		item.Compliant = found && isCompliant(item.Value, item.Target, item.Tolerance, profile.Minimize)
+	}
+	status.Objective = item
+	status.Bottleneck = ""
+	if index := common.Bottleneck(values, targets, !found || profile.Minimize); index >= 0 {
+		status.Bottleneck = names[index]
+	}
+
+	// and all conditions.
+	if len(status.Members) == 0 {
+		for _, condition := range []string{v1alpha1.ConditionCompliant, v1alpha1.ConditionPlanning, v1alpha1.ConditionProfileMissing} {
+			meta.SetStatusCondition(&status.Conditions, metaV1.Condition{Type: condition, Status: metaV1.ConditionUnknown, ObservedGeneration: generation, Reason: "NoMembers", Message: "No status available for the members yet."})
+		}
+		meta.SetStatusCondition(&status.Conditions, metaV1.Condition{Type: v1alpha1.ConditionDegraded, Status: metaV1.ConditionTrue, ObservedGeneration: generation, Reason: "NoMembers", Message: "No status available for the members yet."})
+		return
+	}
+	aggregateConditions(&status.Conditions, status.Members, generation)
+	if !complete {
+		meta.SetStatusCondition(&status.Conditions, metaV1.Condition{Type: v1alpha1.ConditionCompliant, Status: metaV1.ConditionFalse, ObservedGeneration: generation, Reason: "MembersNotObserved", Message: fmt.Sprintf("Objective observed for %d out of %d member(s).", len(names), len(group.Spec.Members))})
+	} else if !item.Compliant {
+		meta.SetStatusCondition(&status.Conditions, metaV1.Condition{Type: v1alpha1.ConditionCompliant, Status: metaV1.ConditionFalse, ObservedGeneration: generation, Reason: "ObjectiveNotMet", Message: "End-to-end objective not met; bottleneck is workload " + status.Bottleneck + "."})
+	} else {
+		meta.SetStatusCondition(&status.Conditions, metaV1.Condition{Type: v1alpha1.ConditionCompliant, Status: metaV1.ConditionTrue, ObservedGeneration: generation, Reason: "ObjectiveMet", Message: "End-to-end objective is met."})
+	}
+}
+
+// modifyGroupStatus applies a modification to the status subresource of the group.
+func (c *IntentController) modifyGroupStatus(key string, modify func(group *v1alpha1.IntentGroup)) {
+	if c.intentClient == nil {
+		return
+	}
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("invalid resource key: '%s'", key))
+		return
+	}
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		group, err := c.intentClient.IdoV1alpha1().IntentGroups(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		groupCopy := group.DeepCopy()
+		modify(groupCopy)
+		_, err = c.intentClient.IdoV1alpha1().IntentGroups(namespace).UpdateStatus(context.TODO(), groupCopy, metaV1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("unable to update status subresource: %s", err))
+		return
+	}
+	klog.V(2).Infof("Updated status for intent group '%s'.", key)
+}
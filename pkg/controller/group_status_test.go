@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/intel/intent-driven-orchestration/pkg/api/intents/v1alpha1"
+	"github.com/intel/intent-driven-orchestration/pkg/common"
+	"github.com/intel/intent-driven-orchestration/pkg/generated/clientset/versioned/fake"
+	"github.com/intel/intent-driven-orchestration/pkg/planner"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// statusGroup returns an intent group for testing.
+func statusGroup() *v1alpha1.IntentGroup {
+	group := newIntentGroup("chain", 100, "default/a", "default/b")
+	group.Spec.Objective.MeasuredBy = "p99latency"
+	group.Spec.Objective.Tolerance = 0.1
+	return group
+}
+
+// memberState returns the current and desired state of a member of a group for testing.
+func memberState(latency float64, target float64) (common.State, common.State) {
+	current := common.State{
+		Intent:      common.Intent{Objectives: map[string]float64{"p99latency": latency}},
+		CurrentPods: map[string]common.PodState{"pod_0": {}},
+	}
+	desired := common.State{Intent: common.Intent{Objectives: map[string]float64{"p99latency": target}}}
+	return current, desired
+}
+
+// Tests for success.
+
+// TestSetMemberStatusForSuccess tests for success.
+func TestSetMemberStatusForSuccess(t *testing.T) {
+	group := statusGroup()
+	current, desired := memberState(40, 50)
+	setMemberStatus(group, "default/a", current, desired, nil, statusProfiles(), time.Now())
+	if len(group.Status.Members) != 1 || group.Status.Members[0].Name != "default/a" || group.Status.Objective == nil {
+		t.Errorf("Expected status for 1 member - got: %v.", group.Status)
+	}
+}
+
+// Tests for failure.
+
+// TestSetMemberStatusForFailure tests for failure.
+func TestSetMemberStatusForFailure(t *testing.T) {
+	group := statusGroup()
+	current, desired := memberState(40, 50)
+
+	// not a member of the group.
+	setMemberStatus(group, "default/c", current, desired, nil, statusProfiles(), time.Now())
+	if len(group.Status.Members) != 0 || group.Status.Objective != nil {
+		t.Errorf("Expected no status - got: %v.", group.Status)
+	}
+
+	// group does not exist.
+	c := newTestController()
+	client := fake.NewSimpleClientset()
+	c.intentClient = client
+	desired.Intent = common.Intent{Key: "default/chain@IntentGroup:default/a", ParentKey: "default/chain", ParentKind: ParentKindIntentGroup, TargetKey: "default/a"}
+	c.updateStatus(desired.Intent.Key, current, desired, nil, statusProfiles())
+	if res := client.Actions(); len(res) != 1 || res[0].GetVerb() != "get" {
+		t.Errorf("Expected only a get - got: %v.", res)
+	}
+}
+
+// Tests for sanity.
+
+// TestSetMemberStatusForSanity tests for sanity.
+func TestSetMemberStatusForSanity(t *testing.T) {
+	group := statusGroup()
+	profiles := statusProfiles()
+	now := time.Now()
+
+	// only one member observed so far.
+	current, desired := memberState(40, 50)
+	setMemberStatus(group, "default/b", current, desired, nil, profiles, now)
+	compliant := meta.FindStatusCondition(group.Status.Conditions, v1alpha1.ConditionCompliant)
+	if compliant.Status != metaV1.ConditionFalse || compliant.Reason != "MembersNotObserved" || group.Status.Objective.Compliant {
+		t.Errorf("Expected to be non compliant - got: %v.", group.Status)
+	}
+
+	// both members observed - end-to-end latency of 80 is compliant.
+	current, desired = memberState(40, 50)
+	setMemberStatus(group, "default/a", current, desired, nil, profiles, now)
+	if len(group.Status.Members) != 2 || group.Status.Members[0].Name != "default/a" {
+		t.Errorf("Expected 2 sorted members - got: %v.", group.Status.Members)
+	}
+	if group.Status.Objective.Value != 80 || group.Status.Objective.Target != 100 || !group.Status.Objective.Compliant {
+		t.Errorf("Expected compliant end-to-end objective - got: %v.", group.Status.Objective)
+	}
+	if !meta.IsStatusConditionTrue(group.Status.Conditions, v1alpha1.ConditionCompliant) {
+		t.Errorf("Expected to be compliant: %v.", group.Status.Conditions)
+	}
+
+	// member b is the bottleneck - end-to-end latency of 130 is not compliant.
+	plan := []planner.Action{{Name: "scaleOut", Properties: map[string]int64{"factor": 1}}}
+	current, desired = memberState(90, 50)
+	setMemberStatus(group, "default/b", current, desired, plan, profiles, now)
+	compliant = meta.FindStatusCondition(group.Status.Conditions, v1alpha1.ConditionCompliant)
+	if compliant.Status != metaV1.ConditionFalse || compliant.Reason != "ObjectiveNotMet" || group.Status.Bottleneck != "default/b" {
+		t.Errorf("Expected member b to be the bottleneck: %v - %v.", compliant, group.Status.Bottleneck)
+	}
+	if group.Status.Objective.Value != 130 || group.Status.Objective.Compliant {
+		t.Errorf("Expected non compliant end-to-end objective - got: %v.", group.Status.Objective)
+	}
+	if !meta.IsStatusConditionTrue(group.Status.Conditions, v1alpha1.ConditionPlanning) || len(group.Status.Members[1].LastPlan) != 1 {
+		t.Errorf("Expected plan to be reported: %v.", group.Status)
+	}
+
+	// member b leaves the group.
+	group.Spec.Members = group.Spec.Members[:1]
+	removeMemberStatus(group, "default/b", profiles)
+	if len(group.Status.Members) != 1 || group.Status.Objective.Value != 40 || !meta.IsStatusConditionTrue(group.Status.Conditions, v1alpha1.ConditionCompliant) {
+		t.Errorf("Expected only compliant member a - got: %v.", group.Status)
+	}
+
+	// no members left.
+	removeMemberStatus(group, "default/a", profiles)
+	degraded := meta.FindStatusCondition(group.Status.Conditions, v1alpha1.ConditionDegraded)
+	if len(group.Status.Members) != 0 || degraded.Status != metaV1.ConditionTrue || degraded.Reason != "NoMembers" || group.Status.Objective.Compliant {
+		t.Errorf("Expected to be degraded - got: %v.", group.Status)
+	}
+}
+
+// TestUpdateGroupStatusForSanity tests for sanity.
+func TestUpdateGroupStatusForSanity(t *testing.T) {
+	group := statusGroup()
+	c := newTestController()
+	client := fake.NewSimpleClientset(group)
+	c.intentClient = client
+	current, desired := memberState(40, 50)
+	desired.Intent.Key = "default/chain@IntentGroup:default/a"
+	desired.Intent.ParentKey = "default/chain"
+	desired.Intent.ParentKind = ParentKindIntentGroup
+	desired.Intent.TargetKey = "default/a"
+	c.updateStatus(desired.Intent.Key, current, desired, nil, statusProfiles())
+
+	res, err := client.IdoV1alpha1().IntentGroups("default").Get(t.Context(), "chain", metaV1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Could not get intent group: %v", err)
+	}
+	if len(res.Status.Members) != 1 || res.Status.Members[0].Name != "default/a" || res.Status.Objective == nil {
+		t.Errorf("Status of group not updated as expected: %v.", res.Status)
+	}
+
+	// no intent should have been touched.
+	for _, action := range client.Actions() {
+		if action.GetResource().Resource != "intentgroups" {
+			t.Errorf("Unexpected action: %v.", action)
+		}
+	}
+}
@@ -1,19 +1,48 @@
 package controller
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"runtime"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/intel/intent-driven-orchestration/pkg/api/intents/v1alpha1"
 	"github.com/intel/intent-driven-orchestration/pkg/common"
+	clientSet "github.com/intel/intent-driven-orchestration/pkg/generated/clientset/versioned"
 	"github.com/intel/intent-driven-orchestration/pkg/planner"
+	"github.com/prometheus/client_golang/prometheus"
 
 	v1 "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/workqueue"
 
+	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 )
 
+// defaultReplanBackoffBase is the initial delay before planning again for an intent after a failed plan.
+const defaultReplanBackoffBase = 5 * time.Second
+
+// defaultReplanBackoffMax is the max delay before planning again for an intent after failed plans.
+const defaultReplanBackoffMax = 5 * time.Minute
+
+// errPlanFailed is returned for intents whose objectives are not met and for which no plan could be found.
+var errPlanFailed = errors.New("no plan found to meet the objectives")
+
+// errTransientFailure is returned for intents for which an actuator failed to perform the plan - but might succeed
+// when trying again.
+var errTransientFailure = errors.New("transient failure while performing the plan")
+
+// errPlanRejected is returned for intents whose plan was rejected during the arbitration - so they back off instead of
+// losing again on every tick.
+var errPlanRejected = errors.New("plan rejected during the arbitration")
+
 // warmupDone will be set to true once the first tick is triggered.
 var warmupDone = false
 
@@ -22,11 +51,16 @@ var warmupLock = sync.RWMutex{}
 
 // IntentController defines the overall intent controller.
 type IntentController struct {
+	EventEmitter
 	cfg          common.Config
 	clientSet    kubernetes.Interface
+	intentClient clientSet.Interface
 	podInformer  v1.PodInformer
-	tasks        chan string
+	nodeInformer v1.NodeInformer
+	queue        workqueue.TypedRateLimitingInterface[string]
 	intents      map[string]common.Intent
+	// lastPlanned holds when a plan was last created per intent - guarded by the intentsLock.
+	lastPlanned  map[string]time.Time
 	intentsLock  sync.RWMutex
 	profiles     map[string]common.Profile
 	profilesLock sync.Mutex
@@ -36,27 +70,73 @@ type IntentController struct {
 	tracer       Tracer
 	planCache    *common.TTLCache
 	plannerMutex sync.RWMutex
+	clock        clock.PassiveClock
+	// stopping is set once the controller shuts down - guarded by the intentsLock.
+	stopping bool
+	workers  sync.WaitGroup
+	inFlight sync.WaitGroup
+	// nWorkers is the number of workers started; lent counts the workers lent to those waiting for an arbitration, and
+	// surplus how many of them still need to retire.
+	nWorkers int
+	lent     atomic.Int32
+	surplus  atomic.Int32
+	// settling holds the executed plans which might still get rolled back per intent - guarded by the intentsLock.
+	settling map[string]settlingPlan
+	// cooldowns holds the actuators cooling down per intent - guarded by the intentsLock.
+	cooldowns map[string]map[string]cooldown
+	// arbiter checks the plans against the capacity of the nodes - nil if the arbitration is disabled.
+	arbiter *arbiter
 }
 
 // NewController initializes a new IntentController.
-func NewController(cfg common.Config, tracer Tracer, clientSet kubernetes.Interface, informer v1.PodInformer) *IntentController {
+func NewController(cfg common.Config, tracer Tracer, clientSet kubernetes.Interface, intentClient clientSet.Interface, informer v1.PodInformer, nodeInformer v1.NodeInformer) *IntentController {
 	if cfg.Controller.TaskChannelLength <= 0 ||
 		cfg.Controller.TaskChannelLength > common.MaxTaskChannelLen {
 		klog.Error("invalid input value. Check documentation for the allowed limit")
 		return nil
 	}
-	taskChannel := make(chan string, cfg.Controller.TaskChannelLength)
+	backoffBase := defaultReplanBackoffBase
+	if cfg.Controller.ReplanBackoffBase > 0 {
+		backoffBase = time.Duration(cfg.Controller.ReplanBackoffBase) * time.Millisecond
+	}
+	backoffMax := defaultReplanBackoffMax
+	if cfg.Controller.ReplanBackoffMax > 0 {
+		backoffMax = time.Duration(cfg.Controller.ReplanBackoffMax) * time.Millisecond
+	}
+	if backoffMax < backoffBase {
+		backoffMax = backoffBase
+	}
+	queue := workqueue.NewTypedRateLimitingQueueWithConfig[string](
+		workqueue.NewTypedItemExponentialFailureRateLimiter[string](backoffBase, backoffMax),
+		workqueue.TypedRateLimitingQueueConfig[string]{Name: "Tasks"})
 	c := &IntentController{
-		cfg:         cfg,
-		clientSet:   clientSet,
-		podInformer: informer,
-		tasks:       taskChannel,
-		intents:     make(map[string]common.Intent),
-		profiles:    make(map[string]common.Profile),
-		podErrors:   make(map[string][]common.PodError),
-		tracer:      tracer,
+		cfg:          cfg,
+		clientSet:    clientSet,
+		intentClient: intentClient,
+		podInformer:  informer,
+		nodeInformer: nodeInformer,
+		queue:        queue,
+		intents:      make(map[string]common.Intent),
+		lastPlanned:  make(map[string]time.Time),
+		settling:     make(map[string]settlingPlan),
+		cooldowns:    make(map[string]map[string]cooldown),
+		profiles:     make(map[string]common.Profile),
+		podErrors:    make(map[string][]common.PodError),
+		tracer:       tracer,
+		clock:        clock.RealClock{},
 	}
 	c.planCache, _ = common.NewCache(cfg.Controller.PlanCacheTTL, time.Duration(cfg.Controller.PlanCacheTimeout))
+	if informer != nil {
+		indexPodsByNode(informer)
+	}
+	if nodeInformer != nil {
+		// registers the informer with its factory - so it gets started with the factory.
+		nodeInformer.Informer()
+	}
+	if cfg.Controller.ArbitrationWindow > 0 {
+		c.arbiter = newArbiter(time.Duration(cfg.Controller.ArbitrationWindow)*time.Millisecond,
+			time.Duration(cfg.Controller.PlanCacheTTL)*time.Millisecond, nodeInformer, informer)
+	}
 	return c
 }
 
@@ -68,6 +148,11 @@ func (c *IntentController) SetPlanner(planner planner.Planner) {
 	c.planner = planner
 }
 
+// SetClock sets the time source used to determine the active schedule windows of the objectives.
+func (c *IntentController) SetClock(source clock.PassiveClock) {
+	c.clock = source
+}
+
 func (c *IntentController) getPlanner() planner.Planner {
 	c.plannerMutex.RLock()
 	defer c.plannerMutex.RUnlock()
@@ -75,6 +160,29 @@ func (c *IntentController) getPlanner() planner.Planner {
 	return planner
 }
 
+// PlannerReady returns an error if no planner is set - e.g. as this replica is not the leader - or the planner is not
+// ready yet.
+func (c *IntentController) PlannerReady() error {
+	current := c.getPlanner()
+	if current == nil {
+		return errors.New("no planner set")
+	}
+	if checker, ok := current.(planner.ReadinessChecker); ok {
+		return checker.Ready()
+	}
+	return nil
+}
+
+// WarmedUp returns an error until the first tick has happened.
+func (c *IntentController) WarmedUp() error {
+	warmupLock.RLock()
+	defer warmupLock.RUnlock()
+	if !warmupDone {
+		return errors.New("waiting for the first tick")
+	}
+	return nil
+}
+
 // UpdateIntent channel function used by the intent monitor so send updates.
 func (c *IntentController) UpdateIntent() chan<- common.Intent {
 	events := make(chan common.Intent)
@@ -85,8 +193,31 @@ func (c *IntentController) UpdateIntent() chan<- common.Intent {
 				c.intents[e.Key] = e
 			} else {
 				delete(c.intents, e.Key)
+				delete(c.lastPlanned, e.Key)
+				delete(c.settling, e.Key)
+				delete(c.cooldowns, e.Key)
+				common.PlanningDuration.DeleteLabelValues(e.Key)
+				common.ActuatorCooldown.DeletePartialMatch(prometheus.Labels{"intent": e.Key})
 			}
 			c.intentsLock.Unlock()
+			if e.Priority < 0 && e.ParentKey != "" {
+				// workload no longer selected by the parent intent - or no longer part of the group.
+				c.profilesLock.Lock()
+				profiles := make(map[string]common.Profile, len(c.profiles))
+				for k, v := range c.profiles {
+					profiles[k] = v
+				}
+				c.profilesLock.Unlock()
+				if e.ParentKind == ParentKindIntentGroup {
+					c.modifyGroupStatus(e.ParentKey, func(group *v1alpha1.IntentGroup) {
+						removeMemberStatus(group, e.TargetKey, profiles)
+					})
+				} else {
+					c.modifyStatus(e.ParentKey, func(intent *v1alpha1.Intent) {
+						removeWorkloadStatus(intent, e.TargetKey, profiles)
+					})
+				}
+			}
 			c.processIntents()
 		}
 	}()
@@ -129,59 +260,337 @@ func (c *IntentController) UpdatePodError() chan<- common.PodError {
 	return events
 }
 
-// processIntents triggers processing of all intents currently known.
+// processIntents triggers processing of all intents currently known - those with a higher priority first. Intents
+// already queued are not queued again, and those backing off after a failed plan are queued once the backoff expires.
 func (c *IntentController) processIntents() {
 	warmupLock.Lock()
 	tmp := warmupDone
 	warmupLock.Unlock()
 	if tmp {
-		c.intentsLock.Lock()
+		c.intentsLock.RLock()
+		defer c.intentsLock.RUnlock()
+		if c.stopping {
+			return
+		}
+		keys := make([]string, 0, len(c.intents))
 		for key := range c.intents {
-			if !c.planCache.IsIn(key) {
-				// only need to trigger planner when we've not recently triggered a plan execution.
-				c.tasks <- key
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if c.intents[keys[i]].Priority != c.intents[keys[j]].Priority {
+				return c.intents[keys[i]].Priority > c.intents[keys[j]].Priority
+			}
+			return keys[i] < keys[j]
+		})
+		for _, key := range keys {
+			if c.planCache.IsIn(key) {
+				common.PlanCacheLookups.WithLabelValues("hit").Inc()
+				continue
+			}
+			// only need to trigger planner when we've not recently triggered a plan execution.
+			common.PlanCacheLookups.WithLabelValues("miss").Inc()
+			if c.queue.NumRequeues(key) > 0 {
+				klog.V(2).Infof("Backing off from planning for: %s.", key)
+				continue
 			}
+			if c.queue.Len() >= c.cfg.Controller.TaskChannelLength {
+				klog.Warningf("Task queue is full - not queueing: %s.", key)
+				continue
+			}
+			c.queue.Add(key)
 		}
-		c.intentsLock.Unlock()
+		common.TaskQueueDepth.Set(float64(c.queue.Len()))
 	}
 }
 
-// worker will trigger the planner to look into an intent.
-func (c *IntentController) worker(id int, tasks <-chan string) {
-	for key := range tasks {
-		klog.V(2).Infof("Worker %d looking at: %s.", id, key)
-		planner := c.getPlanner()
-		if planner == nil {
-			klog.Info("no planner configured")
-			continue
+// worker will trigger the planner to look into the intents in the queue - until the queue is shut down, or a worker lent
+// before is no longer needed.
+func (c *IntentController) worker(id int) {
+	for c.processNextTask(id) {
+		if c.retire() {
+			return
 		}
-		c.intentsLock.Lock()
-		current := getCurrentState(c.cfg.Controller, c.clientSet, c.podInformer, c.intents[key], c.podErrors, c.profiles)
-		desired := getDesiredState(c.intents[key])
-		c.intentsLock.Unlock()
-		plan := planner.CreatePlan(current, desired, c.profiles)
-		klog.Infof("Planner output for %s was: %v", key, plan)
-		if desired.Intent.ActivelyManaged && len(plan) > 0 {
-			klog.V(2).Infof("Triggering execution of plan for: %s.", key)
-			go planner.ExecutePlan(current, plan)
+	}
+}
+
+// lendWorker starts another worker while the calling one waits for the arbitration - so all intents queued for a tick
+// get planned for within the arbitration window, even if there are more of them than workers.
+func (c *IntentController) lendWorker() {
+	if c.nWorkers == 0 {
+		return
+	}
+	id := c.nWorkers + int(c.lent.Add(1)) - 1
+	c.surplus.Add(1)
+	c.workers.Add(1)
+	go func() {
+		defer c.workers.Done()
+		c.worker(id)
+	}()
+}
+
+// retire checks if the calling worker should stop - as more workers than configured are running.
+func (c *IntentController) retire() bool {
+	for {
+		n := c.surplus.Load()
+		if n <= 0 {
+			return false
+		}
+		if c.surplus.CompareAndSwap(n, n-1) {
+			return true
+		}
+	}
+}
+
+// processNextTask takes the next intent from the queue; intents for which planning failed, or whose plan could not be
+// performed due to transient failures, are queued again with an exponential backoff, and those planned for recently are delayed until the min replan interval has passed.
+func (c *IntentController) processNextTask(id int) bool {
+	key, shutdown := c.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer c.queue.Done(key)
+	common.TaskQueueDepth.Set(float64(c.queue.Len()))
+
+	c.intentsLock.RLock()
+	_, known := c.intents[key]
+	stopping := c.stopping
+	delay := c.replanDelay(key)
+	c.intentsLock.RUnlock()
+	if stopping {
+		return false
+	}
+	if !known {
+		c.queue.Forget(key)
+		return true
+	}
+	if delay > 0 {
+		klog.V(2).Infof("Planned for %s recently - delaying by %s.", key, delay)
+		c.queue.AddAfter(key, delay)
+		return true
+	}
+
+	if err := c.processIntent(id, key); err != nil {
+		klog.Warningf("Planning for %s failed - will retry after backoff: %v.", key, err)
+		c.queue.AddRateLimited(key)
+		return true
+	}
+	c.queue.Forget(key)
+	return true
+}
+
+// replanDelay returns how long to wait before planning for an intent again; needs to be called holding the
+// intentsLock.
+func (c *IntentController) replanDelay(key string) time.Duration {
+	if c.cfg.Controller.MinReplanInterval <= 0 {
+		return 0
+	}
+	last, ok := c.lastPlanned[key]
+	if !ok {
+		return 0
+	}
+	return last.Add(time.Duration(c.cfg.Controller.MinReplanInterval) * time.Millisecond).Sub(c.clock.Now())
+}
+
+// processIntent triggers the planner to look into an intent; returns an error if the planning failed.
+func (c *IntentController) processIntent(id int, key string) error {
+	klog.V(2).Infof("Worker %d looking at: %s.", id, key)
+	p := c.getPlanner()
+	if p == nil {
+		klog.Info("no planner configured")
+		return nil
+	}
+	c.intentsLock.Lock()
+	current := getCurrentState(c.cfg.Controller, c.clientSet, c.podInformer, c.nodeInformer, c.intents[key], c.podErrors, c.profiles)
+	desired := getDesiredState(c.intents[key], c.clock.Now())
+	c.lastPlanned[key] = c.clock.Now()
+	c.intentsLock.Unlock()
+	if current.CurrentPods == nil {
+		c.IntentEvent(desired.Intent, coreV1.EventTypeWarning, ReasonTargetNotFound,
+			"%s %s could not be found.", desired.Intent.TargetKind, desired.Intent.TargetKey)
+	}
+	if c.checkSettling(key, current, desired) {
+		return nil
+	}
+	desired.Intent.Cooldowns = c.activeCooldowns(key, desired)
+	current.Intent.Cooldowns = desired.Intent.Cooldowns
+	start := time.Now()
+	plan, predicted := c.createPlan(p, current, desired)
+	common.PlanningDuration.WithLabelValues(key).Observe(time.Since(start).Seconds())
+	common.PlanLength.Observe(float64(len(plan)))
+	klog.Infof("Planner output for %s was: %v", key, plan)
+	if len(plan) > 0 {
+		c.IntentEvent(desired.Intent, coreV1.EventTypeNormal, ReasonPlanCreated, "Created plan: %s.", planSummary(plan))
+	} else {
+		c.IntentEvent(desired.Intent, coreV1.EventTypeNormal, ReasonPlanEmpty, "No actions needed or possible.")
+	}
+	shadowed := desired.Intent.ActivelyManaged && len(plan) > 0 && c.shadowed(desired.Intent)
+	var lost string
+	if desired.Intent.ActivelyManaged && len(plan) > 0 && !shadowed {
+		plan, predicted, lost = c.arbitrate(key, current, desired, plan, predicted)
+	}
+	var results []planner.Result
+	if desired.Intent.ActivelyManaged && len(plan) > 0 && !shadowed {
+		results = c.executePlan(p, key, current, desired, plan, predicted)
+	}
+	klog.V(2).Infof("Triggering effect calculation for: %s.", key)
+	c.inFlight.Add(1)
+	go func() {
+		defer c.inFlight.Done()
+		p.TriggerEffect(current, c.profiles)
+	}()
+	klog.V(2).Infof("Tracing event for: %s.", key)
+	c.tracer.TraceEvent(current, desired, plan, results)
+	c.updateStatus(key, current, desired, plan, c.profiles)
+	if lost != "" {
+		c.updateArbitrationStatus(key, desired, len(plan) > 0, lost)
+	}
+	if shadowed {
+		c.shadowPlan(p, key, current, desired, plan)
+	}
+	if failed := transientFailures(results); len(failed) > 0 {
+		return fmt.Errorf("%w: %s", errTransientFailure, resultSummary(failed))
+	}
+	if current.CurrentPods == nil {
+		return fmt.Errorf("%s %s could not be found", desired.Intent.TargetKind, desired.Intent.TargetKey)
+	}
+	if lost != "" && len(plan) == 0 {
+		return fmt.Errorf("%w: %s", errPlanRejected, lost)
+	}
+	if len(plan) == 0 && !objectivesMet(current, desired, c.profiles) {
+		return errPlanFailed
+	}
+	return nil
+}
+
+// createPlan asks the planner for a plan - and for the state each action of the plan is predicted to lead to, if the
+// planner can tell.
+func (c *IntentController) createPlan(p planner.Planner, current common.State, desired common.State) ([]planner.Action, []common.State) {
+	if predictor, ok := p.(planner.Predictor); ok {
+		return predictor.CreatePredictedPlan(current, desired, c.profiles)
+	}
+	return p.CreatePlan(current, desired, c.profiles), nil
+}
+
+// executePlan performs the plan and reports the outcome; intents for which the plan could not be performed due to
+// transient failures are not put in the plan cache - so they can be retried. Otherwise, the workload is given time to
+// settle, so the plan can be rolled back if the objectives get worse than predicted. If cooldowns are configured, only
+// the actuators which ran cool down - instead of putting the intent in the plan cache.
+func (c *IntentController) executePlan(p planner.Planner, key string, current common.State, desired common.State, plan []planner.Action, steps []common.State) []planner.Result {
+	klog.V(2).Infof("Triggering execution of plan for: %s.", key)
+	var predicted *common.State
+	if len(steps) > 0 {
+		predicted = &steps[len(steps)-1]
+	}
+	snapshot := c.snapshot(desired, predicted)
+	results := p.ExecutePlan(current, plan)
+	klog.Infof("Results of executing the plan for %s were: %v", key, results)
+	var failed []planner.Result
+	for _, result := range results {
+		if result.Status == planner.ResultFailed {
+			failed = append(failed, result)
+		}
+	}
+	if len(failed) > 0 {
+		c.IntentEvent(desired.Intent, coreV1.EventTypeWarning, ReasonPerformFailed, "Failed to execute plan %s: %s.", planSummary(plan), resultSummary(failed))
+	} else {
+		c.IntentEvent(desired.Intent, coreV1.EventTypeNormal, ReasonPlanExecuted, "Executed plan: %s.", planSummary(plan))
+	}
+	c.startCooldowns(key, results)
+	if len(transientFailures(results)) == 0 {
+		if len(c.cfg.Controller.Cooldowns) == 0 {
 			c.planCache.Put(key)
 		}
-		klog.V(2).Infof("Triggering effect calculation for: %s.", key)
-		go planner.TriggerEffect(current, c.profiles)
-		klog.V(2).Infof("Tracing event for: %s.", key)
-		c.tracer.TraceEvent(current, desired, plan)
+		c.settle(key, desired, snapshot, predicted, plan, results)
 	}
+	return results
+}
+
+// transientFailures returns those results which failed - but might succeed when trying again.
+func transientFailures(results []planner.Result) []planner.Result {
+	var res []planner.Result
+	for _, result := range results {
+		if result.Status == planner.ResultFailed && result.Transient {
+			res = append(res, result)
+		}
+	}
+	return res
+}
+
+// resultSummary returns a short, human-readable description of the results - e.g. "scaleOut failed (conflict)".
+func resultSummary(results []planner.Result) string {
+	items := make([]string, 0, len(results))
+	for _, result := range results {
+		item := result.Actuator + " " + string(result.Status)
+		if result.Reason != "" {
+			item += " (" + result.Reason + ")"
+		}
+		items = append(items, item)
+	}
+	return strings.Join(items, ", ")
+}
+
+// shadowed checks if the planner should only record the changes it would apply for an intent - instead of applying
+// them.
+func (c *IntentController) shadowed(intent common.Intent) bool {
+	if c.cfg.Controller.ShadowMode {
+		return true
+	}
+	namespace := strings.Split(intent.Key, "/")[0]
+	for _, item := range c.cfg.Controller.ShadowNamespaces {
+		if item == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// shadowPlan records the changes the execution of a plan would apply - in the tracer and the status of the intent;
+// planners not supporting dry runs report no changes.
+func (c *IntentController) shadowPlan(p planner.Planner, key string, current common.State, desired common.State, plan []planner.Action) {
+	klog.V(2).Infof("Triggering dry run of plan for: %s.", key)
+	var patches []planner.Patch
+	if dryRunner, ok := p.(planner.DryRunner); ok {
+		patches = dryRunner.DryRunPlan(current, plan)
+	} else {
+		klog.Warning("Planner does not support dry runs.")
+	}
+	c.planCache.Put(key)
+	c.IntentEvent(desired.Intent, coreV1.EventTypeNormal, ReasonPlanShadowed, "Recorded plan in shadow mode with %d change(s): %s.", len(patches), planSummary(plan))
+	if tracer, ok := c.tracer.(ShadowTracer); ok {
+		tracer.TraceShadowPlan(desired, plan, patches)
+	}
+	c.updateShadowStatus(key, desired, patches)
+}
+
+// objectivesMet checks if the current state meets the objectives of the desired state - objectives which could not be
+// observed are not met.
+func objectivesMet(current common.State, desired common.State, profiles map[string]common.Profile) bool {
+	if len(desired.Intent.Objectives) == 0 {
+		return true
+	}
+	for key := range desired.Intent.Objectives {
+		if value, ok := current.Intent.Objectives[key]; !ok || value < 0 {
+			return false
+		}
+	}
+	return current.IsBetter(&desired, profiles)
 }
 
 // Run the overall IntentController logic.
 func (c *IntentController) Run(nWorkers int, stopper <-chan struct{}) {
+	c.nWorkers = nWorkers
 	for i := 0; i < nWorkers; i++ {
-		go c.worker(i, c.tasks)
+		c.workers.Add(1)
+		go func(id int) {
+			defer c.workers.Done()
+			c.worker(id)
+		}(i)
 	}
 	klog.V(1).Infof("Started %d worker(s).", nWorkers)
 
 	ticker := time.NewTicker(time.Duration(c.cfg.Controller.ControllerTimeout) * time.Second)
 	go func() {
+		defer ticker.Stop()
 		for {
 			select {
 			case <-stopper:
@@ -200,3 +609,51 @@ func (c *IntentController) Run(nWorkers int, stopper <-chan struct{}) {
 		}
 	}()
 }
+
+// Shutdown stops the controller from processing intents: pending tasks are dropped, and the workers - including the plan
+// executions they perform - as well as the in-flight effect calculations are waited for - until the context is done.
+func (c *IntentController) Shutdown(ctx context.Context) error {
+	c.intentsLock.Lock()
+	if c.stopping {
+		c.intentsLock.Unlock()
+		return nil
+	}
+	c.stopping = true
+	c.intentsLock.Unlock()
+	c.queue.ShutDown()
+	dropped := 0
+	for c.queue.Len() > 0 {
+		key, shutdown := c.queue.Get()
+		if shutdown {
+			break
+		}
+		c.queue.Forget(key)
+		c.queue.Done(key)
+		dropped++
+	}
+	common.TaskQueueDepth.Set(0)
+	klog.Infof("Shutting down controller - dropped %d pending task(s).", dropped)
+
+	if err := waitFor(ctx, &c.workers); err != nil {
+		return fmt.Errorf("workers did not finish in time: %w", err)
+	}
+	if err := waitFor(ctx, &c.inFlight); err != nil {
+		return fmt.Errorf("effect calculations did not finish in time: %w", err)
+	}
+	return nil
+}
+
+// waitFor waits for a wait group - until the context is done.
+func waitFor(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -1,7 +1,14 @@
 package controller
 
 import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -14,6 +21,7 @@ import (
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/klog/v2"
+	testingClock "k8s.io/utils/clock/testing"
 )
 
 // TIMEOUT used to ensure updates get processed by the controller before asserting the results.
@@ -26,7 +34,7 @@ func (d dummyTracer) GetEffect(string, string, string, int, func() interface{})
 	return nil, nil
 }
 
-func (d dummyTracer) TraceEvent(_ common.State, _ common.State, _ []planner.Action) {
+func (d dummyTracer) TraceEvent(_ common.State, _ common.State, _ []planner.Action, _ []planner.Result) {
 	klog.Info("TraceEvent called.")
 }
 
@@ -37,14 +45,134 @@ func (d dummyPlanner) CreatePlan(_ common.State, _ common.State, _ map[string]co
 	return []planner.Action{{Name: "test"}, {Name: "done"}}
 }
 
-func (d dummyPlanner) ExecutePlan(_ common.State, _ []planner.Action) {
+func (d dummyPlanner) ExecutePlan(_ common.State, _ []planner.Action) []planner.Result {
 	klog.Info("Execute called.")
+	return []planner.Result{{Actuator: "test", Status: planner.ResultApplied}}
 }
 
 func (d dummyPlanner) TriggerEffect(_ common.State, _ map[string]common.Profile) {
 	klog.Info("Trigger called.")
 }
 
+// unreadyPlanner for testing - reports the given readiness.
+type unreadyPlanner struct {
+	dummyPlanner
+	err error
+}
+
+func (d unreadyPlanner) Ready() error {
+	return d.err
+}
+
+// countingPlanner for testing - counts how often a plan was created per intent.
+type countingPlanner struct {
+	dummyPlanner
+	lock  *sync.Mutex
+	count map[string]int
+	plan  []planner.Action
+}
+
+func newCountingPlanner(plan []planner.Action) countingPlanner {
+	return countingPlanner{lock: &sync.Mutex{}, count: map[string]int{}, plan: plan}
+}
+
+func (d countingPlanner) CreatePlan(_ common.State, desired common.State, _ map[string]common.Profile) []planner.Action {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.count[desired.Intent.Key]++
+	return d.plan
+}
+
+func (d countingPlanner) calls(key string) int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.count[key]
+}
+
+// newQueueTestController returns a controller with existing target workloads for testing the task queue.
+func newQueueTestController(t *testing.T, modify func(cfg *common.ControllerConfig), keys ...string) *IntentController {
+	deployment, pods := createDummies("Deployment", map[string]string{"foo": "bar"}, 1)
+	client, informer := k8sShim(deployment, pods)
+	cfg := newTestController().cfg
+	modify(&cfg.Controller)
+	c := NewController(cfg, dummyTracer{}, client, nil, informer, nodeShim())
+	if c == nil {
+		t.Fatal("Could not create controller.")
+	}
+	for _, key := range keys {
+		c.intents[key] = common.Intent{Key: key, Priority: 1.0, TargetKey: "default/my-deployment", TargetKind: "Deployment"}
+	}
+	warmupLock.Lock()
+	warmupDone = true
+	warmupLock.Unlock()
+	return c
+}
+
+// shadowPlanner for testing - reports executed plans and dry runs.
+type shadowPlanner struct {
+	dummyPlanner
+	executed chan string
+	dryRuns  chan string
+}
+
+func (d shadowPlanner) ExecutePlan(state common.State, _ []planner.Action) []planner.Result {
+	d.executed <- state.Intent.Key
+	return nil
+}
+
+func (d shadowPlanner) DryRunPlan(state common.State, _ []planner.Action) []planner.Patch {
+	d.dryRuns <- state.Intent.Key
+	return []planner.Patch{{Actuator: "test", Kind: "Deployment", Namespace: "default", Name: "my-deployment"}}
+}
+
+// shadowTracer for testing - reports the traced patches.
+type shadowTracer struct {
+	dummyTracer
+	patches chan []planner.Patch
+}
+
+func (d shadowTracer) TraceShadowPlan(_ common.State, _ []planner.Action, patches []planner.Patch) {
+	d.patches <- patches
+}
+
+// resultPlanner for testing - reports the given results when executing a plan.
+type resultPlanner struct {
+	dummyPlanner
+	results []planner.Result
+}
+
+func (d resultPlanner) ExecutePlan(_ common.State, _ []planner.Action) []planner.Result {
+	return d.results
+}
+
+// resultTracer for testing - reports the traced results.
+type resultTracer struct {
+	dummyTracer
+	results chan []planner.Result
+}
+
+func (d resultTracer) TraceEvent(_ common.State, _ common.State, _ []planner.Action, results []planner.Result) {
+	d.results <- results
+}
+
+// slowPlanner for testing - planning and plan executions take a while.
+type slowPlanner struct {
+	dummyPlanner
+	delay    time.Duration
+	executed chan string
+}
+
+func (d slowPlanner) CreatePlan(current common.State, desired common.State, profiles map[string]common.Profile) []planner.Action {
+	time.Sleep(d.delay)
+	return d.dummyPlanner.CreatePlan(current, desired, profiles)
+}
+
+func (d slowPlanner) ExecutePlan(state common.State, _ []planner.Action) []planner.Result {
+	time.Sleep(d.delay)
+	d.executed <- state.Intent.Key
+	return nil
+}
+
 // newTestController returns a controller ready for testing.
 func newTestController() *IntentController {
 	controllerConfig := common.ControllerConfig{
@@ -60,11 +188,37 @@ func newTestController() *IntentController {
 	client := fake.NewSimpleClientset(nil...)
 	informer := informers.NewSharedInformerFactory(client, func() time.Duration { return 0 }())
 	dummyPlanner := dummyPlanner{}
-	controller := NewController(cfg, dummyTracer{}, nil, informer.Core().V1().Pods())
+	controller := NewController(cfg, dummyTracer{}, nil, nil, informer.Core().V1().Pods(), informer.Core().V1().Nodes())
 	controller.SetPlanner(dummyPlanner)
 	return controller
 }
 
+// scrapeMetrics returns the metrics as exposed on /metrics.
+func scrapeMetrics(t *testing.T) string {
+	server := httptest.NewServer(common.MetricsHandler())
+	defer server.Close()
+	response, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Could not scrape metrics: %v", err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("Could not read metrics: %v", err)
+	}
+	return string(body)
+}
+
+// metricValue returns the value of a single series - or an empty string if the series was not found.
+func metricValue(metrics string, series string) string {
+	for _, line := range strings.Split(metrics, "\n") {
+		if value, ok := strings.CutPrefix(line, series+" "); ok {
+			return value
+		}
+	}
+	return ""
+}
+
 // Tests for success.
 
 // TestUpdateProfileForSuccess tests for success.
@@ -111,9 +265,37 @@ func TestProcessIntentsForSuccess(_ *testing.T) {
 	c.processIntents()
 }
 
+// TestShutdownForSuccess tests for success.
+func TestShutdownForSuccess(t *testing.T) {
+	stopChannel := make(chan struct{})
+	defer close(stopChannel)
+	c := newTestController()
+	c.Run(2, stopChannel)
+	if err := c.Shutdown(context.Background()); err != nil {
+		t.Errorf("Should have shut down: %v.", err)
+	}
+}
+
 // Tests for failure.
 
-// n/a
+// TestShutdownForFailure tests for failure.
+func TestShutdownForFailure(t *testing.T) {
+	stopChannel := make(chan struct{})
+	defer close(stopChannel)
+	c := newTestController()
+	c.SetPlanner(slowPlanner{delay: time.Second, executed: make(chan string, 1)})
+	c.intents["default/my-intent"] = common.Intent{Key: "default/my-intent", Priority: 1.0, TargetKey: "default/frontend", TargetKind: "bar", ActivelyManaged: true}
+	c.Run(1, stopChannel)
+	c.queue.Add("default/my-intent")
+	time.Sleep(TIMEOUT * time.Millisecond)
+
+	// plan execution takes longer than the deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := c.Shutdown(ctx); err == nil {
+		t.Error("Should have timed out waiting for the plan execution.")
+	}
+}
 
 // Tests for sanity.
 
@@ -213,6 +395,128 @@ func TestProcessIntentsForSanity(_ *testing.T) {
 	time.Sleep(time.Duration(c.cfg.Controller.ControllerTimeout)*time.Second + time.Second)
 }
 
+// TestShutdownForSanity tests for sanity.
+func TestShutdownForSanity(t *testing.T) {
+	stopChannel := make(chan struct{})
+	defer close(stopChannel)
+	c := newTestController()
+	executed := make(chan string, 10)
+	c.SetPlanner(slowPlanner{delay: 200 * time.Millisecond, executed: executed})
+	for _, key := range []string{"default/a", "default/b", "default/c"} {
+		c.intents[key] = common.Intent{Key: key, Priority: 1.0, TargetKey: "default/frontend", TargetKind: "bar", ActivelyManaged: true}
+		c.queue.Add(key)
+	}
+	c.Run(1, stopChannel)
+	time.Sleep(TIMEOUT * time.Millisecond)
+
+	// in-flight plan execution is waited for; pending tasks are dropped.
+	if err := c.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Should have shut down: %v.", err)
+	}
+	if len(executed) != 1 || <-executed != "default/a" {
+		t.Errorf("Expected only the plan for the first intent to be executed.")
+	}
+
+	// no more tasks are accepted - and shutting down again is fine.
+	warmupLock.Lock()
+	warmupDone = true
+	warmupLock.Unlock()
+	c.processIntents()
+	if c.queue.Len() != 0 {
+		t.Errorf("Should not have accepted tasks: %d.", c.queue.Len())
+	}
+	if err := c.Shutdown(context.Background()); err != nil {
+		t.Errorf("Should have shut down again: %v.", err)
+	}
+}
+
+// TestControllerMetricsForSanity tests for sanity.
+func TestControllerMetricsForSanity(t *testing.T) {
+	c := newTestController()
+	warmupLock.Lock()
+	warmupDone = true
+	warmupLock.Unlock()
+	c.intents["default/metrics"] = common.Intent{Key: "default/metrics", Priority: 1.0, TargetKey: "default/frontend", TargetKind: "bar"}
+
+	// intent not in the plan cache - gets queued.
+	before := scrapeMetrics(t)
+	c.processIntents()
+	metrics := scrapeMetrics(t)
+	if metricValue(metrics, "ido_task_queue_depth") != "1" {
+		t.Errorf("Expected one queued task - got: %s.", metricValue(metrics, "ido_task_queue_depth"))
+	}
+	if metricValue(metrics, `ido_plan_cache_lookups_total{result="miss"}`) == metricValue(before, `ido_plan_cache_lookups_total{result="miss"}`) {
+		t.Errorf("Expected a cache miss.")
+	}
+
+	// worker plans for the intent.
+	go c.worker(0)
+	time.Sleep(TIMEOUT * time.Millisecond)
+	metrics = scrapeMetrics(t)
+	if metricValue(metrics, `ido_planning_duration_seconds_count{intent="default/metrics"}`) != "1" {
+		t.Errorf("Expected planning duration to be observed: %s.", metrics)
+	}
+	if metricValue(metrics, "ido_task_queue_depth") != "0" {
+		t.Errorf("Expected no queued tasks - got: %s.", metricValue(metrics, "ido_task_queue_depth"))
+	}
+
+	// intent in the plan cache.
+	c.planCache.Put("default/metrics")
+	c.processIntents()
+	if metricValue(scrapeMetrics(t), `ido_plan_cache_lookups_total{result="hit"}`) == metricValue(metrics, `ido_plan_cache_lookups_total{result="hit"}`) {
+		t.Errorf("Expected a cache hit.")
+	}
+
+	// intent is removed.
+	c.UpdateIntent() <- common.Intent{Key: "default/metrics", Priority: -1.0}
+	time.Sleep(TIMEOUT * time.Millisecond)
+	if metricValue(scrapeMetrics(t), `ido_planning_duration_seconds_count{intent="default/metrics"}`) != "" {
+		t.Errorf("Expected planning duration of removed intent to be gone.")
+	}
+	c.queue.ShutDown()
+}
+
+// TestReadinessForSanity tests for sanity.
+func TestReadinessForSanity(t *testing.T) {
+	c := newTestController()
+
+	// standby replicas have no planner.
+	c.SetPlanner(nil)
+	if err := c.PlannerReady(); err == nil {
+		t.Error("Should not be ready without a planner.")
+	}
+	c.SetPlanner(unreadyPlanner{err: errors.New("not listening")})
+	if err := c.PlannerReady(); err == nil {
+		t.Error("Should not be ready while the planner is not.")
+	}
+	c.SetPlanner(unreadyPlanner{})
+	if err := c.PlannerReady(); err != nil {
+		t.Errorf("Should be ready: %v.", err)
+	}
+	c.SetPlanner(dummyPlanner{})
+	if err := c.PlannerReady(); err != nil {
+		t.Errorf("Planners not reporting readiness should be ready: %v.", err)
+	}
+
+	// warmup.
+	warmupLock.Lock()
+	tmp := warmupDone
+	warmupDone = false
+	warmupLock.Unlock()
+	if err := c.WarmedUp(); err == nil {
+		t.Error("Should not be ready before the first tick.")
+	}
+	warmupLock.Lock()
+	warmupDone = true
+	warmupLock.Unlock()
+	if err := c.WarmedUp(); err != nil {
+		t.Errorf("Should be ready after the first tick: %v.", err)
+	}
+	warmupLock.Lock()
+	warmupDone = tmp
+	warmupLock.Unlock()
+}
+
 // TestNewControllerForFailure tests for sanity.
 func TestNewControllerForFailure(t *testing.T) {
 	type args struct {
@@ -311,7 +615,7 @@ func TestNewControllerForFailure(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			controller := NewController(tt.args.cfg, nil, tt.args.clientSet, tt.args.informer)
+			controller := NewController(tt.args.cfg, nil, tt.args.clientSet, nil, tt.args.informer, nil)
 			if controller != nil {
 				if got := controller.planner; !reflect.DeepEqual(got, tt.want) {
 					t.Errorf("Planner in NewController() = %v, want %v", got, tt.want)
@@ -320,3 +624,224 @@ func TestNewControllerForFailure(t *testing.T) {
 		})
 	}
 }
+
+// TestEventStormForSanity tests for sanity.
+func TestEventStormForSanity(t *testing.T) {
+	keys := []string{"default/a", "default/b", "default/c"}
+	c := newQueueTestController(t, func(cfg *common.ControllerConfig) {
+		cfg.MinReplanInterval = 60000
+	}, keys...)
+	now := time.Now()
+	clock := testingClock.NewFakePassiveClock(now)
+	c.SetClock(clock)
+	counter := newCountingPlanner(nil)
+	c.SetPlanner(counter)
+
+	// lots of pod events - each intent is queued once.
+	events := c.UpdatePodError()
+	for i := 0; i < 500; i++ {
+		events <- common.PodError{Key: "pod_0", Start: now, End: now}
+	}
+	close(events)
+	time.Sleep(TIMEOUT * time.Millisecond)
+	if c.queue.Len() != len(keys) {
+		t.Errorf("Expected %d queued tasks - got: %d.", len(keys), c.queue.Len())
+	}
+
+	// workers plan once per intent.
+	go c.worker(0)
+	go c.worker(1)
+	defer c.queue.ShutDown()
+	time.Sleep(TIMEOUT * time.Millisecond)
+	for _, key := range keys {
+		if counter.calls(key) != 1 {
+			t.Errorf("Expected one plan for %s - got: %d.", key, counter.calls(key))
+		}
+	}
+
+	// another storm within the min replan interval - planning is delayed.
+	for i := 0; i < 500; i++ {
+		c.processIntents()
+	}
+	time.Sleep(TIMEOUT * time.Millisecond)
+	for _, key := range keys {
+		if counter.calls(key) != 1 {
+			t.Errorf("Should not have planned again for %s - got: %d.", key, counter.calls(key))
+		}
+	}
+
+	// min replan interval has passed.
+	clock.SetTime(now.Add(time.Minute))
+	c.processIntents()
+	time.Sleep(TIMEOUT * time.Millisecond)
+	for _, key := range keys {
+		if counter.calls(key) != 2 {
+			t.Errorf("Expected a second plan for %s - got: %d.", key, counter.calls(key))
+		}
+	}
+}
+
+// TestReplanBackoffForSanity tests for sanity.
+func TestReplanBackoffForSanity(t *testing.T) {
+	c := newQueueTestController(t, func(cfg *common.ControllerConfig) {
+		cfg.ReplanBackoffBase = 200
+		cfg.ReplanBackoffMax = 1000
+	}, "default/a")
+	c.profiles["avail"] = common.Profile{Key: "avail", ProfileType: common.ProfileTypeFromText("availability")}
+	intent := c.intents["default/a"]
+	intent.Objectives = map[string]float64{"avail": 2.0}
+	c.intents["default/a"] = intent
+
+	// objective not met & no plan found - backs off.
+	counter := newCountingPlanner(nil)
+	c.SetPlanner(counter)
+	go c.worker(0)
+	defer c.queue.ShutDown()
+	c.processIntents()
+	time.Sleep(50 * time.Millisecond)
+	if counter.calls("default/a") != 1 || c.queue.NumRequeues("default/a") != 1 {
+		t.Errorf("Expected one failed plan - got: %d - %d.", counter.calls("default/a"), c.queue.NumRequeues("default/a"))
+	}
+	for i := 0; i < 100; i++ {
+		c.processIntents()
+	}
+	if c.queue.Len() != 0 || counter.calls("default/a") != 1 {
+		t.Errorf("Should not queue intents backing off - got: %d.", c.queue.Len())
+	}
+
+	// retried after the backoff - with an increasing delay.
+	time.Sleep(300 * time.Millisecond)
+	if counter.calls("default/a") != 2 || c.queue.NumRequeues("default/a") != 2 {
+		t.Errorf("Expected a retry - got: %d - %d.", counter.calls("default/a"), c.queue.NumRequeues("default/a"))
+	}
+	time.Sleep(150 * time.Millisecond)
+	if counter.calls("default/a") != 2 {
+		t.Errorf("Expected backoff to increase - got: %d.", counter.calls("default/a"))
+	}
+
+	// a plan is found - backoff is reset.
+	c.SetPlanner(newCountingPlanner([]planner.Action{{Name: "scaleOut"}}))
+	time.Sleep(400 * time.Millisecond)
+	if c.queue.NumRequeues("default/a") != 0 {
+		t.Errorf("Expected backoff to be reset - got: %d.", c.queue.NumRequeues("default/a"))
+	}
+
+	// removed intents are not retried.
+	c.SetPlanner(counter)
+	c.processIntents()
+	time.Sleep(50 * time.Millisecond)
+	c.UpdateIntent() <- common.Intent{Key: "default/a", Priority: -1.0}
+	time.Sleep(400 * time.Millisecond)
+	if c.queue.NumRequeues("default/a") != 0 || c.queue.Len() != 0 {
+		t.Errorf("Should have forgotten the removed intent: %d.", c.queue.NumRequeues("default/a"))
+	}
+}
+
+// TestFullQueueForSanity tests for sanity.
+func TestFullQueueForSanity(t *testing.T) {
+	c := newQueueTestController(t, func(cfg *common.ControllerConfig) {
+		cfg.TaskChannelLength = 1
+	}, "default/a", "default/b", "default/c")
+
+	// does not block while the queue is full.
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 100; i++ {
+			c.processIntents()
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Should not block on a full queue.")
+	}
+	if c.queue.Len() != 1 {
+		t.Errorf("Expected one queued task - got: %d.", c.queue.Len())
+	}
+}
+
+// TestShadowModeForSanity tests for sanity.
+func TestShadowModeForSanity(t *testing.T) {
+	c := newQueueTestController(t, func(cfg *common.ControllerConfig) {
+		cfg.ShadowNamespaces = []string{"shadow"}
+	})
+	p := shadowPlanner{executed: make(chan string, 2), dryRuns: make(chan string, 2)}
+	tracer := shadowTracer{patches: make(chan []planner.Patch, 2)}
+	c.SetPlanner(p)
+	c.tracer = tracer
+	for _, key := range []string{"default/a", "shadow/b"} {
+		c.intents[key] = common.Intent{Key: key, Priority: 1.0, TargetKey: "default/my-deployment", TargetKind: "Deployment", ActivelyManaged: true}
+		if err := c.processIntent(0, key); err != nil {
+			t.Errorf("Planning should not have failed: %v.", err)
+		}
+	}
+	c.inFlight.Wait()
+
+	// plans for intents in shadow namespaces are not executed.
+	if len(p.executed) != 1 || <-p.executed != "default/a" {
+		t.Error("Expected only the plan for default/a to be executed.")
+	}
+	if len(p.dryRuns) != 1 || <-p.dryRuns != "shadow/b" {
+		t.Error("Expected only a dry run of the plan for shadow/b.")
+	}
+	if len(tracer.patches) != 1 || len(<-tracer.patches) != 1 {
+		t.Error("Expected the patches to be traced.")
+	}
+	if !c.planCache.IsIn("shadow/b") {
+		t.Error("Shadowed plans should be cached like executed ones.")
+	}
+
+	// cluster wide shadow mode.
+	c.cfg.Controller.ShadowMode = true
+	if err := c.processIntent(0, "default/a"); err != nil {
+		t.Errorf("Planning should not have failed: %v.", err)
+	}
+	c.inFlight.Wait()
+	if len(p.executed) != 0 || len(p.dryRuns) != 1 {
+		t.Errorf("Expected a dry run instead of an execution - got: %d & %d.", len(p.executed), len(p.dryRuns))
+	}
+}
+
+// TestPlanResultsForSanity tests for sanity.
+func TestPlanResultsForSanity(t *testing.T) {
+	c := newQueueTestController(t, func(_ *common.ControllerConfig) {})
+	tracer := resultTracer{results: make(chan []planner.Result, 3)}
+	c.tracer = tracer
+	for _, key := range []string{"default/a", "default/b"} {
+		c.intents[key] = common.Intent{Key: key, Priority: 1.0, TargetKey: "default/my-deployment", TargetKind: "Deployment", ActivelyManaged: true}
+	}
+
+	// permanent failures are traced - but not retried.
+	failed := planner.Result{Actuator: "scaleOut", Status: planner.ResultFailed, Reason: "not found"}
+	c.SetPlanner(resultPlanner{results: []planner.Result{failed}})
+	if err := c.processIntent(0, "default/a"); err != nil {
+		t.Errorf("Should not retry permanent failures: %v.", err)
+	}
+	if results := <-tracer.results; len(results) != 1 || results[0].Reason != "not found" {
+		t.Errorf("Expected the results to be traced - got: %v.", results)
+	}
+	if !c.planCache.IsIn("default/a") {
+		t.Error("Expected the plan to be cached.")
+	}
+
+	// transient failures are retried with a backoff.
+	failed.Transient = true
+	c.SetPlanner(resultPlanner{results: []planner.Result{{Actuator: "rmPod", Status: planner.ResultApplied}, failed}})
+	if err := c.processIntent(0, "default/b"); !errors.Is(err, errTransientFailure) {
+		t.Errorf("Expected a transient failure - got: %v.", err)
+	}
+	if results := <-tracer.results; len(results) != 2 {
+		t.Errorf("Expected the results to be traced - got: %v.", results)
+	}
+	if c.planCache.IsIn("default/b") {
+		t.Error("Plan should not be cached - so it can be retried.")
+	}
+	c.queue.Add("default/b")
+	c.processNextTask(0)
+	if c.queue.NumRequeues("default/b") != 1 {
+		t.Errorf("Expected the intent to be queued again - got: %d.", c.queue.NumRequeues("default/b"))
+	}
+	c.queue.ShutDown()
+	c.inFlight.Wait()
+}
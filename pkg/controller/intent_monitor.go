@@ -1,7 +1,9 @@
 package controller
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/intel/intent-driven-orchestration/pkg/api/intents/v1alpha1"
@@ -10,32 +12,61 @@ import (
 	informers "github.com/intel/intent-driven-orchestration/pkg/generated/informers/externalversions/intents/v1alpha1"
 	lister "github.com/intel/intent-driven-orchestration/pkg/generated/listers/intents/v1alpha1"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 )
 
+// defaultResyncPeriod is the default period between re-evaluating which workloads are selected by intents.
+const defaultResyncPeriod = 30 * time.Second
+
+// ParentKindIntent is the parent kind of the intents derived from an Intent using a selector.
+const ParentKindIntent = "Intent"
+
+// ChildIntentKey returns the key for the intent derived from a parent - an Intent using a selector, or an IntentGroup -
+// for one of its workloads. The kind of the parent is part of the key, so the intents derived from an Intent and an
+// IntentGroup with the same name do not collide.
+func ChildIntentKey(parentKind string, parentKey string, targetKey string) string {
+	return parentKey + "@" + parentKind + ":" + targetKey
+}
+
 // IntentMonitor is the part implementing the monitoring of Intents.
 type IntentMonitor struct {
 	intentClient clientSet.Interface
+	clientSet    kubernetes.Interface
 	intentLister lister.IntentLister
 	intentSynced cache.InformerSynced
 	queue        workqueue.TypedRateLimitingInterface[string]
 	update       chan<- common.Intent
 	syncHandler  func(key string) error // For testing purposes.
+	resyncPeriod time.Duration
+	// children holds the keys of the workloads (indexed by the key of the derived intent) selected by each intent using a selector.
+	children     map[string]map[string]string
+	childrenLock sync.Mutex
 }
 
 // NewIntentMonitor returns a new monitor instance.
-func NewIntentMonitor(intentClient clientSet.Interface, intentInformer informers.IntentInformer, ch chan<- common.Intent) *IntentMonitor {
+func NewIntentMonitor(cfg common.MonitorConfig, intentClient clientSet.Interface, clientSet kubernetes.Interface, intentInformer informers.IntentInformer, ch chan<- common.Intent) *IntentMonitor {
+	resyncPeriod := defaultResyncPeriod
+	if cfg.Intent.ResyncPeriod > 0 {
+		resyncPeriod = time.Duration(cfg.Intent.ResyncPeriod) * time.Second
+	}
 	mon := &IntentMonitor{
 		intentClient: intentClient,
+		clientSet:    clientSet,
 		intentLister: intentInformer.Lister(),
 		intentSynced: intentInformer.Informer().HasSynced,
 		queue:        workqueue.NewTypedRateLimitingQueueWithConfig[string](workqueue.DefaultTypedControllerRateLimiter[string](), workqueue.TypedRateLimitingQueueConfig[string]{Name: "Intents"}),
 		update:       ch,
+		resyncPeriod: resyncPeriod,
+		children:     make(map[string]map[string]string),
 	}
 	mon.syncHandler = mon.processIntent
 
@@ -46,6 +77,10 @@ func NewIntentMonitor(intentClient clientSet.Interface, intentInformer informers
 			if oldVersion.(*v1alpha1.Intent).ResourceVersion == newVersion.(*v1alpha1.Intent).ResourceVersion {
 				return
 			}
+			if equality.Semantic.DeepEqual(oldVersion.(*v1alpha1.Intent).Spec, newVersion.(*v1alpha1.Intent).Spec) {
+				// status only updates (e.g. by the controller itself) do not need to be processed.
+				return
+			}
 			mon.enqueueItem(newVersion)
 		},
 		DeleteFunc: func(obj interface{}) {
@@ -57,7 +92,18 @@ func NewIntentMonitor(intentClient clientSet.Interface, intentInformer informers
 				return
 			}
 			klog.Infof("Will remove intent: '%s'.", key)
-			mon.update <- common.Intent{Key: key, Priority: -1.0}
+			mon.childrenLock.Lock()
+			children, found := mon.children[key]
+			delete(mon.children, key)
+			mon.childrenLock.Unlock()
+			if !found {
+				mon.update <- common.Intent{Key: key, Priority: -1.0}
+				return
+			}
+			for childKey := range children {
+				// no need to set the parent key; the parent's status is gone anyhow.
+				mon.update <- common.Intent{Key: childKey, Priority: -1.0}
+			}
 		},
 	})
 
@@ -88,10 +134,25 @@ func (mon *IntentMonitor) Run(nWorkers int, stopper <-chan struct{}) {
 	for i := 0; i < nWorkers; i++ {
 		go wait.Until(mon.runWorker, time.Second, stopper)
 	}
+	go wait.Until(mon.resync, mon.resyncPeriod, stopper)
 	klog.V(1).Infof("Started %d worker(s).", nWorkers)
 	<-stopper
 }
 
+// resync enqueues all intents using a selector, so workloads which appeared or disappeared are picked up.
+func (mon *IntentMonitor) resync() {
+	intents, err := mon.intentLister.List(labels.Everything())
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("unable to list intents: %v", err))
+		return
+	}
+	for _, intent := range intents {
+		if intent.Spec.TargetRef.Selector != nil {
+			mon.enqueueItem(intent)
+		}
+	}
+}
+
 // runWorker will run forever and process items of a queue.
 func (mon *IntentMonitor) runWorker() {
 	for mon.processNextWorkItem() {
@@ -141,6 +202,9 @@ func (mon *IntentMonitor) processIntent(key string) error {
 	// easier to work with a map in the planner later on.
 	objectivesMap := make(map[string]float64)
 	tolerationsMap := make(map[string]float64)
+	weightsMap := make(map[string]float64)
+	normalizationsMap := make(map[string]common.Normalization)
+	schedulesMap := make(map[string][]common.ScheduleWindow)
 	for _, target := range intent.Spec.Objectives {
 		if _, ok := objectivesMap[target.MeasuredBy]; ok {
 			// TODO: set status to faulty.
@@ -149,18 +213,151 @@ func (mon *IntentMonitor) processIntent(key string) error {
 		objectivesMap[target.MeasuredBy] = target.Value
 		// b/c a default value is set we are sure that both maps are equal length.
 		tolerationsMap[target.MeasuredBy] = target.Tolerance
+		if target.Weight > 0 {
+			weightsMap[target.MeasuredBy] = target.Weight
+		}
+		normalizationsMap[target.MeasuredBy] = common.NormalizationFromText(target.Normalization)
+		if windows := toScheduleWindows(key, target); len(windows) > 0 {
+			schedulesMap[target.MeasuredBy] = windows
+		}
 	}
 
-	updateObject := common.Intent{
-		Key:             key,
-		Priority:        intent.Spec.Priority,
-		TargetKey:       intent.Spec.TargetRef.Name,
-		TargetKind:      intent.Spec.TargetRef.Kind,
-		ActivelyManaged: intent.Spec.ActivelyManaged,
-		Objectives:      objectivesMap,
-		Tolerations:     tolerationsMap,
+	var budget *common.Budget
+	if intent.Spec.Budget != nil {
+		budget = &common.Budget{
+			MinReplicas:     int(intent.Spec.Budget.MinReplicas),
+			MaxReplicas:     int(intent.Spec.Budget.MaxReplicas),
+			MaxContainerCPU: intent.Spec.Budget.MaxContainerCPU,
+			MaxTotalCPU:     intent.Spec.Budget.MaxTotalCPU,
+		}
+	}
+
+	if intent.Spec.TargetRef.Selector == nil {
+		mon.setChildren(key, nil)
+		mon.update <- common.Intent{
+			Key:              key,
+			UID:              string(intent.UID),
+			Priority:         intent.Spec.Priority,
+			TargetKey:        intent.Spec.TargetRef.Name,
+			TargetKind:       intent.Spec.TargetRef.Kind,
+			ActivelyManaged:  intent.Spec.ActivelyManaged,
+			Objectives:       objectivesMap,
+			Tolerations:      tolerationsMap,
+			Weights:          weightsMap,
+			Normalizations:   normalizationsMap,
+			Schedules:        schedulesMap,
+			Budget:           budget,
+			AllowedActuators: intent.Spec.AllowedActuators,
+			DeniedActuators:  intent.Spec.DeniedActuators,
+		}
+		return nil
+	}
+
+	// one intent per selected workload.
+	targets, err := mon.selectTargets(intent)
+	if err != nil {
+		return err
+	}
+	children := make(map[string]string, len(targets))
+	for _, target := range targets {
+		children[ChildIntentKey(ParentKindIntent, key, target)] = target
+	}
+	mon.setChildren(key, children)
+	for childKey, target := range children {
+		mon.update <- common.Intent{
+			Key:              childKey,
+			ParentKey:        key,
+			ParentKind:       ParentKindIntent,
+			UID:              string(intent.UID),
+			Priority:         intent.Spec.Priority,
+			TargetKey:        target,
+			TargetKind:       intent.Spec.TargetRef.Kind,
+			ActivelyManaged:  intent.Spec.ActivelyManaged,
+			Objectives:       objectivesMap,
+			Tolerations:      tolerationsMap,
+			Weights:          weightsMap,
+			Normalizations:   normalizationsMap,
+			Schedules:        schedulesMap,
+			Budget:           budget,
+			AllowedActuators: intent.Spec.AllowedActuators,
+			DeniedActuators:  intent.Spec.DeniedActuators,
+		}
 	}
-	mon.update <- updateObject
 
 	return nil
 }
+
+// toScheduleWindows parses the schedule windows of an objective; invalid windows are ignored.
+func toScheduleWindows(key string, target v1alpha1.TargetObjective) []common.ScheduleWindow {
+	var windows []common.ScheduleWindow
+	for _, item := range target.Schedule {
+		window, err := common.ParseScheduleWindow(item.Name, item.Days, item.Start, item.End, item.TimeZone, item.Value)
+		if err != nil {
+			klog.Warningf("Ignoring schedule window of objective '%s' in intent '%s': %v.", target.Name, key, err)
+			continue
+		}
+		windows = append(windows, window)
+	}
+	return windows
+}
+
+// setChildren stores the workloads selected by an intent, and removes the intents for those no longer selected.
+func (mon *IntentMonitor) setChildren(key string, children map[string]string) {
+	mon.childrenLock.Lock()
+	previous, found := mon.children[key]
+	if children == nil {
+		delete(mon.children, key)
+	} else {
+		mon.children[key] = children
+	}
+	mon.childrenLock.Unlock()
+
+	if found && children == nil {
+		klog.Infof("Intent '%s' no longer uses a selector.", key)
+	} else if !found && children != nil {
+		// intent might have referenced a single workload before.
+		mon.update <- common.Intent{Key: key, Priority: -1.0}
+	}
+	for childKey, target := range previous {
+		if _, ok := children[childKey]; !ok {
+			klog.Infof("Workload '%s' is no longer selected by intent '%s'.", target, key)
+			mon.update <- common.Intent{Key: childKey, ParentKey: key, ParentKind: ParentKindIntent, TargetKey: target, Priority: -1.0}
+		}
+	}
+}
+
+// selectTargets returns the keys of all workloads selected by an intent.
+func (mon *IntentMonitor) selectTargets(intent *v1alpha1.Intent) ([]string, error) {
+	selector, err := metaV1.LabelSelectorAsSelector(intent.Spec.TargetRef.Selector)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("invalid selector for intent '%s/%s': %v", intent.Namespace, intent.Name, err))
+		//lint:ignore nilerr n.a.
+		return nil, nil
+	}
+	namespaces := []string{intent.Namespace}
+	if intent.Spec.TargetRef.NamespaceSelector != nil {
+		nsSelector, err := metaV1.LabelSelectorAsSelector(intent.Spec.TargetRef.NamespaceSelector)
+		if err != nil {
+			runtime.HandleError(fmt.Errorf("invalid namespace selector for intent '%s/%s': %v", intent.Namespace, intent.Name, err))
+			//lint:ignore nilerr n.a.
+			return nil, nil
+		}
+		res, err := mon.clientSet.CoreV1().Namespaces().List(context.TODO(), metaV1.ListOptions{LabelSelector: nsSelector.String()})
+		if err != nil {
+			return nil, err
+		}
+		namespaces = nil
+		for _, item := range res.Items {
+			namespaces = append(namespaces, item.Name)
+		}
+	}
+	var targets []string
+	for _, namespace := range namespaces {
+		res, err := ListTargets(mon.clientSet, intent.Spec.TargetRef.Kind, namespace, selector)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, res...)
+	}
+	return targets, nil
+}
@@ -3,6 +3,7 @@ package controller
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"sync"
 	"testing"
 	"time"
@@ -12,9 +13,12 @@ import (
 	"github.com/intel/intent-driven-orchestration/pkg/generated/clientset/versioned/fake"
 	informers "github.com/intel/intent-driven-orchestration/pkg/generated/informers/externalversions"
 
+	appsV1 "k8s.io/api/apps/v1"
+	coreV1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
+	k8sFake "k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
 	"k8s.io/klog/v2"
 )
@@ -26,10 +30,13 @@ var intentUpdateWaitGroup sync.WaitGroup
 type intentFixture struct {
 	test            *testing.T
 	client          *fake.Clientset
+	k8sClient       *k8sFake.Clientset
 	intentLister    []*v1alpha1.Intent
 	objects         []runtime.Object
+	k8sObjects      []runtime.Object
 	expectedUpdates []common.Intent
 	actualUpdates   []common.Intent
+	informer        informers.SharedInformerFactory
 }
 
 // newIntentFixture initializes the test.
@@ -56,12 +63,13 @@ func (f *intentFixture) acceptUpdates() chan<- common.Intent {
 // newMonitor creates a new monitor tailored for testing.
 func (f *intentFixture) newMonitor(done chan struct{}) (*IntentMonitor, *watch.FakeWatcher) {
 	f.client = fake.NewSimpleClientset(f.objects...)
+	f.k8sClient = k8sFake.NewSimpleClientset(f.k8sObjects...)
 	faker := watch.NewFake()
 	f.client.PrependWatchReactor("intents", core.DefaultWatchReactor(faker, nil))
 
 	// new intent monitor
 	informer := informers.NewSharedInformerFactory(f.client, func() time.Duration { return 0 }())
-	mon := NewIntentMonitor(f.client, informer.Ido().V1alpha1().Intents(), f.acceptUpdates())
+	mon := NewIntentMonitor(common.MonitorConfig{}, f.client, f.k8sClient, informer.Ido().V1alpha1().Intents(), f.acceptUpdates())
 	mon.intentSynced = func() bool { return true }
 
 	for _, f := range f.intentLister {
@@ -72,9 +80,18 @@ func (f *intentFixture) newMonitor(done chan struct{}) (*IntentMonitor, *watch.F
 	}
 
 	informer.Start(done)
+	f.informer = informer
 	return mon, faker
 }
 
+// addIntent adds or updates an intent in the informer's cache.
+func (f *intentFixture) addIntent(intent *v1alpha1.Intent) {
+	err := f.informer.Ido().V1alpha1().Intents().Informer().GetIndexer().Update(intent)
+	if err != nil {
+		f.test.Fatal(err)
+	}
+}
+
 // testSyncHandler processes and objects and checks if right actions have been performed.
 func (f *intentFixture) testSyncHandler(key string) {
 	done := make(chan struct{})
@@ -132,6 +149,34 @@ func newIntent(name string, p99 float64) *v1alpha1.Intent {
 	}
 }
 
+// newSelectorIntent creates an intent selecting workloads by label for testing purposes.
+func newSelectorIntent(name string, tier string) *v1alpha1.Intent {
+	intent := newIntent(name, 100)
+	intent.Spec.TargetRef.Name = ""
+	intent.Spec.TargetRef.Selector = &metaV1.LabelSelector{MatchLabels: map[string]string{"tier": tier}}
+	return intent
+}
+
+// newTierDeployment creates a deployment with a tier label for testing purposes.
+func newTierDeployment(namespace string, name string, tier string) *appsV1.Deployment {
+	return &appsV1.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"tier": tier}}}
+}
+
+// syncAndCollect runs the sync handler and returns the updates send - indexed by their key.
+func (f *intentFixture) syncAndCollect(mon *IntentMonitor, key string) map[string]common.Intent {
+	if err := mon.syncHandler(key); err != nil {
+		f.test.Errorf("error running test: %s", err)
+	}
+	time.Sleep(timeout * time.Millisecond)
+	intentUpdateWaitGroup.Wait()
+	res := make(map[string]common.Intent)
+	for _, item := range f.actualUpdates {
+		res[item.Key] = item
+	}
+	f.actualUpdates = make([]common.Intent, 0)
+	return res
+}
+
 // Tests for success.
 
 // TestRunIntentMonitorForSuccess tests for success.
@@ -161,6 +206,17 @@ func TestProcessIntentForSuccess(t *testing.T) {
 	f.testSyncHandler("default/bar")
 }
 
+// TestProcessSelectorIntentForSuccess tests for success.
+func TestProcessSelectorIntentForSuccess(t *testing.T) {
+	f := newIntentFixture(t)
+	intent := newSelectorIntent("bar", "web")
+	f.objects = append(f.objects, intent)
+	f.intentLister = append(f.intentLister, intent)
+	f.k8sObjects = append(f.k8sObjects, newTierDeployment("default", "a", "web"), newTierDeployment("default", "b", "db"))
+	f.expectedUpdates = []common.Intent{{Key: "default/bar", Priority: -1}, {Key: "default/bar@Intent:default/a"}}
+	f.testSyncHandler("default/bar")
+}
+
 // Tests for failure.
 
 // TestRunIntentMonitorForFailure tests for failure.
@@ -191,6 +247,15 @@ func TestProcessIntenForFailure(t *testing.T) {
 
 	// invalid key - no updates send.
 	f.testSyncHandler("default/foo/bar")
+
+	// invalid selector - no workloads selected.
+	intent := newSelectorIntent("foo", "web")
+	intent.Spec.TargetRef.Selector.MatchLabels["tier"] = "not a valid/label!"
+	f.objects = append(f.objects, intent)
+	f.intentLister = append(f.intentLister, intent)
+	f.k8sObjects = append(f.k8sObjects, newTierDeployment("default", "a", "web"))
+	f.expectedUpdates = []common.Intent{{Key: "default/foo", Priority: -1}}
+	f.testSyncHandler("default/foo")
 }
 
 // Tests for sanity.
@@ -245,3 +310,101 @@ func TestProcessIntentForSanity(t *testing.T) {
 	f.expectedUpdates = []common.Intent{{Key: "default/foo"}}
 	f.testSyncHandler("default/foo")
 }
+
+// TestProcessSelectorIntentForSanity tests for sanity.
+func TestProcessSelectorIntentForSanity(t *testing.T) {
+	f := newIntentFixture(t)
+	intent := newSelectorIntent("bar", "web")
+	f.objects = append(f.objects, intent)
+	f.intentLister = append(f.intentLister, intent)
+	f.k8sObjects = append(f.k8sObjects,
+		&coreV1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "default"}},
+		&coreV1.Namespace{ObjectMeta: metaV1.ObjectMeta{Name: "other", Labels: map[string]string{"team": "web"}}},
+		newTierDeployment("default", "a", "web"),
+		newTierDeployment("default", "b", "web"),
+		newTierDeployment("default", "c", "db"),
+		newTierDeployment("other", "d", "web"))
+
+	done := make(chan struct{})
+	defer close(done)
+	mon, _ := f.newMonitor(done)
+	// one intent per workload in the intent's namespace.
+	updates := f.syncAndCollect(mon, "default/bar")
+	for _, target := range []string{"default/a", "default/b"} {
+		item, ok := updates[ChildIntentKey(ParentKindIntent, "default/bar", target)]
+		if !ok || item.ParentKey != "default/bar" || item.ParentKind != ParentKindIntent || item.TargetKey != target || item.TargetKind != "Deployment" || item.Objectives["p99latency"] != 100 {
+			t.Errorf("Expected an intent for workload %s - got: %v.", target, updates)
+		}
+	}
+	if len(updates) != 3 || updates["default/bar"].Priority != -1 {
+		t.Errorf("Expected 2 workload intents & removal of the parent - got: %v.", updates)
+	}
+	// members of a group with the same name get different keys.
+	if ChildIntentKey(ParentKindIntentGroup, "default/bar", "default/a") == ChildIntentKey(ParentKindIntent, "default/bar", "default/a") {
+		t.Error("Expected the keys of intents derived from an Intent & an IntentGroup to differ.")
+	}
+
+	// workload disappears.
+	_ = f.k8sClient.AppsV1().Deployments("default").Delete(t.Context(), "b", metaV1.DeleteOptions{})
+	updates = f.syncAndCollect(mon, "default/bar")
+	removed := updates["default/bar@Intent:default/b"]
+	if len(updates) != 2 || removed.Priority != -1 || removed.ParentKey != "default/bar" || removed.TargetKey != "default/b" {
+		t.Errorf("Expected removal of workload b - got: %v.", updates)
+	}
+
+	// workloads across namespaces.
+	other := newSelectorIntent("foo", "web")
+	other.Spec.TargetRef.NamespaceSelector = &metaV1.LabelSelector{MatchLabels: map[string]string{"team": "web"}}
+	f.addIntent(other)
+	updates = f.syncAndCollect(mon, "default/foo")
+	if _, ok := updates["default/foo@Intent:other/d"]; !ok || len(updates) != 2 || updates["default/foo"].Priority != -1 {
+		t.Errorf("Expected workload d to be selected - got: %v.", updates)
+	}
+
+	// an empty namespace selector matches all namespaces.
+	other.Spec.TargetRef.NamespaceSelector = &metaV1.LabelSelector{}
+	f.addIntent(other)
+	updates = f.syncAndCollect(mon, "default/foo")
+	if len(updates) != 2 || updates["default/foo@Intent:default/a"].Priority < 0 || updates["default/foo@Intent:other/d"].Priority < 0 {
+		t.Errorf("Expected workloads a & d to be selected - got: %v.", updates)
+	}
+
+	// selector gets removed.
+	other = newIntent("foo", 100)
+	f.addIntent(other)
+	updates = f.syncAndCollect(mon, "default/foo")
+	if len(updates) != 3 || updates["default/foo"].Priority < 0 || updates["default/foo@Intent:default/a"].Priority != -1 || updates["default/foo@Intent:other/d"].Priority != -1 {
+		t.Errorf("Expected removal of all workload intents - got: %v.", updates)
+	}
+}
+
+// TestProcessIntentBudgetForSanity tests for sanity.
+func TestProcessIntentBudgetForSanity(t *testing.T) {
+	f := newIntentFixture(t)
+	intent := newIntent("bar", 100)
+	intent.Spec.Budget = &v1alpha1.Budget{MinReplicas: 1, MaxReplicas: 4, MaxContainerCPU: 2000, MaxTotalCPU: 4000}
+	intent.Spec.AllowedActuators = []string{"scaling"}
+	intent.Spec.DeniedActuators = []string{"rmpod"}
+	f.objects = append(f.objects, intent)
+	f.intentLister = append(f.intentLister, intent)
+
+	done := make(chan struct{})
+	defer close(done)
+	mon, _ := f.newMonitor(done)
+	updates := f.syncAndCollect(mon, "default/bar")
+	expected := common.Budget{MinReplicas: 1, MaxReplicas: 4, MaxContainerCPU: 2000, MaxTotalCPU: 4000}
+	if item := updates["default/bar"]; item.Budget == nil || *item.Budget != expected {
+		t.Errorf("Expected budget to be passed on - got: %v.", item.Budget)
+	}
+	if item := updates["default/bar"]; !reflect.DeepEqual(item.AllowedActuators, []string{"scaling"}) || !reflect.DeepEqual(item.DeniedActuators, []string{"rmpod"}) {
+		t.Errorf("Expected actuator filters to be passed on - got: %v - %v.", item.AllowedActuators, item.DeniedActuators)
+	}
+
+	// no budget.
+	intent.Spec.Budget = nil
+	f.addIntent(intent)
+	updates = f.syncAndCollect(mon, "default/bar")
+	if item := updates["default/bar"]; item.Budget != nil {
+		t.Errorf("Expected no budget - got: %v.", item.Budget)
+	}
+}
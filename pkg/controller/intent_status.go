@@ -0,0 +1,453 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/intel/intent-driven-orchestration/pkg/api/intents/v1alpha1"
+	"github.com/intel/intent-driven-orchestration/pkg/common"
+	"github.com/intel/intent-driven-orchestration/pkg/planner"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// isCompliant checks if an observed value meets the target value - taking the tolerance into account.
+func isCompliant(value float64, target float64, tolerance float64, minimize bool) bool {
+	if minimize {
+		return value <= target*(1+tolerance)
+	}
+	return value >= target*(1-tolerance)
+}
+
+// toPlannedActions converts the actions of a plan into their representation in the status object.
+func toPlannedActions(plan []planner.Action) []v1alpha1.PlannedAction {
+	var res []v1alpha1.PlannedAction
+	for _, item := range plan {
+		action := v1alpha1.PlannedAction{Name: item.Name}
+		switch props := item.Properties.(type) {
+		case map[string]int64:
+			action.Properties = make(map[string]string, len(props))
+			for k, v := range props {
+				action.Properties[k] = strconv.FormatInt(v, 10)
+			}
+		case map[string]string:
+			action.Properties = make(map[string]string, len(props))
+			for k, v := range props {
+				action.Properties[k] = v
+			}
+		case nil:
+			// no properties to convert.
+		default:
+			action.Properties = map[string]string{"value": fmt.Sprintf("%v", props)}
+		}
+		res = append(res, action)
+	}
+	return res
+}
+
+// toCooldownStatus converts the cooldowns of an intent into their representation in the status object - sorted by
+// actuator.
+func toCooldownStatus(cooldowns map[string]time.Time) []v1alpha1.CooldownStatus {
+	var res []v1alpha1.CooldownStatus
+	for name, until := range cooldowns {
+		item := v1alpha1.CooldownStatus{Actuator: name}
+		if !until.IsZero() {
+			item.Until = &metaV1.Time{Time: until}
+		}
+		res = append(res, item)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Actuator < res[j].Actuator
+	})
+	return res
+}
+
+// toShadowPatches converts the patches a plan would have applied into their representation in the status object.
+func toShadowPatches(patches []planner.Patch) []v1alpha1.ShadowPatch {
+	var res []v1alpha1.ShadowPatch
+	for _, item := range patches {
+		res = append(res, v1alpha1.ShadowPatch{
+			Actuator:    item.Actuator,
+			Kind:        item.Kind,
+			Namespace:   item.Namespace,
+			Name:        item.Name,
+			Subresource: item.Subresource,
+			Type:        item.Type,
+			Patch:       item.Data,
+			Diff:        item.Diff,
+		})
+	}
+	return res
+}
+
+// setShadowPlan records the patches the last plan would have applied in the status of an intent handled in shadow mode.
+func setShadowPlan(intent *v1alpha1.Intent, patches []planner.Patch, planning bool) {
+	intent.Status.ShadowPlan = toShadowPatches(patches)
+	if planning {
+		meta.SetStatusCondition(&intent.Status.Conditions, metaV1.Condition{Type: v1alpha1.ConditionPlanning, Status: metaV1.ConditionTrue, ObservedGeneration: intent.Generation, Reason: "PlanShadowed", Message: fmt.Sprintf("Plan recorded in shadow mode with %d change(s).", len(patches))})
+	}
+}
+
+// setRolledBack marks the conditions as degraded as the last plan was rolled back.
+func setRolledBack(conditions *[]metaV1.Condition, generation int64, regressed []string) {
+	meta.SetStatusCondition(conditions, metaV1.Condition{Type: v1alpha1.ConditionDegraded, Status: metaV1.ConditionTrue, ObservedGeneration: generation, Reason: "PlanRolledBack", Message: "Last plan was rolled back - objective(s) worse than predicted: " + strings.Join(regressed, ", ") + "."})
+}
+
+// setArbitrationLost records in the conditions why the plan was trimmed - or rejected, if no actions are left.
+func setArbitrationLost(conditions *[]metaV1.Condition, generation int64, planning bool, reason string) {
+	status := metaV1.ConditionFalse
+	if planning {
+		status = metaV1.ConditionTrue
+	}
+	meta.SetStatusCondition(conditions, metaV1.Condition{Type: v1alpha1.ConditionPlanning, Status: status, ObservedGeneration: generation, Reason: "ArbitrationLost", Message: "Plan did not fit the capacity of the nodes: " + reason + "."})
+}
+
+// rolledBack checks if the conditions state that the last plan was rolled back.
+func rolledBack(conditions []metaV1.Condition) bool {
+	condition := meta.FindStatusCondition(conditions, v1alpha1.ConditionDegraded)
+	return condition != nil && condition.Status == metaV1.ConditionTrue && condition.Reason == "PlanRolledBack"
+}
+
+// setIntentStatus updates the status object of an intent based on the observed current state, and the plan the planner came up with.
+func setIntentStatus(intent *v1alpha1.Intent, current common.State, desired common.State, plan []planner.Action, profiles map[string]common.Profile, now time.Time) {
+	status := &intent.Status
+
+	// the per objective status.
+	var objectives []v1alpha1.ObjectiveStatus
+	var missing, failed, violated []string
+	for _, objective := range intent.Spec.Objectives {
+		item := v1alpha1.ObjectiveStatus{
+			Name:         objective.Name,
+			MeasuredBy:   objective.MeasuredBy,
+			Target:       objective.Value,
+			Tolerance:    objective.Tolerance,
+			ActiveWindow: desired.Intent.ActiveWindows[objective.MeasuredBy],
+		}
+		// the target value might be taken from a schedule window.
+		if target, ok := desired.Intent.Objectives[objective.MeasuredBy]; ok {
+			item.Target = target
+		}
+		profile, found := profiles[objective.MeasuredBy]
+		value, observed := current.Intent.Objectives[objective.MeasuredBy]
+		if !found {
+			missing = append(missing, objective.MeasuredBy)
+		} else if !observed || value < 0 {
+			failed = append(failed, objective.Name)
+		} else {
+			item.Value = value
+			item.Compliant = isCompliant(value, item.Target, objective.Tolerance, profile.Minimize)
+		}
+		if !item.Compliant {
+			violated = append(violated, objective.Name)
+		}
+		objectives = append(objectives, item)
+	}
+	status.Objectives = objectives
+
+	// the last plan.
+	if len(plan) > 0 {
+		status.LastPlan = toPlannedActions(plan)
+		status.LastPlanTime = &metaV1.Time{Time: now}
+		status.ShadowPlan = nil
+	}
+	status.Cooldowns = toCooldownStatus(desired.Intent.Cooldowns)
+
+	// and all conditions.
+	generation := intent.Generation
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		meta.SetStatusCondition(&status.Conditions, metaV1.Condition{Type: v1alpha1.ConditionProfileMissing, Status: metaV1.ConditionTrue, ObservedGeneration: generation, Reason: "ProfileNotFound", Message: "Unknown profile(s): " + strings.Join(missing, ", ") + "."})
+	} else {
+		meta.SetStatusCondition(&status.Conditions, metaV1.Condition{Type: v1alpha1.ConditionProfileMissing, Status: metaV1.ConditionFalse, ObservedGeneration: generation, Reason: "ProfilesResolved", Message: "All profiles are known."})
+	}
+	if len(violated) > 0 {
+		meta.SetStatusCondition(&status.Conditions, metaV1.Condition{Type: v1alpha1.ConditionCompliant, Status: metaV1.ConditionFalse, ObservedGeneration: generation, Reason: "ObjectivesNotMet", Message: "Objective(s) not met: " + strings.Join(violated, ", ") + "."})
+	} else {
+		meta.SetStatusCondition(&status.Conditions, metaV1.Condition{Type: v1alpha1.ConditionCompliant, Status: metaV1.ConditionTrue, ObservedGeneration: generation, Reason: "ObjectivesMet", Message: "All objectives are met."})
+	}
+	if len(plan) > 0 {
+		reason := "PlanCreated"
+		if desired.Intent.ActivelyManaged {
+			reason = "PlanExecuted"
+		}
+		meta.SetStatusCondition(&status.Conditions, metaV1.Condition{Type: v1alpha1.ConditionPlanning, Status: metaV1.ConditionTrue, ObservedGeneration: generation, Reason: reason, Message: fmt.Sprintf("Plan with %d action(s).", len(plan))})
+	} else {
+		meta.SetStatusCondition(&status.Conditions, metaV1.Condition{Type: v1alpha1.ConditionPlanning, Status: metaV1.ConditionFalse, ObservedGeneration: generation, Reason: "EmptyPlan", Message: "No actions planned."})
+	}
+	if current.CurrentPods == nil {
+		meta.SetStatusCondition(&status.Conditions, metaV1.Condition{Type: v1alpha1.ConditionDegraded, Status: metaV1.ConditionTrue, ObservedGeneration: generation, Reason: "TargetNotFound", Message: "Target workload " + intent.Spec.TargetRef.Name + " could not be found."})
+	} else if len(failed) > 0 {
+		meta.SetStatusCondition(&status.Conditions, metaV1.Condition{Type: v1alpha1.ConditionDegraded, Status: metaV1.ConditionTrue, ObservedGeneration: generation, Reason: "MeasurementFailed", Message: "Could not observe objective(s): " + strings.Join(failed, ", ") + "."})
+	} else if len(violated) > 0 && len(plan) == 0 {
+		meta.SetStatusCondition(&status.Conditions, metaV1.Condition{Type: v1alpha1.ConditionDegraded, Status: metaV1.ConditionTrue, ObservedGeneration: generation, Reason: "NoPlanFound", Message: "Objectives are not met, but the planner could not find a plan."})
+	} else if len(plan) > 0 || !rolledBack(status.Conditions) {
+		// intents stay degraded after a rollback until the planner comes up with a new plan.
+		meta.SetStatusCondition(&status.Conditions, metaV1.Condition{Type: v1alpha1.ConditionDegraded, Status: metaV1.ConditionFalse, ObservedGeneration: generation, Reason: "AsExpected", Message: "Intent is handled as expected."})
+	}
+}
+
+// setWorkloadStatus updates the status of a single workload resource selected by an intent, and aggregates the status of all selected workloads into the overall status of the intent.
+func setWorkloadStatus(intent *v1alpha1.Intent, targetKey string, current common.State, desired common.State, plan []planner.Action, profiles map[string]common.Profile, now time.Time) {
+	index := -1
+	for i, item := range intent.Status.Workloads {
+		if item.Name == targetKey {
+			index = i
+			break
+		}
+	}
+
+	// reuse the logic for a single workload on a scratch object.
+	scratch := &v1alpha1.Intent{ObjectMeta: intent.ObjectMeta, Spec: *intent.Spec.DeepCopy()}
+	scratch.Spec.TargetRef.Name = targetKey
+	if index >= 0 {
+		previous := intent.Status.Workloads[index]
+		scratch.Status = v1alpha1.IntentStatus{LastPlan: previous.LastPlan, LastPlanTime: previous.LastPlanTime, Conditions: previous.Conditions}
+	}
+	setIntentStatus(scratch, current, desired, plan, profiles, now)
+	if len(plan) > 0 {
+		intent.Status.ShadowPlan = nil
+	}
+	workload := v1alpha1.WorkloadStatus{
+		Name:         targetKey,
+		Objectives:   scratch.Status.Objectives,
+		LastPlan:     scratch.Status.LastPlan,
+		LastPlanTime: scratch.Status.LastPlanTime,
+		Cooldowns:    scratch.Status.Cooldowns,
+		Conditions:   scratch.Status.Conditions,
+	}
+	if index >= 0 {
+		intent.Status.Workloads[index] = workload
+	} else {
+		intent.Status.Workloads = append(intent.Status.Workloads, workload)
+		sort.Slice(intent.Status.Workloads, func(i, j int) bool {
+			return intent.Status.Workloads[i].Name < intent.Status.Workloads[j].Name
+		})
+	}
+	aggregateWorkloadStatus(intent, profiles)
+}
+
+// removeWorkloadStatus removes the status of a workload resource no longer selected by an intent.
+func removeWorkloadStatus(intent *v1alpha1.Intent, targetKey string, profiles map[string]common.Profile) {
+	var workloads []v1alpha1.WorkloadStatus
+	for _, item := range intent.Status.Workloads {
+		if item.Name != targetKey {
+			workloads = append(workloads, item)
+		}
+	}
+	intent.Status.Workloads = workloads
+	aggregateWorkloadStatus(intent, profiles)
+}
+
+// aggregateWorkloadStatus sets the overall status of an intent based on the status of all selected workloads: objectives report the worst observed value, and conditions hold if they hold for any of the workloads - besides being compliant, which requires all workloads to be compliant.
+func aggregateWorkloadStatus(intent *v1alpha1.Intent, profiles map[string]common.Profile) {
+	status := &intent.Status
+	generation := intent.Generation
+
+	// the per objective status.
+	var objectives []v1alpha1.ObjectiveStatus
+	for _, objective := range intent.Spec.Objectives {
+		item := v1alpha1.ObjectiveStatus{
+			Name:       objective.Name,
+			MeasuredBy: objective.MeasuredBy,
+			Target:     objective.Value,
+			Tolerance:  objective.Tolerance,
+			Compliant:  len(status.Workloads) > 0,
+		}
+		profile, found := profiles[objective.MeasuredBy]
+		seen := false
+		for _, workload := range status.Workloads {
+			for _, other := range workload.Objectives {
+				if other.MeasuredBy != objective.MeasuredBy {
+					continue
+				}
+				if !seen || (profile.Minimize && other.Value > item.Value) || (!profile.Minimize && other.Value < item.Value) {
+					item.Value = other.Value
+				}
+				if !seen {
+					// all workloads share the same schedule windows.
+					item.Target = other.Target
+					item.ActiveWindow = other.ActiveWindow
+				}
+				seen = true
+				item.Compliant = item.Compliant && other.Compliant
+			}
+		}
+		item.Compliant = item.Compliant && seen && found
+		objectives = append(objectives, item)
+	}
+	status.Objectives = objectives
+
+	// the most recent plan.
+	status.LastPlan = nil
+	status.LastPlanTime = nil
+	for _, workload := range status.Workloads {
+		if workload.LastPlanTime != nil && (status.LastPlanTime == nil || status.LastPlanTime.Before(workload.LastPlanTime)) {
+			status.LastPlan = workload.LastPlan
+			status.LastPlanTime = workload.LastPlanTime
+		}
+	}
+
+	// and all conditions.
+	if len(status.Workloads) == 0 {
+		for _, condition := range []string{v1alpha1.ConditionCompliant, v1alpha1.ConditionPlanning, v1alpha1.ConditionProfileMissing} {
+			meta.SetStatusCondition(&status.Conditions, metaV1.Condition{Type: condition, Status: metaV1.ConditionUnknown, ObservedGeneration: generation, Reason: "NoWorkloads", Message: "No workload resources match the selector."})
+		}
+		meta.SetStatusCondition(&status.Conditions, metaV1.Condition{Type: v1alpha1.ConditionDegraded, Status: metaV1.ConditionTrue, ObservedGeneration: generation, Reason: "NoWorkloads", Message: "No workload resources match the selector."})
+		return
+	}
+	aggregateConditions(&status.Conditions, status.Workloads, generation)
+	var violated []string
+	for _, workload := range status.Workloads {
+		if !meta.IsStatusConditionTrue(workload.Conditions, v1alpha1.ConditionCompliant) {
+			violated = append(violated, workload.Name)
+		}
+	}
+	if len(violated) > 0 {
+		meta.SetStatusCondition(&status.Conditions, metaV1.Condition{Type: v1alpha1.ConditionCompliant, Status: metaV1.ConditionFalse, ObservedGeneration: generation, Reason: "ObjectivesNotMet", Message: "Objective(s) not met for workload(s): " + strings.Join(violated, ", ") + "."})
+	} else {
+		meta.SetStatusCondition(&status.Conditions, metaV1.Condition{Type: v1alpha1.ConditionCompliant, Status: metaV1.ConditionTrue, ObservedGeneration: generation, Reason: "ObjectivesMet", Message: "All objectives are met for all workloads."})
+	}
+}
+
+// aggregateConditions sets the planning, degraded and profile missing conditions based on the status of a set of
+// workloads; they hold if they hold for any of the workloads.
+func aggregateConditions(conditions *[]metaV1.Condition, workloads []v1alpha1.WorkloadStatus, generation int64) {
+	for _, condition := range []string{v1alpha1.ConditionPlanning, v1alpha1.ConditionDegraded, v1alpha1.ConditionProfileMissing} {
+		var affected []string
+		for _, workload := range workloads {
+			if meta.IsStatusConditionTrue(workload.Conditions, condition) {
+				affected = append(affected, workload.Name)
+			}
+		}
+		if len(affected) > 0 {
+			meta.SetStatusCondition(conditions, metaV1.Condition{Type: condition, Status: metaV1.ConditionTrue, ObservedGeneration: generation, Reason: "WorkloadsAffected", Message: "Applies to workload(s): " + strings.Join(affected, ", ") + "."})
+		} else {
+			meta.SetStatusCondition(conditions, metaV1.Condition{Type: condition, Status: metaV1.ConditionFalse, ObservedGeneration: generation, Reason: "NoWorkloadsAffected", Message: "Applies to none of the workloads."})
+		}
+	}
+}
+
+// modifyStatus applies a modification to the status subresource of the intent.
+func (c *IntentController) modifyStatus(key string, modify func(intent *v1alpha1.Intent)) {
+	if c.intentClient == nil {
+		return
+	}
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("invalid resource key: '%s'", key))
+		return
+	}
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		intent, err := c.intentClient.IdoV1alpha1().Intents(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		intentCopy := intent.DeepCopy()
+		modify(intentCopy)
+		_, err = c.intentClient.IdoV1alpha1().Intents(namespace).UpdateStatus(context.TODO(), intentCopy, metaV1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("unable to update status subresource: %s", err))
+		return
+	}
+	klog.V(2).Infof("Updated status for intent '%s'.", key)
+}
+
+// updateStatus updates the status subresource of the intent; for intents derived from a selector the status of the
+// parent intent is updated, and for intents derived for the members of a group the status of the group.
+func (c *IntentController) updateStatus(key string, current common.State, desired common.State, plan []planner.Action, profiles map[string]common.Profile) {
+	now := c.clock.Now()
+	if desired.Intent.ParentKind == ParentKindIntentGroup {
+		c.modifyGroupStatus(desired.Intent.ParentKey, func(group *v1alpha1.IntentGroup) {
+			setMemberStatus(group, desired.Intent.TargetKey, current, desired, plan, profiles, now)
+		})
+		return
+	}
+	if desired.Intent.ParentKey != "" {
+		c.modifyStatus(desired.Intent.ParentKey, func(intent *v1alpha1.Intent) {
+			setWorkloadStatus(intent, desired.Intent.TargetKey, current, desired, plan, profiles, now)
+		})
+		return
+	}
+	c.modifyStatus(key, func(intent *v1alpha1.Intent) {
+		setIntentStatus(intent, current, desired, plan, profiles, now)
+	})
+}
+
+// updateShadowStatus records the patches the last plan would have applied in the status subresource of an intent
+// handled in shadow mode; for intents derived from a selector they are recorded in the status of the parent intent.
+// Intents derived for the members of a group are only traced.
+func (c *IntentController) updateShadowStatus(key string, desired common.State, patches []planner.Patch) {
+	if desired.Intent.ParentKind == ParentKindIntentGroup {
+		return
+	}
+	if desired.Intent.ParentKey != "" {
+		c.modifyStatus(desired.Intent.ParentKey, func(intent *v1alpha1.Intent) {
+			setShadowPlan(intent, patches, false)
+		})
+		return
+	}
+	c.modifyStatus(key, func(intent *v1alpha1.Intent) {
+		setShadowPlan(intent, patches, true)
+	})
+}
+
+// updateRollbackStatus marks an intent as degraded as its last plan was rolled back; for intents derived from a
+// selector the status of the workload in the parent intent is marked. Intents derived for the members of a group are
+// only reported through events.
+func (c *IntentController) updateRollbackStatus(key string, desired common.State, regressed []string) {
+	if desired.Intent.ParentKind == ParentKindIntentGroup {
+		return
+	}
+	if desired.Intent.ParentKey != "" {
+		c.modifyStatus(desired.Intent.ParentKey, func(intent *v1alpha1.Intent) {
+			for i := range intent.Status.Workloads {
+				if intent.Status.Workloads[i].Name == desired.Intent.TargetKey {
+					setRolledBack(&intent.Status.Workloads[i].Conditions, intent.Generation, regressed)
+				}
+			}
+			if len(intent.Status.Workloads) > 0 {
+				aggregateConditions(&intent.Status.Conditions, intent.Status.Workloads, intent.Generation)
+			}
+		})
+		return
+	}
+	c.modifyStatus(key, func(intent *v1alpha1.Intent) {
+		setRolledBack(&intent.Status.Conditions, intent.Generation, regressed)
+	})
+}
+
+// updateArbitrationStatus records in the status subresource of an intent why its plan was trimmed or rejected during
+// the arbitration; for intents derived from a selector it is recorded in the status of the workload. Intents derived
+// for the members of a group are only reported through events.
+func (c *IntentController) updateArbitrationStatus(key string, desired common.State, planning bool, reason string) {
+	if desired.Intent.ParentKind == ParentKindIntentGroup {
+		return
+	}
+	if desired.Intent.ParentKey != "" {
+		c.modifyStatus(desired.Intent.ParentKey, func(intent *v1alpha1.Intent) {
+			for i := range intent.Status.Workloads {
+				if intent.Status.Workloads[i].Name == desired.Intent.TargetKey {
+					setArbitrationLost(&intent.Status.Workloads[i].Conditions, intent.Generation, planning, reason)
+				}
+			}
+			if len(intent.Status.Workloads) > 0 {
+				aggregateConditions(&intent.Status.Conditions, intent.Status.Workloads, intent.Generation)
+			}
+		})
+		return
+	}
+	c.modifyStatus(key, func(intent *v1alpha1.Intent) {
+		setArbitrationLost(&intent.Status.Conditions, intent.Generation, planning, reason)
+	})
+}
@@ -0,0 +1,401 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/intel/intent-driven-orchestration/pkg/api/intents/v1alpha1"
+	"github.com/intel/intent-driven-orchestration/pkg/common"
+	"github.com/intel/intent-driven-orchestration/pkg/generated/clientset/versioned/fake"
+	"github.com/intel/intent-driven-orchestration/pkg/planner"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testingClock "k8s.io/utils/clock/testing"
+)
+
+// statusProfiles returns a set of profiles for testing.
+func statusProfiles() map[string]common.Profile {
+	return map[string]common.Profile{
+		"p99latency":   {Key: "p99latency", ProfileType: common.Latency, Minimize: true},
+		"availability": {Key: "availability", ProfileType: common.Availability, Minimize: false},
+	}
+}
+
+// statusIntent returns an intent for testing.
+func statusIntent() *v1alpha1.Intent {
+	intent := newIntent("my-intent", 10)
+	intent.Spec.Objectives[0].Tolerance = 0.1
+	intent.Spec.Objectives = append(intent.Spec.Objectives, v1alpha1.TargetObjective{Name: "avail", Value: 0.99, MeasuredBy: "availability"})
+	return intent
+}
+
+// Tests for success.
+
+// TestSetIntentStatusForSuccess tests for success.
+func TestSetIntentStatusForSuccess(t *testing.T) {
+	intent := statusIntent()
+	current := common.State{
+		Intent:      common.Intent{Objectives: map[string]float64{"p99latency": 8, "availability": 0.999}},
+		CurrentPods: map[string]common.PodState{"pod_0": {}},
+	}
+	setIntentStatus(intent, current, common.State{}, nil, statusProfiles(), time.Now())
+	if len(intent.Status.Objectives) != 2 || len(intent.Status.Conditions) != 4 {
+		t.Errorf("Expected 2 objectives & 4 conditions - got: %v.", intent.Status)
+	}
+}
+
+// TestUpdateStatusForSuccess tests for success.
+func TestUpdateStatusForSuccess(t *testing.T) {
+	intent := statusIntent()
+	c := newTestController()
+	client := fake.NewSimpleClientset(intent)
+	c.intentClient = client
+	current := common.State{Intent: common.Intent{Objectives: map[string]float64{"p99latency": 12, "availability": 0.999}}, CurrentPods: map[string]common.PodState{}}
+	desired := common.State{Intent: common.Intent{ActivelyManaged: true}}
+	c.updateStatus("default/my-intent", current, desired, []planner.Action{{Name: "scaleOut", Properties: map[string]int64{"factor": 1}}}, statusProfiles())
+
+	res := client.Actions()
+	if len(res) != 2 || res[1].GetVerb() != "update" || res[1].GetSubresource() != "status" {
+		t.Errorf("Expected a get & status update - got: %v.", res)
+	}
+}
+
+// TestSetWorkloadStatusForSuccess tests for success.
+func TestSetWorkloadStatusForSuccess(t *testing.T) {
+	intent := statusIntent()
+	current := common.State{
+		Intent:      common.Intent{Objectives: map[string]float64{"p99latency": 8, "availability": 0.999}},
+		CurrentPods: map[string]common.PodState{"pod_0": {}},
+	}
+	setWorkloadStatus(intent, "default/a", current, common.State{}, nil, statusProfiles(), time.Now())
+	if len(intent.Status.Workloads) != 1 || intent.Status.Workloads[0].Name != "default/a" || len(intent.Status.Objectives) != 2 || len(intent.Status.Conditions) != 4 {
+		t.Errorf("Expected status for 1 workload - got: %v.", intent.Status)
+	}
+}
+
+// Tests for failure.
+
+// TestUpdateStatusForFailure tests for failure.
+func TestUpdateStatusForFailure(t *testing.T) {
+	c := newTestController()
+	client := fake.NewSimpleClientset()
+	c.intentClient = client
+
+	// invalid key.
+	c.updateStatus("default/foo/bar", common.State{}, common.State{}, nil, statusProfiles())
+	if len(client.Actions()) != 0 {
+		t.Errorf("Should not have called the API: %v.", client.Actions())
+	}
+
+	// non existing intent.
+	c.updateStatus("default/my-intent", common.State{}, common.State{}, nil, statusProfiles())
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "update" {
+			t.Errorf("Should not have updated anything: %v.", action)
+		}
+	}
+}
+
+// Tests for sanity.
+
+// TestIsCompliantForSanity tests for sanity.
+func TestIsCompliantForSanity(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     float64
+		target    float64
+		tolerance float64
+		minimize  bool
+		want      bool
+	}{
+		{name: "tc-0", value: 4.0, target: 4.0, tolerance: 0.0, minimize: true, want: true},
+		{name: "tc-1", value: 4.01, target: 4.0, tolerance: 0.0, minimize: true, want: false},
+		{name: "tc-2", value: 4.01, target: 4.0, tolerance: 0.1, minimize: true, want: true},
+		{name: "tc-3", value: 4.5, target: 4.0, tolerance: 0.1, minimize: true, want: false},
+		{name: "tc-4", value: 0.95, target: 0.99, tolerance: 0.0, minimize: false, want: false},
+		{name: "tc-5", value: 0.95, target: 0.99, tolerance: 0.05, minimize: false, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isCompliant(tt.value, tt.target, tt.tolerance, tt.minimize); got != tt.want {
+				t.Errorf("isCompliant() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSetIntentStatusForSanity tests for sanity.
+func TestSetIntentStatusForSanity(t *testing.T) {
+	now := time.Now()
+	pods := map[string]common.PodState{"pod_0": {}}
+
+	// compliant within tolerance; no plan.
+	intent := statusIntent()
+	current := common.State{Intent: common.Intent{Objectives: map[string]float64{"p99latency": 10.5, "availability": 0.999}}, CurrentPods: pods}
+	setIntentStatus(intent, current, common.State{}, nil, statusProfiles(), now)
+	if !meta.IsStatusConditionTrue(intent.Status.Conditions, v1alpha1.ConditionCompliant) ||
+		meta.IsStatusConditionTrue(intent.Status.Conditions, v1alpha1.ConditionPlanning) ||
+		meta.IsStatusConditionTrue(intent.Status.Conditions, v1alpha1.ConditionDegraded) ||
+		meta.IsStatusConditionTrue(intent.Status.Conditions, v1alpha1.ConditionProfileMissing) {
+		t.Errorf("Unexpected conditions: %v.", intent.Status.Conditions)
+	}
+	if intent.Status.LastPlanTime != nil || intent.Status.Objectives[0].Value != 10.5 || !intent.Status.Objectives[0].Compliant {
+		t.Errorf("Unexpected status: %v.", intent.Status)
+	}
+
+	// not compliant; plan got executed.
+	current.Intent.Objectives["p99latency"] = 20
+	plan := []planner.Action{{Name: "scaleOut", Properties: map[string]int64{"factor": 2}}, {Name: "rdt", Properties: map[string]string{"option": "cos0"}}}
+	desired := common.State{Intent: common.Intent{ActivelyManaged: true}}
+	setIntentStatus(intent, current, desired, plan, statusProfiles(), now)
+	if meta.IsStatusConditionTrue(intent.Status.Conditions, v1alpha1.ConditionCompliant) ||
+		!meta.IsStatusConditionTrue(intent.Status.Conditions, v1alpha1.ConditionPlanning) ||
+		meta.IsStatusConditionTrue(intent.Status.Conditions, v1alpha1.ConditionDegraded) {
+		t.Errorf("Unexpected conditions: %v.", intent.Status.Conditions)
+	}
+	if meta.FindStatusCondition(intent.Status.Conditions, v1alpha1.ConditionPlanning).Reason != "PlanExecuted" {
+		t.Errorf("Expected plan to be executed: %v.", intent.Status.Conditions)
+	}
+	if len(intent.Status.LastPlan) != 2 || intent.Status.LastPlan[0].Properties["factor"] != "2" ||
+		intent.Status.LastPlan[1].Properties["option"] != "cos0" || !intent.Status.LastPlanTime.Equal(&metaV1.Time{Time: now}) {
+		t.Errorf("Unexpected last plan: %v - %v.", intent.Status.LastPlan, intent.Status.LastPlanTime)
+	}
+
+	// not compliant; no plan --> degraded; last plan is kept.
+	setIntentStatus(intent, current, desired, nil, statusProfiles(), now.Add(time.Minute))
+	cond := meta.FindStatusCondition(intent.Status.Conditions, v1alpha1.ConditionDegraded)
+	if cond.Status != metaV1.ConditionTrue || cond.Reason != "NoPlanFound" || len(intent.Status.LastPlan) != 2 {
+		t.Errorf("Expected intent to be degraded: %v.", intent.Status)
+	}
+
+	// missing profile & failed measurement.
+	profiles := statusProfiles()
+	delete(profiles, "availability")
+	current.Intent.Objectives = map[string]float64{"p99latency": -1}
+	setIntentStatus(intent, current, desired, nil, profiles, now)
+	if !meta.IsStatusConditionTrue(intent.Status.Conditions, v1alpha1.ConditionProfileMissing) {
+		t.Errorf("Expected missing profile: %v.", intent.Status.Conditions)
+	}
+	if meta.FindStatusCondition(intent.Status.Conditions, v1alpha1.ConditionDegraded).Reason != "MeasurementFailed" {
+		t.Errorf("Expected failed measurement: %v.", intent.Status.Conditions)
+	}
+
+	// target not found.
+	current.CurrentPods = nil
+	setIntentStatus(intent, current, desired, nil, profiles, now)
+	if meta.FindStatusCondition(intent.Status.Conditions, v1alpha1.ConditionDegraded).Reason != "TargetNotFound" {
+		t.Errorf("Expected target not found: %v.", intent.Status.Conditions)
+	}
+}
+
+// TestUpdateStatusForSanity tests for sanity.
+func TestUpdateStatusForSanity(t *testing.T) {
+	intent := statusIntent()
+	c := newTestController()
+	client := fake.NewSimpleClientset(intent)
+	c.intentClient = client
+	current := common.State{Intent: common.Intent{Objectives: map[string]float64{"p99latency": 9, "availability": 0.999}}, CurrentPods: map[string]common.PodState{}}
+	c.updateStatus("default/my-intent", current, common.State{}, nil, statusProfiles())
+
+	res, err := client.IdoV1alpha1().Intents("default").Get(t.Context(), "my-intent", metaV1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Could not get intent: %v", err)
+	}
+	if !meta.IsStatusConditionTrue(res.Status.Conditions, v1alpha1.ConditionCompliant) || len(res.Status.Objectives) != 2 {
+		t.Errorf("Status not updated as expected: %v.", res.Status)
+	}
+}
+
+// TestUpdateShadowStatusForSanity tests for sanity.
+func TestUpdateShadowStatusForSanity(t *testing.T) {
+	intent := statusIntent()
+	c := newTestController()
+	client := fake.NewSimpleClientset(intent)
+	c.intentClient = client
+	current := common.State{Intent: common.Intent{Objectives: map[string]float64{"p99latency": 12, "availability": 0.999}}, CurrentPods: map[string]common.PodState{}}
+	desired := common.State{Intent: common.Intent{ActivelyManaged: true}}
+	plan := []planner.Action{{Name: "scaleOut", Properties: map[string]int64{"factor": 1}}}
+	patches := []planner.Patch{{Actuator: "scale_out", Kind: "Deployment", Namespace: "default", Name: "my-deployment", Type: "application/strategic-merge-patch+json", Data: `{"spec":{"replicas":2}}`, Diff: []string{"spec.replicas: 1 -> 2"}}}
+	c.updateStatus("default/my-intent", current, desired, plan, statusProfiles())
+	c.updateShadowStatus("default/my-intent", desired, patches)
+
+	res, _ := client.IdoV1alpha1().Intents("default").Get(t.Context(), "my-intent", metaV1.GetOptions{})
+	if len(res.Status.ShadowPlan) != 1 || res.Status.ShadowPlan[0].Patch != patches[0].Data || res.Status.ShadowPlan[0].Diff[0] != patches[0].Diff[0] {
+		t.Errorf("Expected the patches in the status - got: %v.", res.Status.ShadowPlan)
+	}
+	if condition := meta.FindStatusCondition(res.Status.Conditions, v1alpha1.ConditionPlanning); condition == nil || condition.Reason != "PlanShadowed" {
+		t.Errorf("Expected the plan to be marked as shadowed - got: %v.", condition)
+	}
+
+	// next executed plan clears the patches.
+	c.updateStatus("default/my-intent", current, desired, plan, statusProfiles())
+	res, _ = client.IdoV1alpha1().Intents("default").Get(t.Context(), "my-intent", metaV1.GetOptions{})
+	if len(res.Status.ShadowPlan) != 0 {
+		t.Errorf("Expected no patches in the status - got: %v.", res.Status.ShadowPlan)
+	}
+}
+
+// TestUpdateRollbackStatusForSanity tests for sanity.
+func TestUpdateRollbackStatusForSanity(t *testing.T) {
+	intent := statusIntent()
+	c := newTestController()
+	client := fake.NewSimpleClientset(intent)
+	c.intentClient = client
+	current := common.State{Intent: common.Intent{Objectives: map[string]float64{"p99latency": 12, "availability": 0.999}}, CurrentPods: map[string]common.PodState{}}
+	desired := common.State{Intent: common.Intent{ActivelyManaged: true}}
+	c.updateStatus("default/my-intent", current, desired, []planner.Action{{Name: "scaleOut"}}, statusProfiles())
+	c.updateRollbackStatus("default/my-intent", desired, []string{"p99latency"})
+
+	res, _ := client.IdoV1alpha1().Intents("default").Get(t.Context(), "my-intent", metaV1.GetOptions{})
+	if !rolledBack(res.Status.Conditions) {
+		t.Errorf("Expected the intent to be degraded - got: %v.", res.Status.Conditions)
+	}
+
+	// stays degraded until the planner comes up with a new plan.
+	current.Intent.Objectives["p99latency"] = 9
+	c.updateStatus("default/my-intent", current, desired, nil, statusProfiles())
+	res, _ = client.IdoV1alpha1().Intents("default").Get(t.Context(), "my-intent", metaV1.GetOptions{})
+	if !rolledBack(res.Status.Conditions) {
+		t.Errorf("Expected the intent to stay degraded - got: %v.", res.Status.Conditions)
+	}
+	c.updateStatus("default/my-intent", current, desired, []planner.Action{{Name: "rmPod"}}, statusProfiles())
+	res, _ = client.IdoV1alpha1().Intents("default").Get(t.Context(), "my-intent", metaV1.GetOptions{})
+	if rolledBack(res.Status.Conditions) {
+		t.Errorf("Expected the rollback to be cleared - got: %v.", res.Status.Conditions)
+	}
+}
+
+// TestUpdateArbitrationStatusForSanity tests for sanity.
+func TestUpdateArbitrationStatusForSanity(t *testing.T) {
+	intent := statusIntent()
+	c := newTestController()
+	client := fake.NewSimpleClientset(intent)
+	c.intentClient = client
+	current := common.State{Intent: common.Intent{Objectives: map[string]float64{"p99latency": 12, "availability": 0.999}}, CurrentPods: map[string]common.PodState{}}
+	desired := common.State{Intent: common.Intent{ActivelyManaged: true}}
+	c.updateStatus("default/my-intent", current, desired, nil, statusProfiles())
+	c.updateArbitrationStatus("default/my-intent", desired, false, "insufficient cpu on node node0")
+
+	res, _ := client.IdoV1alpha1().Intents("default").Get(t.Context(), "my-intent", metaV1.GetOptions{})
+	condition := meta.FindStatusCondition(res.Status.Conditions, v1alpha1.ConditionPlanning)
+	if condition == nil || condition.Status != metaV1.ConditionFalse || condition.Reason != "ArbitrationLost" || !strings.Contains(condition.Message, "insufficient cpu on node node0") {
+		t.Errorf("Expected the lost arbitration in the conditions - got: %v.", res.Status.Conditions)
+	}
+}
+
+// TestSetWorkloadStatusForSanity tests for sanity.
+func TestSetWorkloadStatusForSanity(t *testing.T) {
+	intent := statusIntent()
+	profiles := statusProfiles()
+	now := time.Now()
+	pods := map[string]common.PodState{"pod_0": {}}
+
+	// two compliant workloads; worst values are reported.
+	setWorkloadStatus(intent, "default/b", common.State{Intent: common.Intent{Objectives: map[string]float64{"p99latency": 8, "availability": 0.999}}, CurrentPods: pods}, common.State{}, nil, profiles, now)
+	setWorkloadStatus(intent, "default/a", common.State{Intent: common.Intent{Objectives: map[string]float64{"p99latency": 9, "availability": 0.995}}, CurrentPods: pods}, common.State{}, nil, profiles, now)
+	if len(intent.Status.Workloads) != 2 || intent.Status.Workloads[0].Name != "default/a" {
+		t.Errorf("Expected 2 sorted workloads - got: %v.", intent.Status.Workloads)
+	}
+	if intent.Status.Objectives[0].Value != 9 || intent.Status.Objectives[1].Value != 0.995 || !intent.Status.Objectives[0].Compliant {
+		t.Errorf("Expected worst values - got: %v.", intent.Status.Objectives)
+	}
+	if !meta.IsStatusConditionTrue(intent.Status.Conditions, v1alpha1.ConditionCompliant) {
+		t.Errorf("Expected to be compliant: %v.", intent.Status.Conditions)
+	}
+
+	// one workload violates the objectives & gets a plan.
+	plan := []planner.Action{{Name: "scaleOut", Properties: map[string]int64{"factor": 1}}}
+	setWorkloadStatus(intent, "default/b", common.State{Intent: common.Intent{Objectives: map[string]float64{"p99latency": 20, "availability": 0.999}}, CurrentPods: pods}, common.State{}, plan, profiles, now)
+	compliant := meta.FindStatusCondition(intent.Status.Conditions, v1alpha1.ConditionCompliant)
+	if compliant.Status != metaV1.ConditionFalse || compliant.Message != "Objective(s) not met for workload(s): default/b." {
+		t.Errorf("Expected workload b to be non compliant: %v.", compliant)
+	}
+	if intent.Status.Objectives[0].Value != 20 || intent.Status.Objectives[0].Compliant {
+		t.Errorf("Expected worst value of workload b - got: %v.", intent.Status.Objectives)
+	}
+	if !meta.IsStatusConditionTrue(intent.Status.Conditions, v1alpha1.ConditionPlanning) || len(intent.Status.LastPlan) != 1 {
+		t.Errorf("Expected plan to be reported: %v.", intent.Status)
+	}
+	if meta.IsStatusConditionTrue(intent.Status.Workloads[0].Conditions, v1alpha1.ConditionPlanning) {
+		t.Errorf("Workload a should not have a plan: %v.", intent.Status.Workloads[0])
+	}
+
+	// workload b disappears.
+	removeWorkloadStatus(intent, "default/b", profiles)
+	if len(intent.Status.Workloads) != 1 || !meta.IsStatusConditionTrue(intent.Status.Conditions, v1alpha1.ConditionCompliant) {
+		t.Errorf("Expected only compliant workload a - got: %v.", intent.Status)
+	}
+
+	// no workloads left.
+	removeWorkloadStatus(intent, "default/a", profiles)
+	degraded := meta.FindStatusCondition(intent.Status.Conditions, v1alpha1.ConditionDegraded)
+	if len(intent.Status.Workloads) != 0 || degraded.Status != metaV1.ConditionTrue || degraded.Reason != "NoWorkloads" || intent.Status.Objectives[0].Compliant {
+		t.Errorf("Expected to be degraded - got: %v.", intent.Status)
+	}
+}
+
+// TestUpdateWorkloadStatusForSanity tests for sanity.
+func TestUpdateWorkloadStatusForSanity(t *testing.T) {
+	intent := statusIntent()
+	c := newTestController()
+	client := fake.NewSimpleClientset(intent)
+	c.intentClient = client
+	current := common.State{Intent: common.Intent{Objectives: map[string]float64{"p99latency": 9, "availability": 0.999}}, CurrentPods: map[string]common.PodState{}}
+	desired := common.State{Intent: common.Intent{Key: "default/my-intent@Intent:default/a", ParentKey: "default/my-intent", TargetKey: "default/a"}}
+	c.updateStatus(desired.Intent.Key, current, desired, nil, statusProfiles())
+
+	res, err := client.IdoV1alpha1().Intents("default").Get(t.Context(), "my-intent", metaV1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Could not get intent: %v", err)
+	}
+	if len(res.Status.Workloads) != 1 || res.Status.Workloads[0].Name != "default/a" || !meta.IsStatusConditionTrue(res.Status.Conditions, v1alpha1.ConditionCompliant) {
+		t.Errorf("Status of parent not updated as expected: %v.", res.Status)
+	}
+}
+
+// TestScheduledObjectivesStatusForSanity tests for sanity.
+func TestScheduledObjectivesStatusForSanity(t *testing.T) {
+	intent := statusIntent()
+	intent.Spec.Objectives[0].Schedule = []v1alpha1.ScheduleWindow{
+		{Name: "business-hours", Days: []string{"Mon"}, Start: "08:00", End: "18:00", TimeZone: "Europe/Berlin", Value: 5},
+	}
+	c := newTestController()
+	client := fake.NewSimpleClientset(intent)
+	c.intentClient = client
+	c.profiles = statusProfiles()
+	// 2024-05-06 is a Monday - 12:00 in Berlin.
+	clock := testingClock.NewFakePassiveClock(time.Date(2024, 5, 6, 10, 0, 0, 0, time.UTC))
+	c.SetClock(clock)
+	c.intents["default/my-intent"] = common.Intent{
+		Key:        "default/my-intent",
+		Priority:   1.0,
+		TargetKey:  "default/my-deployment",
+		TargetKind: "bar",
+		Objectives: map[string]float64{"p99latency": 10, "availability": 0.99},
+		Schedules:  map[string][]common.ScheduleWindow{"p99latency": toScheduleWindows("default/my-intent", intent.Spec.Objectives[0])},
+	}
+	go c.worker(0)
+	defer c.queue.ShutDown()
+
+	getObjective := func() v1alpha1.ObjectiveStatus {
+		c.queue.Add("default/my-intent")
+		time.Sleep(TIMEOUT * time.Millisecond)
+		res, err := client.IdoV1alpha1().Intents("default").Get(t.Context(), "my-intent", metaV1.GetOptions{})
+		if err != nil || len(res.Status.Objectives) != 2 {
+			t.Fatalf("Could not get status of the intent: %v - %v", err, res)
+		}
+		return res.Status.Objectives[0]
+	}
+
+	if objective := getObjective(); objective.Target != 5 || objective.ActiveWindow != "business-hours" {
+		t.Errorf("Expected target value of the active window - got: %v.", objective)
+	}
+
+	// 19:00 in Berlin.
+	clock.SetTime(time.Date(2024, 5, 6, 17, 0, 0, 0, time.UTC))
+	if objective := getObjective(); objective.Target != 10 || objective.ActiveWindow != "" {
+		t.Errorf("Expected default target value - got: %v.", objective)
+	}
+}
@@ -8,8 +8,11 @@ import (
 	"net/http"
 	"net/url"
 	"reflect"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/intel/intent-driven-orchestration/pkg/common"
@@ -20,6 +23,18 @@ import (
 // round represents the rounding factor. // TODO: make configurable.
 const round = 1000
 
+// DefaultQueryWindow defines the time window used in queries if a profile does not define one.
+const DefaultQueryWindow = "30s"
+
+// positionalPlaceholders defines the number of placeholders of queries using the positional format.
+const positionalPlaceholders = 4
+
+// windowFormat defines the format of a time window as used in PromQL range selectors - e.g. "30s" or "1h30m".
+var windowFormat = regexp.MustCompile(`^([0-9]+(ms|s|m|h|d|w|y))+$`)
+
+// querySourceProfile labels the metrics of the queries for the KPI profiles.
+const querySourceProfile = "profile"
+
 // Client represents a http client.
 var Client httpClient
 
@@ -62,45 +77,154 @@ type prometheusResponse struct {
 	} `json:"data"`
 }
 
+// QueryData holds the values which can be used as placeholders in query templates - e.g. {{.Namespace}}.
+type QueryData struct {
+	// Namespace of the workload resource.
+	Namespace string
+	// Kind of the workload resource in lower case.
+	Kind string
+	// Name of the workload resource.
+	Name string
+	// Pods is a regular expression matching the names of the PODs of the workload - e.g. for use with "=~".
+	Pods string
+	// Nodes is a regular expression matching the names of the nodes the PODs of the workload run on.
+	Nodes string
+	// Window is the time window to use in range selectors - e.g. "30s".
+	Window string
+}
+
+// quoteRegexp escapes the metacharacters in a name for use in a regular expression within a PromQL string literal - in
+// which the backslashes need escaping themselves.
+func quoteRegexp(name string) string {
+	return strings.ReplaceAll(regexp.QuoteMeta(name), `\`, `\\`)
+}
+
+// newQueryData returns the values for the placeholders in a query for an objective and the PODs of its workload.
+func newQueryData(objective common.Intent, pods map[string]common.PodState, window string) (QueryData, error) {
+	tmp := strings.Split(objective.TargetKey, "/")
+	if len(tmp) != 2 {
+		return QueryData{}, fmt.Errorf("target key '%s' is not of the form namespace/name", objective.TargetKey)
+	}
+	if window == "" {
+		window = DefaultQueryWindow
+	}
+	var podNames, nodeNames []string
+	nodes := map[string]bool{}
+	for name, pod := range pods {
+		podNames = append(podNames, quoteRegexp(name))
+		if pod.NodeName != "" && !nodes[pod.NodeName] {
+			nodes[pod.NodeName] = true
+			nodeNames = append(nodeNames, quoteRegexp(pod.NodeName))
+		}
+	}
+	sort.Strings(podNames)
+	sort.Strings(nodeNames)
+	return QueryData{
+		Namespace: tmp[0],
+		Kind:      strings.ToLower(objective.TargetKind),
+		Name:      tmp[1],
+		Pods:      strings.Join(podNames, "|"),
+		Nodes:     strings.Join(nodeNames, "|"),
+		Window:    window,
+	}, nil
+}
+
+// isPositionalQuery checks if a query uses the positional format - namespace, kind, name and kind as "%s" - which is
+// still supported for the default profiles.
+func isPositionalQuery(query string, external bool) bool {
+	return !external && strings.Contains(query, "%s")
+}
+
+// parseQuery parses a query template; referring to unknown placeholders is an error.
+func parseQuery(query string) (*template.Template, error) {
+	tmpl, err := template.New("query").Option("missingkey=error").Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	// make sure only known placeholders are used.
+	if err = tmpl.Execute(io.Discard, QueryData{}); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// ValidateQuery checks if the query and time window of a profile are valid.
+func ValidateQuery(query string, window string, external bool) error {
+	if window != "" && !windowFormat.MatchString(window) {
+		return fmt.Errorf("invalid time window '%s'", window)
+	}
+	if isPositionalQuery(query, external) {
+		if n := strings.Count(strings.ReplaceAll(query, "%%", ""), "%s"); n != positionalPlaceholders {
+			return fmt.Errorf("query uses %d positional placeholders instead of %d - consider using named placeholders such as {{.Namespace}}", n, positionalPlaceholders)
+		}
+		return nil
+	}
+	if _, err := parseQuery(query); err != nil {
+		return fmt.Errorf("invalid query template: %v", err)
+	}
+	return nil
+}
+
+// renderQuery fills in the placeholders of the query of a profile.
+func renderQuery(profile common.Profile, data QueryData) (string, error) {
+	if isPositionalQuery(profile.Query, profile.External) {
+		return fmt.Sprintf(profile.Query, data.Namespace, data.Kind, data.Name, data.Kind), nil
+	}
+	tmpl, err := parseQuery(profile.Query)
+	if err != nil {
+		return "", err
+	}
+	var query strings.Builder
+	if err = tmpl.Execute(&query, data); err != nil {
+		return "", err
+	}
+	return query.String(), nil
+}
+
 // doQuery asks a Prometheus compatible endpoint for the current value.
-func doQuery(profile common.Profile, objective common.Intent) float64 {
+func doQuery(profile common.Profile, objective common.Intent, pods map[string]common.PodState) float64 {
 	defer func() {
 		if err := recover(); err != nil {
 			klog.Errorf("failed: %v - but we're recovering", err)
 		}
 	}()
-	var query string
-	if profile.External {
-		query = profile.Query
-	} else {
-		if !strings.Contains(objective.TargetKey, "/") && strings.Count(objective.TargetKey, "/") != 1 {
-			return -1.0
-		}
-		tmp := strings.Split(objective.TargetKey, "/")
-		kind := strings.ToLower(objective.TargetKind)
-		query = fmt.Sprintf(profile.Query, tmp[0], kind, tmp[1], kind)
+	data, err := newQueryData(objective, pods, profile.Window)
+	if err != nil {
+		klog.Errorf("Could not determine values for query of profile %s: %s", profile.Key, err)
+		return -1.0
+	}
+	query, err := renderQuery(profile, data)
+	if err != nil {
+		klog.Errorf("Could not render query of profile %s: %s", profile.Key, err)
+		return -1.0
 	}
 
 	request, err := http.NewRequest(http.MethodGet, profile.Address+"?query="+url.QueryEscape(query), nil)
 	if err != nil {
 		klog.Errorf("Could not construct new request: %s", err)
+		common.QueryFailures.WithLabelValues(querySourceProfile).Inc()
 		return -1.0
 	}
+	start := time.Now()
 	response, err := Client.Do(request)
+	common.QueryDuration.WithLabelValues(querySourceProfile).Observe(time.Since(start).Seconds())
 	if err != nil {
 		klog.Errorf("Could not perform request: %s", err)
+		common.QueryFailures.WithLabelValues(querySourceProfile).Inc()
 		return -1.0
 	}
 	if response.StatusCode == 200 {
 		body, err := io.ReadAll(response.Body)
 		if err != nil {
 			klog.Errorf("Could not read body: %s.", err)
+			common.QueryFailures.WithLabelValues(querySourceProfile).Inc()
 			return -1.0
 		}
 		var result prometheusResponse
 		err = json.Unmarshal(body, &result)
 		if err != nil {
 			klog.V(1).Infof("Could not unmarshal json: %s.", err)
+			common.QueryFailures.WithLabelValues(querySourceProfile).Inc()
 			return -1.0
 		}
 		if len(result.Data.Result) != 0 {
@@ -112,6 +236,9 @@ func doQuery(profile common.Profile, objective common.Intent) float64 {
 		}
 	}
 	klog.Warningf("Sth went wrong while trying get information from Prometheus - will return -1.0. Status code was: %v.", response.StatusCode)
+	if response.StatusCode != 200 {
+		common.QueryFailures.WithLabelValues(querySourceProfile).Inc()
+	}
 	return -1.0
 }
 
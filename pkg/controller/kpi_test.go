@@ -32,7 +32,7 @@ func TestDoQueryForSuccess(_ *testing.T) {
 		TargetKind: "Deployment",
 		Objectives: nil,
 	}
-	doQuery(prof, objective)
+	doQuery(prof, objective, nil)
 }
 
 // TestPodAvailabilityForSuccess tests for success.
@@ -66,7 +66,7 @@ func TestDoQueryForFailure(t *testing.T) {
 		TargetKind: "Deployment",
 		Objectives: nil,
 	}
-	res := doQuery(prof, objective)
+	res := doQuery(prof, objective, nil)
 	if res != -1.0 {
 		t.Errorf("Should have returned -1.0 - actually returned: %f", res)
 	}
@@ -92,7 +92,7 @@ func TestDoQueryForSanity(t *testing.T) {
 		TargetKind: "Deployment",
 		Objectives: nil,
 	}
-	res := doQuery(prof, objective)
+	res := doQuery(prof, objective, nil)
 	if res != 1.235 {
 		t.Errorf("Expected 1.235 - actually got: %f", res)
 	}
@@ -100,7 +100,7 @@ func TestDoQueryForSanity(t *testing.T) {
 	// json parse will fail.
 	nonsense := "foo[{9]}"
 	MockResponse(nonsense, 200)
-	res = doQuery(prof, objective)
+	res = doQuery(prof, objective, nil)
 	if res != -1.0 {
 		t.Errorf("Expected -1.0 - actually got: %f", res)
 	}
@@ -108,7 +108,7 @@ func TestDoQueryForSanity(t *testing.T) {
 	// no data
 	noData := "{\"data\": {\"result\": []}}"
 	MockResponse(noData, 200)
-	res = doQuery(prof, objective)
+	res = doQuery(prof, objective, nil)
 	if res != -1.0 {
 		t.Errorf("Expected -1.0 - actually got: %f", res)
 	}
@@ -116,12 +116,100 @@ func TestDoQueryForSanity(t *testing.T) {
 	// not a number data
 	notANumber := "{\"data\": {\"result\": [{\"value\": [1645019125.000, \"NaN\"]}]}}"
 	MockResponse(notANumber, 200)
-	res = doQuery(prof, objective)
+	res = doQuery(prof, objective, nil)
 	if res != -1.0 {
 		t.Errorf("Expected -1.0 - actually got: %f", res)
 	}
 }
 
+// TestRenderQueryForSanity tests for sanity.
+func TestRenderQueryForSanity(t *testing.T) {
+	objective := common.Intent{TargetKey: "default/my-deployment", TargetKind: "Deployment"}
+	pods := map[string]common.PodState{
+		"my-deployment-b": {NodeName: "node1"},
+		"my-deployment-a": {NodeName: "node0"},
+		"my-deployment-c": {NodeName: "node1"},
+		"my-deployment-d": {NodeName: "ip-10-0-0-1.ec2.internal"},
+	}
+	data, err := newQueryData(objective, pods, "")
+	if err != nil {
+		t.Fatalf("Should not have failed: %v.", err)
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		external bool
+		want     string
+	}{
+		{
+			name:  "positional",
+			query: "q{namespace=\"%s\",%s=\"%s\"}by(%s)",
+			want:  "q{namespace=\"default\",deployment=\"my-deployment\"}by(deployment)",
+		},
+		{
+			name:  "named",
+			query: "q{namespace=\"{{.Namespace}}\",{{.Kind}}=\"{{.Name}}\"}[{{.Window}}]",
+			want:  "q{namespace=\"default\",deployment=\"my-deployment\"}[30s]",
+		},
+		{
+			name:     "pods-and-nodes",
+			query:    "q{pod=~\"{{.Pods}}\",node=~\"{{.Nodes}}\"}",
+			external: true,
+			want:     "q{pod=~\"my-deployment-a|my-deployment-b|my-deployment-c|my-deployment-d\",node=~\"ip-10-0-0-1\\\\.ec2\\\\.internal|node0|node1\"}",
+		},
+		{
+			name:     "external-verbatim",
+			query:    "q{namespace=\"%s\"}",
+			external: true,
+			want:     "q{namespace=\"%s\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := renderQuery(common.Profile{Query: tt.query, External: tt.external}, data)
+			if err != nil || got != tt.want {
+				t.Errorf("renderQuery() = %v (%v), want %v", got, err, tt.want)
+			}
+		})
+	}
+
+	// target keys need to be of the form namespace/name.
+	if _, err = newQueryData(common.Intent{TargetKey: "foo"}, nil, ""); err == nil {
+		t.Error("Expected an error for an invalid target key.")
+	}
+}
+
+// TestValidateQueryForSanity tests for sanity.
+func TestValidateQueryForSanity(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		window   string
+		external bool
+		valid    bool
+	}{
+		{name: "positional", query: "q{a=\"%s\",%s=\"%s\"}by(%s)", valid: true},
+		{name: "positional-escaped", query: "q{a=\"%s\",%s=\"%s\"}by(%s) %% 2", valid: true},
+		{name: "positional-too-few", query: "q{a=\"%s\",%s=\"%s\"}", valid: false},
+		{name: "positional-too-many", query: "q{a=\"%s\",%s=\"%s\"}by(%s,%s)", valid: false},
+		{name: "named", query: "q{a=\"{{.Namespace}}\",b=~\"{{.Pods}}\",c=~\"{{.Nodes}}\"}[{{.Window}}]", valid: true},
+		{name: "named-external", query: "q{a=\"{{.Name}}\"}", external: true, valid: true},
+		{name: "plain", query: "q{a=\"b\"}", external: true, valid: true},
+		{name: "unknown-placeholder", query: "q{a=\"{{.Namespaces}}\"}", valid: false},
+		{name: "broken-template", query: "q{a=\"{{.Namespace\"}", external: true, valid: false},
+		{name: "window", query: "q", window: "1h30m", external: true, valid: true},
+		{name: "invalid-window", query: "q", window: "30 seconds", external: true, valid: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateQuery(tt.query, tt.window, tt.external); (err == nil) != tt.valid {
+				t.Errorf("ValidateQuery() = %v, valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
 // TestPodAvailabilityForSanity tests for sanity.
 func TestPodAvailabilityForSanity(t *testing.T) {
 	created, _ := time.Parse(time.RFC3339, "2022-02-16T10:00:00Z")
@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/intel/intent-driven-orchestration/pkg/common"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/klog/v2"
+)
+
+// LeaderIdentity returns a unique identity for this replica of the planner.
+func LeaderIdentity() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "planner"
+	}
+	return host + "_" + string(uuid.NewUUID())
+}
+
+// NewLeaderElector returns an elector using a Lease to determine which replica of the planner is the leader. Only the
+// leader should execute plans; the lease is released when the context passed to Run is cancelled.
+func NewLeaderElector(cfg common.LeaderElectionConfig, clientSet kubernetes.Interface, identity string, onStartedLeading func(ctx context.Context), onStoppedLeading func()) (*leaderelection.LeaderElector, error) {
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metaV1.ObjectMeta{
+			Namespace: cfg.Namespace,
+			Name:      cfg.LeaseName,
+		},
+		Client:     clientSet.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+	}
+	return leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		Name:            cfg.LeaseName,
+		LeaseDuration:   time.Duration(cfg.LeaseDuration) * time.Second,
+		RenewDeadline:   time.Duration(cfg.RenewDeadline) * time.Second,
+		RetryPeriod:     time.Duration(cfg.RetryPeriod) * time.Second,
+		ReleaseOnCancel: true,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				klog.Infof("Replica '%s' started leading.", identity)
+				onStartedLeading(ctx)
+			},
+			OnStoppedLeading: func() {
+				klog.Infof("Replica '%s' stopped leading.", identity)
+				onStoppedLeading()
+			},
+			OnNewLeader: func(leader string) {
+				if leader != identity {
+					klog.Infof("Replica '%s' is the leader.", leader)
+				}
+			},
+		},
+	})
+}
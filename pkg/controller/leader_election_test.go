@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/intel/intent-driven-orchestration/pkg/common"
+
+	k8sFake "k8s.io/client-go/kubernetes/fake"
+)
+
+// leaderElectionConfig returns a configuration for testing.
+func leaderElectionConfig() common.LeaderElectionConfig {
+	return common.LeaderElectionConfig{
+		Enabled:       true,
+		Namespace:     "ido",
+		LeaseName:     "planner",
+		LeaseDuration: 3,
+		RenewDeadline: 2,
+		RetryPeriod:   1,
+	}
+}
+
+// Tests for success.
+
+// TestNewLeaderElectorForSuccess tests for success.
+func TestNewLeaderElectorForSuccess(t *testing.T) {
+	elector, err := NewLeaderElector(leaderElectionConfig(), k8sFake.NewSimpleClientset(), LeaderIdentity(), func(_ context.Context) {}, func() {})
+	if err != nil || elector == nil {
+		t.Errorf("Should have returned an elector: %v.", err)
+	}
+}
+
+// Tests for failure.
+
+// TestNewLeaderElectorForFailure tests for failure.
+func TestNewLeaderElectorForFailure(t *testing.T) {
+	cfg := leaderElectionConfig()
+	cfg.RenewDeadline = 5
+	if _, err := NewLeaderElector(cfg, k8sFake.NewSimpleClientset(), LeaderIdentity(), func(_ context.Context) {}, func() {}); err == nil {
+		t.Error("Should have failed - renew deadline exceeds lease duration.")
+	}
+}
+
+// Tests for sanity.
+
+// TestLeaderElectionForSanity tests for sanity.
+func TestLeaderElectionForSanity(t *testing.T) {
+	client := k8sFake.NewSimpleClientset()
+	leaders := make(chan string, 10)
+	contexts := map[string]context.CancelFunc{}
+	for _, identity := range []string{"a", "b"} {
+		elector, err := NewLeaderElector(leaderElectionConfig(), client, identity, func(_ context.Context) {
+			leaders <- identity
+		}, func() {})
+		if err != nil {
+			t.Fatalf("Could not create elector: %v", err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		contexts[identity] = cancel
+		go elector.Run(ctx)
+	}
+
+	// only one replica leads.
+	var first string
+	select {
+	case first = <-leaders:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Timed out waiting for a leader.")
+	}
+	select {
+	case other := <-leaders:
+		t.Fatalf("Only one replica should lead - got: %s & %s.", first, other)
+	case <-time.After(2 * time.Second):
+	}
+
+	// leader steps down - other replica takes over.
+	contexts[first]()
+	select {
+	case second := <-leaders:
+		if second == first {
+			t.Errorf("Expected the other replica to take over - got: %s.", second)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Timed out waiting for the failover.")
+	}
+}
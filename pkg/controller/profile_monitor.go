@@ -2,9 +2,7 @@ package controller
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/intel/intent-driven-orchestration/pkg/api/intents/v1alpha1"
@@ -13,6 +11,7 @@ import (
 	informers "github.com/intel/intent-driven-orchestration/pkg/generated/informers/externalversions/intents/v1alpha1"
 	lister "github.com/intel/intent-driven-orchestration/pkg/generated/listers/intents/v1alpha1"
 
+	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
@@ -24,40 +23,37 @@ import (
 
 // KPIProfileMonitor is the part implementing the monitoring the KPIProfiles.
 type KPIProfileMonitor struct {
+	EventEmitter
 	profileClient   clientSet.Interface
 	profileLister   lister.KPIProfileLister
 	profileSynced   cache.InformerSynced
 	queue           workqueue.TypedRateLimitingInterface[string]
 	update          chan<- common.Profile
-	defaultProfiles map[string]map[string]string
+	defaultProfiles *DefaultProfiles
+	queriesFile     string
 	syncHandler     func(key string) error // Enables us to test this easily.
 }
 
 // NewKPIProfileMonitor returns a new monitor instance.
 func NewKPIProfileMonitor(cfg common.MonitorConfig, profileClient clientSet.Interface, profileInformer informers.KPIProfileInformer, ch chan<- common.Profile) *KPIProfileMonitor {
-	// parse default configs.
-	tmp, err := os.ReadFile(cfg.Profile.Queries)
-	if err != nil {
-		klog.Fatal("Unable to read config file with the default profile definitions: ", err)
-	}
-	var result map[string]map[string]string
-	err = json.Unmarshal(tmp, &result)
-	if err != nil {
-		klog.Fatal("Unable to parse default profile definitions: ", err)
-	}
-
 	// the actual monitor.
 	mon := &KPIProfileMonitor{
-		profileClient:   profileClient,
-		profileLister:   profileInformer.Lister(),
-		profileSynced:   profileInformer.Informer().HasSynced,
-		queue:           workqueue.NewTypedRateLimitingQueueWithConfig[string](workqueue.DefaultTypedControllerRateLimiter[string](), workqueue.TypedRateLimitingQueueConfig[string]{Name: "KPIProfiles"}),
-		update:          ch,
-		defaultProfiles: result,
+		profileClient: profileClient,
+		profileLister: profileInformer.Lister(),
+		profileSynced: profileInformer.Informer().HasSynced,
+		queue:         workqueue.NewTypedRateLimitingQueueWithConfig[string](workqueue.DefaultTypedControllerRateLimiter[string](), workqueue.TypedRateLimitingQueueConfig[string]{Name: "KPIProfiles"}),
+		update:        ch,
 	}
 	mon.syncHandler = mon.processProfile
 
-	// TODO: check for event broadcasting.
+	// parse default configs; unless they come from a ConfigMap, the file is watched for changes once running.
+	mon.defaultProfiles = NewDefaultProfiles(mon.enqueueProfiles)
+	if cfg.Profile.ConfigMap == "" {
+		mon.queriesFile = cfg.Profile.Queries
+		if err := mon.defaultProfiles.LoadFile(mon.queriesFile); err != nil {
+			klog.Warningf("No default profile definitions available for now: %v.", err)
+		}
+	}
 
 	// handle add, update & delete.
 	_, _ = profileInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -85,6 +81,26 @@ func NewKPIProfileMonitor(cfg common.MonitorConfig, profileClient clientSet.Inte
 	return mon
 }
 
+// DefaultProfiles returns the default profile definitions used by the monitor.
+func (mon *KPIProfileMonitor) DefaultProfiles() *DefaultProfiles {
+	return mon.defaultProfiles
+}
+
+// enqueueProfiles adds the existing profiles with the given keys to the work queue, so they get resolved again.
+func (mon *KPIProfileMonitor) enqueueProfiles(keys []string) {
+	for _, key := range keys {
+		namespace, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			continue
+		}
+		if _, err = mon.profileLister.KPIProfiles(namespace).Get(name); err != nil {
+			continue
+		}
+		klog.Infof("Will resolve profile '%s' again.", key)
+		mon.queue.Add(key)
+	}
+}
+
 // enqueueItem adds items to the work queue.
 func (mon *KPIProfileMonitor) enqueueItem(obj interface{}) {
 	var key string
@@ -106,6 +122,9 @@ func (mon *KPIProfileMonitor) Run(nWorkers int, stopper <-chan struct{}) {
 		return
 	}
 
+	if mon.queriesFile != "" {
+		go mon.defaultProfiles.WatchFile(mon.queriesFile, defaultProfilesReloadPeriod, stopper)
+	}
 	for i := 0; i < nWorkers; i++ {
 		go wait.Until(mon.runWorker, time.Second, stopper)
 	}
@@ -160,27 +179,51 @@ func (mon *KPIProfileMonitor) processProfile(key string) error {
 	}
 
 	// look into the profile we got and if it is valid push it into the channel.
+	profileType := common.ProfileTypeFromText(profile.Spec.KPIType)
+	if profileType == common.Obsolete {
+		mon.unresolved(profile, key, fmt.Sprintf("Unknown profile type '%s'.", profile.Spec.KPIType))
+		return nil
+	}
 	var parsedProfile common.Profile
-	if _, found := mon.defaultProfiles[key]; found {
-		tmp := mon.defaultProfiles[key]
-		parsedProfile = common.Profile{Key: key, ProfileType: common.ProfileTypeFromText(profile.Spec.KPIType), Query: tmp["query"], Minimize: profile.Spec.Minimize, Address: tmp["endpoint"]}
-		mon.updateStatus(profile, true, "ok")
-		mon.update <- parsedProfile
+	if tmp, found := mon.defaultProfiles.Get(key); found {
+		window := profile.Spec.Props["window"]
+		if window == "" {
+			window = tmp["window"]
+		}
+		parsedProfile = common.Profile{Key: key, ProfileType: profileType, Query: tmp["query"], Minimize: minimize(profile), Address: tmp["endpoint"], Window: window}
 	} else {
 		if _, found := profile.Spec.Props["endpoint"]; found && profile.Spec.Query != "" {
-			// FIXME - make sure whatever is put in query is safe, secure & valid (regex maybe?)
-			parsedProfile = common.Profile{Key: key, ProfileType: common.ProfileTypeFromText(profile.Spec.KPIType), Query: profile.Spec.Query, Minimize: profile.Spec.Minimize, External: true, Address: profile.Spec.Props["endpoint"]}
-			mon.updateStatus(profile, true, "ok")
-			mon.update <- parsedProfile
+			parsedProfile = common.Profile{Key: key, ProfileType: profileType, Query: profile.Spec.Query, Minimize: minimize(profile), External: true, Address: profile.Spec.Props["endpoint"], Window: profile.Spec.Props["window"]}
 		} else {
-			mon.updateStatus(profile, false, "Both a endpoint and a query need to be defined.")
-			mon.update <- common.Profile{Key: key, ProfileType: common.Obsolete, External: true}
+			mon.unresolved(profile, key, "Both a endpoint and a query need to be defined.")
+			return nil
 		}
 	}
+	if err = ValidateQuery(parsedProfile.Query, parsedProfile.Window, parsedProfile.External); err != nil {
+		mon.unresolved(profile, key, fmt.Sprintf("Invalid query: %v.", err))
+		return nil
+	}
+	mon.updateStatus(profile, true, "ok")
+	mon.update <- parsedProfile
 
 	return nil
 }
 
+// minimize returns if the KPI of a profile should be minimized - in the direction of the profile's type if requested.
+func minimize(profile *v1alpha1.KPIProfile) bool {
+	if profile.Spec.TypeDirection {
+		return common.ProfileTypeFromText(profile.Spec.KPIType).Minimize()
+	}
+	return profile.Spec.Minimize
+}
+
+// unresolved marks a profile as not resolved for the given reason, and makes sure it is no longer used.
+func (mon *KPIProfileMonitor) unresolved(profile *v1alpha1.KPIProfile, key string, reason string) {
+	mon.updateStatus(profile, false, reason)
+	mon.Event(profile, coreV1.EventTypeWarning, ReasonProfileUnresolved, reason)
+	mon.update <- common.Profile{Key: key, ProfileType: common.Obsolete, External: true}
+}
+
 // updateStatus actualUpdates the status of the CRD.
 func (mon *KPIProfileMonitor) updateStatus(profile *v1alpha1.KPIProfile, resolved bool, reason string) {
 	profileCopy := profile.DeepCopy()
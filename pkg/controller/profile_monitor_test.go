@@ -3,6 +3,7 @@ package controller
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -17,6 +18,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
 	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 )
 
@@ -35,6 +37,8 @@ type profileFixture struct {
 	expectedActions      []core.Action
 	actualUpdates        []common.Profile
 	expectedUpdatesTypes []common.ProfileType
+	recorder             *record.FakeRecorder
+	expectedEvents       []string
 }
 
 // newProfileFixture initializes the test struct.
@@ -67,18 +71,16 @@ func (f *profileFixture) newMonitor(stopper chan struct{}) (*KPIProfileMonitor,
 
 	// new monitor
 	informer := informers.NewSharedInformerFactory(f.client, func() time.Duration { return 0 }())
+	cfg := common.MonitorConfig{}
+	cfg.Profile.Queries = "../../artefacts/examples/default_queries.json"
 	mon := NewKPIProfileMonitor(
-		common.MonitorConfig{Profile: struct {
-			Workers int    `json:"workers"`
-			Queries string `json:"queries"`
-		}(struct {
-			Workers int
-			Queries string
-		}{Queries: "../../artefacts/examples/default_queries.json"})},
+		cfg,
 		f.client,
 		informer.Ido().V1alpha1().KPIProfiles(),
 		f.acceptUpdates())
 	mon.profileSynced = func() bool { return true }
+	f.recorder = record.NewFakeRecorder(10)
+	mon.SetEventRecorder(f.recorder)
 
 	for _, f := range f.profileLister {
 		err := informer.Ido().V1alpha1().KPIProfiles().Informer().GetIndexer().Add(f)
@@ -146,10 +148,22 @@ func (f *profileFixture) testSyncHandler(key string) {
 		f.test.Errorf("Number of actual and expected update(s) mismatched: %d - %d.", len(f.actualUpdates), len(f.expectedUpdatesTypes))
 	}
 
+	// check if the expected events were recorded.
+	if len(f.recorder.Events) != len(f.expectedEvents) {
+		f.test.Errorf("Number of actual and expected event(s) mismatched: %d - %d.", len(f.recorder.Events), len(f.expectedEvents))
+	} else {
+		for _, reason := range f.expectedEvents {
+			if event := <-f.recorder.Events; !strings.Contains(event, reason) {
+				f.test.Errorf("Event mismatch: %s - %s.", event, reason)
+			}
+		}
+	}
+
 	// reset!
 	f.actualUpdates = make([]common.Profile, 0)
 	f.expectedActions = make([]core.Action, 0)
 	f.expectedUpdatesTypes = make([]common.ProfileType, 0)
+	f.expectedEvents = make([]string, 0)
 	f.client.ClearActions()
 }
 
@@ -319,7 +333,79 @@ func TestProcessProfileForSanity(t *testing.T) {
 
 	// expecting an obsolete being pushed down.
 	f.expectedUpdatesTypes = append(f.expectedUpdatesTypes, common.ProfileTypeFromText("obsolete"))
+	f.expectedEvents = append(f.expectedEvents, ReasonProfileUnresolved)
 
 	// run
 	f.testSyncHandler("default/my-lat")
 }
+
+// TestProcessProfileQueryTemplateForSanity tests for sanity.
+func TestProcessProfileQueryTemplateForSanity(t *testing.T) {
+	f := newProfileFixture(t)
+
+	// query using named placeholders.
+	profile := newKPIProfile("my-tmpl", "latency", "sum(rate(latency{pod=~\"{{.Pods}}\"}[{{.Window}}]))", true, "https://foo:8080")
+	profile.Spec.Props["window"] = "1m"
+	f.profileLister = append(f.profileLister, profile)
+	f.objects = append(f.objects, profile)
+	f.expectedActions = append(f.expectedActions, core.NewUpdateSubresourceAction(
+		schema.GroupVersionResource{Resource: "kpiprofiles"}, "status", profile.Namespace, profile))
+	f.expectedUpdatesTypes = append(f.expectedUpdatesTypes, common.ProfileTypeFromText("latency"))
+	f.testSyncHandler("default/my-tmpl")
+
+	// unknown placeholder.
+	f = newProfileFixture(t)
+	profile = newKPIProfile("my-tmpl", "latency", "sum(rate(latency{pod=~\"{{.Pod}}\"}[30s]))", true, "https://foo:8080")
+	f.profileLister = append(f.profileLister, profile)
+	f.objects = append(f.objects, profile)
+	f.expectedActions = append(f.expectedActions, core.NewUpdateSubresourceAction(
+		schema.GroupVersionResource{Resource: "kpiprofiles"}, "status", profile.Namespace, profile))
+	f.expectedUpdatesTypes = append(f.expectedUpdatesTypes, common.ProfileTypeFromText("obsolete"))
+	f.expectedEvents = append(f.expectedEvents, ReasonProfileUnresolved+" Invalid query")
+	f.testSyncHandler("default/my-tmpl")
+}
+
+// TestProcessProfileTypesForSanity tests for sanity.
+func TestProcessProfileTypesForSanity(t *testing.T) {
+	f := newProfileFixture(t)
+
+	// unknown types are not resolved.
+	profile := newKPIProfile("my-depth", "backlog_depth", "abc", true, "https://foo:8080")
+	f.profileLister = append(f.profileLister, profile)
+	f.objects = append(f.objects, profile)
+	f.expectedActions = append(f.expectedActions, core.NewUpdateSubresourceAction(
+		schema.GroupVersionResource{Resource: "kpiprofiles"}, "status", profile.Namespace, profile))
+	f.expectedUpdatesTypes = append(f.expectedUpdatesTypes, common.Obsolete)
+	f.expectedEvents = append(f.expectedEvents, ReasonProfileUnresolved+" Unknown profile type 'backlog_depth'.")
+	f.testSyncHandler("default/my-depth")
+
+	// ...once registered they are.
+	backlog, err := common.RegisterProfileType("backlog_depth", true)
+	if err != nil {
+		t.Fatalf("Should have been registered: %v.", err)
+	}
+	f = newProfileFixture(t)
+	f.profileLister = append(f.profileLister, profile)
+	f.objects = append(f.objects, profile)
+	f.expectedActions = append(f.expectedActions, core.NewUpdateSubresourceAction(
+		schema.GroupVersionResource{Resource: "kpiprofiles"}, "status", profile.Namespace, profile))
+	f.expectedUpdatesTypes = append(f.expectedUpdatesTypes, backlog)
+	f.testSyncHandler("default/my-depth")
+
+	// direction can be taken from the type.
+	profile.Spec.TypeDirection = true
+	if !minimize(profile) {
+		t.Error("Backlog depth should be minimized by default.")
+	}
+	profile.Spec.KPIType = "throughput"
+	profile.Spec.Minimize = true
+	if minimize(profile) {
+		t.Error("Throughput should be maximized by default.")
+	}
+	profile.Spec.KPIType = "error_rate"
+	profile.Spec.Minimize = false
+	profile.Spec.TypeDirection = false
+	if minimize(profile) {
+		t.Error("Explicit direction should be used.")
+	}
+}
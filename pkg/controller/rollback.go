@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/intel/intent-driven-orchestration/pkg/common"
+	"github.com/intel/intent-driven-orchestration/pkg/planner"
+
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// settlingPlan holds what is needed to roll back an executed plan - in case the objectives observed once the workload
+// settled are worse than predicted.
+type settlingPlan struct {
+	// snapshot of the target taken before the plan was executed, and applied the one taken right after.
+	snapshot  Snapshot
+	applied   Snapshot
+	predicted common.State
+	plan      []planner.Action
+	// groups of the actuators which applied the plan.
+	groups []string
+	until  time.Time
+}
+
+// snapshot takes a snapshot of the target workload before a plan is executed - if rollbacks are enabled and the
+// planner predicted the state the plan leads to.
+func (c *IntentController) snapshot(desired common.State, predicted *common.State) *Snapshot {
+	if c.cfg.Controller.SettlingPeriod <= 0 || predicted == nil {
+		return nil
+	}
+	res, err := TakeSnapshot(c.clientSet, desired.Intent.TargetKind, desired.Intent.TargetKey)
+	if err != nil {
+		klog.Warningf("Could not take snapshot of %s %s - will not be able to roll back: %v.", desired.Intent.TargetKind, desired.Intent.TargetKey, err)
+		return nil
+	}
+	return &res
+}
+
+// settle lets the workload settle after a plan was applied; the intent is looked at again once the settling period
+// has passed.
+func (c *IntentController) settle(key string, desired common.State, snapshot *Snapshot, predicted *common.State, plan []planner.Action, results []planner.Result) {
+	if snapshot == nil {
+		return
+	}
+	var groups []string
+	for _, result := range results {
+		if result.Status == planner.ResultApplied && result.Group != "" {
+			groups = append(groups, result.Group)
+		}
+	}
+	if len(groups) == 0 {
+		return
+	}
+	applied, err := TakeSnapshot(c.clientSet, desired.Intent.TargetKind, desired.Intent.TargetKey)
+	if err != nil {
+		klog.Warningf("Could not take snapshot of %s %s after executing the plan - will not be able to roll back: %v.", desired.Intent.TargetKind, desired.Intent.TargetKey, err)
+		return
+	}
+	period := time.Duration(c.cfg.Controller.SettlingPeriod) * time.Millisecond
+	c.intentsLock.Lock()
+	c.settling[key] = settlingPlan{snapshot: *snapshot, applied: applied, predicted: *predicted, plan: plan, groups: groups, until: c.clock.Now().Add(period)}
+	c.intentsLock.Unlock()
+	c.queue.AddAfter(key, period)
+}
+
+// checkSettling checks if the last plan executed for an intent is still settling; once settled, the plan is rolled
+// back if the observed objectives are worse than predicted. Returns true if the planning should be skipped.
+func (c *IntentController) checkSettling(key string, current common.State, desired common.State) bool {
+	now := c.clock.Now()
+	c.intentsLock.Lock()
+	pending, ok := c.settling[key]
+	if ok && !now.Before(pending.until) {
+		delete(c.settling, key)
+	}
+	c.intentsLock.Unlock()
+	if !ok {
+		return false
+	}
+	if now.Before(pending.until) {
+		klog.V(2).Infof("Last plan for %s is settling until %s - skipping planning.", key, pending.until)
+		return true
+	}
+	regressed := regressions(current, pending.predicted, c.profiles, c.cfg.Controller.RollbackTolerance)
+	if len(regressed) == 0 {
+		return false
+	}
+	c.rollback(key, current, desired, pending, regressed)
+	return true
+}
+
+// rollback reverts the changes the plan made to the target, records the regression in the knowledge base and marks the
+// intent as degraded. If the target was changed for other reasons since the plan was executed, the rollback is skipped.
+func (c *IntentController) rollback(key string, current common.State, desired common.State, pending settlingPlan, regressed []string) {
+	klog.Warningf("Objective(s) %v of %s worse than predicted - rolling back plan: %v.", regressed, key, pending.plan)
+	err := RestoreSnapshot(c.clientSet, desired.Intent.TargetKind, desired.Intent.TargetKey, pending.snapshot, pending.applied)
+	if errors.Is(err, ErrTargetChanged) {
+		klog.Warningf("Target of %s was changed since the plan was executed - not rolling back plan: %v.", key, pending.plan)
+		c.IntentEvent(desired.Intent, coreV1.EventTypeWarning, ReasonRollbackSkipped, "Skipped rolling back plan %s - objective(s) worse than predicted: %s; but %s %s was changed since the plan was executed.", planSummary(pending.plan), strings.Join(regressed, ", "), desired.Intent.TargetKind, desired.Intent.TargetKey)
+		return
+	} else if err != nil {
+		klog.Errorf("Failed to roll back plan for %s: %v.", key, err)
+		c.IntentEvent(desired.Intent, coreV1.EventTypeWarning, ReasonRollbackFailed, "Failed to roll back plan %s: %v.", planSummary(pending.plan), err)
+		return
+	}
+	common.PlanRollbacks.Inc()
+	if tracer, ok := c.tracer.(RegressionTracer); ok {
+		for _, group := range pending.groups {
+			tracer.TraceRegression(desired.Intent.Key, group, pending.predicted, current)
+		}
+	}
+	c.IntentEvent(desired.Intent, coreV1.EventTypeWarning, ReasonPlanRolledBack, "Rolled back plan %s - objective(s) worse than predicted: %s.", planSummary(pending.plan), strings.Join(regressed, ", "))
+	c.planCache.Put(key)
+	c.updateRollbackStatus(key, desired, regressed)
+}
+
+// regressions returns the objectives whose observed values are worse than predicted by more than the tolerance;
+// objectives which could not be observed or predicted are ignored.
+func regressions(observed common.State, predicted common.State, profiles map[string]common.Profile, tolerance float64) []string {
+	var res []string
+	for key, value := range predicted.Intent.Objectives {
+		actual, ok := observed.Intent.Objectives[key]
+		profile, found := profiles[key]
+		if !ok || !found || actual < 0 || value < 0 {
+			continue
+		}
+		margin := math.Abs(value) * tolerance
+		if (profile.Minimize && actual > value+margin) || (!profile.Minimize && actual < value-margin) {
+			res = append(res, key)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,197 @@
+package controller
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/intel/intent-driven-orchestration/pkg/common"
+	"github.com/intel/intent-driven-orchestration/pkg/planner"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	testingClock "k8s.io/utils/clock/testing"
+)
+
+// predictingPlanner for testing - predicts the given objectives and scales out the target when executing a plan.
+type predictingPlanner struct {
+	dummyPlanner
+	client    kubernetes.Interface
+	predicted map[string]float64
+	lock      *sync.Mutex
+	count     *int
+}
+
+func (d predictingPlanner) CreatePredictedPlan(current common.State, _ common.State, _ map[string]common.Profile) ([]planner.Action, []common.State) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	*d.count++
+	predicted := current.DeepCopy()
+	predicted.Intent.Objectives = d.predicted
+	return []planner.Action{{Name: "scaleOut"}}, []common.State{predicted}
+}
+
+func (d predictingPlanner) ExecutePlan(state common.State, _ []planner.Action) []planner.Result {
+	err := UpdateReplicas(d.client, state.Intent.TargetKind, state.Intent.TargetKey, func(replicas int32) int32 {
+		return replicas + 2
+	})
+	if err != nil {
+		return []planner.Result{{Actuator: "scaleOut", Group: "scaling", Status: planner.ResultFailed, Reason: err.Error()}}
+	}
+	return []planner.Result{{Actuator: "scaleOut", Group: "scaling", Status: planner.ResultApplied}}
+}
+
+func (d predictingPlanner) calls() int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return *d.count
+}
+
+// regressionTracer for testing - reports the groups for which regressions were traced.
+type regressionTracer struct {
+	dummyTracer
+	groups chan string
+}
+
+func (d regressionTracer) TraceRegression(_ string, group string, _ common.State, _ common.State) {
+	d.groups <- group
+}
+
+// Tests for sanity.
+
+// TestRegressionsForSanity tests for sanity.
+func TestRegressionsForSanity(t *testing.T) {
+	profiles := map[string]common.Profile{
+		"p99":   {Key: "p99", ProfileType: common.Latency, Minimize: true},
+		"avail": {Key: "avail", ProfileType: common.Availability},
+	}
+	predicted := common.State{Intent: common.Intent{Objectives: map[string]float64{"p99": 10, "avail": 0.9}}}
+	var tests = []struct {
+		name     string
+		observed map[string]float64
+		expected []string
+	}{
+		{"as predicted", map[string]float64{"p99": 10, "avail": 0.9}, nil},
+		{"better than predicted", map[string]float64{"p99": 5, "avail": 1.0}, nil},
+		{"within tolerance", map[string]float64{"p99": 10.9, "avail": 0.85}, nil},
+		{"latency regressed", map[string]float64{"p99": 11.5, "avail": 0.9}, []string{"p99"}},
+		{"both regressed", map[string]float64{"p99": 20, "avail": 0.5}, []string{"avail", "p99"}},
+		{"not observed", map[string]float64{"p99": -1}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observed := common.State{Intent: common.Intent{Objectives: tt.observed}}
+			if res := regressions(observed, predicted, profiles, 0.1); !reflect.DeepEqual(res, tt.expected) {
+				t.Errorf("Expected %v - got: %v.", tt.expected, res)
+			}
+		})
+	}
+}
+
+// TestRollbackForSanity tests for sanity.
+func TestRollbackForSanity(t *testing.T) {
+	c := newQueueTestController(t, func(cfg *common.ControllerConfig) {
+		cfg.SettlingPeriod = 1000
+		cfg.RollbackTolerance = 0.1
+	})
+	defer c.queue.ShutDown()
+	clock := testingClock.NewFakeClock(time.Now())
+	c.SetClock(clock)
+	tracer := regressionTracer{groups: make(chan string, 1)}
+	c.tracer = tracer
+	p := predictingPlanner{client: c.clientSet, predicted: map[string]float64{"default/availability": 0.99}, lock: &sync.Mutex{}, count: new(int)}
+	c.SetPlanner(p)
+	c.profiles["default/availability"] = common.Profile{Key: "default/availability", ProfileType: common.Availability}
+	key := "default/my-intent"
+	c.intents[key] = common.Intent{Key: key, Priority: 1.0, TargetKey: "default/my-deployment", TargetKind: "Deployment", ActivelyManaged: true, Objectives: map[string]float64{"default/availability": 0.99}}
+
+	// plan is executed & the workload given time to settle.
+	if err := c.processIntent(0, key); err != nil {
+		t.Errorf("Should not have failed: %v.", err)
+	}
+	deployment, _ := c.clientSet.AppsV1().Deployments("default").Get(t.Context(), "my-deployment", metaV1.GetOptions{})
+	if *deployment.Spec.Replicas != 3 {
+		t.Errorf("Expected the plan to be executed - got: %d replicas.", *deployment.Spec.Replicas)
+	}
+	_ = c.processIntent(0, key)
+	if p.calls() != 1 {
+		t.Errorf("Should not plan while the workload settles - got %d calls.", p.calls())
+	}
+
+	// POD keeps failing - so the availability is worse than predicted.
+	c.podErrors["my-deployment-0"] = []common.PodError{{Key: "my-deployment-0", Created: clock.Now().Add(-10 * time.Second), Start: clock.Now().Add(-10 * time.Second), End: time.Now()}}
+	clock.Step(2 * time.Second)
+	if err := c.processIntent(0, key); err != nil {
+		t.Errorf("Should not have failed: %v.", err)
+	}
+	deployment, _ = c.clientSet.AppsV1().Deployments("default").Get(t.Context(), "my-deployment", metaV1.GetOptions{})
+	if *deployment.Spec.Replicas != 1 {
+		t.Errorf("Expected the plan to be rolled back - got: %d replicas.", *deployment.Spec.Replicas)
+	}
+	select {
+	case group := <-tracer.groups:
+		if group != "scaling" {
+			t.Errorf("Expected the regression to be traced for scaling - got: %s.", group)
+		}
+	default:
+		t.Error("Expected the regression to be traced.")
+	}
+	if p.calls() != 1 || !c.planCache.IsIn(key) {
+		t.Errorf("Should not plan right after a rollback - got %d calls.", p.calls())
+	}
+	if _, ok := c.settling[key]; ok {
+		t.Error("Plan should not be settling anymore.")
+	}
+
+	// plans for which the objectives are as predicted are kept.
+	delete(c.podErrors, "my-deployment-0")
+	_ = c.processIntent(0, key)
+	clock.Step(2 * time.Second)
+	observed := common.State{Intent: common.Intent{Objectives: map[string]float64{"default/availability": 1.0}}}
+	if c.checkSettling(key, observed, getDesiredState(c.intents[key], clock.Now())) {
+		t.Error("Should continue planning once the workload settled as predicted.")
+	}
+	deployment, _ = c.clientSet.AppsV1().Deployments("default").Get(t.Context(), "my-deployment", metaV1.GetOptions{})
+	if *deployment.Spec.Replicas != 3 || len(tracer.groups) != 0 {
+		t.Errorf("Expected the plan not to be rolled back - got: %d replicas.", *deployment.Spec.Replicas)
+	}
+	c.inFlight.Wait()
+}
+
+// TestRollbackSkippedForSanity tests for sanity.
+func TestRollbackSkippedForSanity(t *testing.T) {
+	c := newQueueTestController(t, func(cfg *common.ControllerConfig) {
+		cfg.SettlingPeriod = 1000
+	})
+	defer c.queue.ShutDown()
+	recorder := record.NewFakeRecorder(10)
+	c.SetEventRecorder(recorder)
+	clock := testingClock.NewFakeClock(time.Now())
+	c.SetClock(clock)
+	tracer := regressionTracer{groups: make(chan string, 1)}
+	c.tracer = tracer
+	p := predictingPlanner{client: c.clientSet, predicted: map[string]float64{"default/availability": 0.99}, lock: &sync.Mutex{}, count: new(int)}
+	c.SetPlanner(p)
+	c.profiles["default/availability"] = common.Profile{Key: "default/availability", ProfileType: common.Availability}
+	key := "default/my-intent"
+	c.intents[key] = common.Intent{Key: key, Priority: 1.0, TargetKey: "default/my-deployment", TargetKind: "Deployment", ActivelyManaged: true, Objectives: map[string]float64{"default/availability": 0.99}}
+	_ = c.processIntent(0, key)
+
+	// target is scaled by someone else while the workload settles.
+	_ = UpdateReplicas(c.clientSet, KindDeployment, "default/my-deployment", func(replicas int32) int32 {
+		return replicas + 1
+	})
+	c.podErrors["my-deployment-0"] = []common.PodError{{Key: "my-deployment-0", Created: clock.Now().Add(-10 * time.Second), Start: clock.Now().Add(-10 * time.Second), End: time.Now()}}
+	clock.Step(2 * time.Second)
+	_ = c.processIntent(0, key)
+	deployment, _ := c.clientSet.AppsV1().Deployments("default").Get(t.Context(), "my-deployment", metaV1.GetOptions{})
+	if *deployment.Spec.Replicas != 4 || len(tracer.groups) != 0 {
+		t.Errorf("Expected the plan not to be rolled back - got: %d replicas.", *deployment.Spec.Replicas)
+	}
+	if _, ok := eventReasons(collectEvents(recorder, 3))[ReasonRollbackSkipped]; !ok {
+		t.Error("Expected the skipped rollback to be reported.")
+	}
+	c.inFlight.Wait()
+}
@@ -1,16 +1,17 @@
 package controller
 
 import (
-	"context"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/intel/intent-driven-orchestration/pkg/common"
 
-	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	v1 "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 )
 
@@ -25,31 +26,14 @@ func getPods(clientSet kubernetes.Interface,
 	podErrors map[string][]common.PodError) (map[string]common.PodState, map[string]string, map[string]int64, []string) {
 	podStates := map[string]common.PodState{}
 	var hosts []string
-	tmp := strings.Split(targetKey, "/")
-	if len(tmp) <= 1 {
-		// TODO: for future release check if we can use a struct(ns, name) instead of string - check K8s.
-		klog.Errorf("invalid target key")
+	selector, err := GetTargetSelector(clientSet, targetKind, targetKey)
+	if err != nil {
+		klog.Errorf("%s '%s' could not be found: %s.", targetKind, targetKey, err)
 		return nil, nil, nil, nil
 	}
-	var labels *metaV1.LabelSelector
-	if targetKind == "Deployment" {
-		deployment, err := clientSet.AppsV1().Deployments(tmp[0]).Get(context.TODO(), tmp[1], metaV1.GetOptions{})
-		if err != nil {
-			klog.Errorf("Deployment '%s' could not be found: %s.", targetKey, err)
-			return nil, nil, nil, nil
-		}
-		labels = deployment.Spec.Selector
-	} else if targetKind == "ReplicaSet" {
-		rs, err := clientSet.AppsV1().ReplicaSets(tmp[0]).Get(context.TODO(), tmp[1], metaV1.GetOptions{})
-		if err != nil {
-			klog.Errorf("ReplicaSet '%s' could not be found: %s.", targetKey, err)
-			return nil, nil, nil, nil
-		}
-		labels = rs.Spec.Selector
-	}
 	// Will ignore errors as pod list will be empty anyhow.
-	selector, _ := metaV1.LabelSelectorAsSelector(labels)
-	pods, _ := informer.Lister().Pods(tmp[0]).List(selector)
+	namespace, _, _ := splitTargetKey(targetKey)
+	pods, _ := informer.Lister().Pods(namespace).List(selector)
 	containerResources := map[string]int64{}
 	var annotations map[string]string
 	for _, pod := range pods {
@@ -79,11 +63,139 @@ func getPods(clientSet kubernetes.Interface,
 	return podStates, annotations, containerResources, hosts
 }
 
+// podNodeIndex is the name of the index of the PODs by the node they are scheduled on.
+const podNodeIndex = "nodeName"
+
+// indexPodsByNode adds the index of the PODs by the node they are scheduled on to the informer - if not added already.
+func indexPodsByNode(informer v1.PodInformer) {
+	if _, ok := informer.Informer().GetIndexer().GetIndexers()[podNodeIndex]; ok {
+		return
+	}
+	err := informer.Informer().AddIndexers(cache.Indexers{podNodeIndex: func(obj interface{}) ([]string, error) {
+		pod, ok := obj.(*coreV1.Pod)
+		if !ok || pod.Spec.NodeName == "" {
+			return nil, nil
+		}
+		return []string{pod.Spec.NodeName}, nil
+	}})
+	if err != nil {
+		klog.Warningf("Could not index the PODs by node: %v.", err)
+	}
+}
+
+// nodeUsage returns the nodes passing the filter and - per node - the resources requested by the PODs running on them
+// in milli units.
+func nodeUsage(nodeInformer v1.NodeInformer, informer v1.PodInformer, filter func(node *coreV1.Node) bool) ([]*coreV1.Node, nodeResources, error) {
+	nodes, err := nodeInformer.Lister().List(labels.Everything())
+	if err != nil {
+		return nil, nil, err
+	}
+	var res []*coreV1.Node
+	requested := nodeResources{}
+	for _, node := range nodes {
+		if filter != nil && !filter(node) {
+			continue
+		}
+		pods, err := informer.Informer().GetIndexer().ByIndex(podNodeIndex, node.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		res = append(res, node)
+		requested[node.Name] = map[string]int64{}
+		for _, obj := range pods {
+			pod, ok := obj.(*coreV1.Pod)
+			if !ok || pod.Status.Phase == coreV1.PodSucceeded || pod.Status.Phase == coreV1.PodFailed {
+				continue
+			}
+			for _, container := range pod.Spec.Containers {
+				for name, quantity := range container.Resources.Requests {
+					requested[node.Name][name.String()] += quantity.MilliValue()
+				}
+			}
+		}
+	}
+	return res, requested, nil
+}
+
+// podSpec returns the spec of one of the PODs of a target - or an empty one if none could be found.
+func podSpec(informer v1.PodInformer, targetKey string, pods map[string]common.PodState) *coreV1.PodSpec {
+	namespace, _, _ := splitTargetKey(targetKey)
+	for _, name := range sortedKeys(pods) {
+		pod, err := informer.Lister().Pods(namespace).Get(name)
+		if err == nil {
+			return &pod.Spec
+		}
+	}
+	return &coreV1.PodSpec{}
+}
+
+// schedulable checks if PODs with the given spec can be scheduled on a node: the node needs to be uncordoned, match the
+// node selector, and all its NoSchedule & NoExecute taints need to be tolerated.
+func schedulable(node *coreV1.Node, spec *coreV1.PodSpec) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	if !labels.SelectorFromSet(spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return false
+	}
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect != coreV1.TaintEffectNoSchedule && taint.Effect != coreV1.TaintEffectNoExecute {
+			continue
+		}
+		tolerated := false
+		for _, toleration := range spec.Tolerations {
+			if toleration.ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// getNodeCapacity returns the CPU and memory of the nodes the given hosts are, and of those new PODs with the given
+// spec can be scheduled on. Returns nil if the capacity could not be determined.
+func getNodeCapacity(nodeInformer v1.NodeInformer, informer v1.PodInformer, spec *coreV1.PodSpec, hosts []string) map[string]common.NodeCapacity {
+	relevant := map[string]bool{}
+	for _, host := range hosts {
+		relevant[host] = true
+	}
+	nodes, requested, err := nodeUsage(nodeInformer, informer, func(node *coreV1.Node) bool {
+		return relevant[node.Name] || schedulable(node, spec)
+	})
+	if err != nil {
+		klog.Warningf("Could not determine the capacity of the nodes: %v.", err)
+		return nil
+	}
+	res := map[string]common.NodeCapacity{}
+	for _, node := range nodes {
+		cpu, memory := node.Status.Allocatable.Cpu().MilliValue(), node.Status.Allocatable.Memory().MilliValue()
+		usage := requested[node.Name]
+		res[node.Name] = common.NodeCapacity{
+			AllocatableCPU:    cpu,
+			AllocatableMemory: memory,
+			RequestedCPU:      usage["cpu"],
+			RequestedMemory:   usage["memory"],
+			FreeCPU:           cpu - usage["cpu"],
+			FreeMemory:        memory - usage["memory"],
+		}
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
+
 // getCurrentState returns the current state for an objective.
 func getCurrentState(
 	cfg common.ControllerConfig,
 	clientSet kubernetes.Interface,
 	informer v1.PodInformer,
+	nodeInformer v1.NodeInformer,
 	objective common.Intent,
 	podErrors map[string][]common.PodError,
 	profiles map[string]common.Profile) common.State {
@@ -100,7 +212,7 @@ func getCurrentState(
 		if profile.ProfileType == common.ProfileTypeFromText("availability") {
 			currentObjectives[item] = PodSetAvailability(pods)
 		} else {
-			currentObjectives[item] = doQuery(profile, objective)
+			currentObjectives[item] = doQuery(profile, objective, pods)
 		}
 	}
 
@@ -115,21 +227,52 @@ func getCurrentState(
 	state := common.State{
 		Intent: common.Intent{
 			Key:        objective.Key,
+			ParentKey:  objective.ParentKey,
+			ParentKind: objective.ParentKind,
+			UID:        objective.UID,
 			Priority:   objective.Priority,
 			TargetKey:  objective.TargetKey,
 			TargetKind: objective.TargetKind,
 			Objectives: currentObjectives,
-			// For current state we'll not carry the tolerations.
+			// For current state we'll not carry the tolerations; the actuator filters are needed when executing plans.
+			AllowedActuators: objective.AllowedActuators,
+			DeniedActuators:  objective.DeniedActuators,
 		},
 		CurrentPods: pods,
 		CurrentData: data,
 		Resources:   resources,
 		Annotations: annotations,
 	}
+	if pods != nil && nodeInformer != nil {
+		state.Nodes = getNodeCapacity(nodeInformer, informer, podSpec(informer, objective.TargetKey, pods), hosts)
+		state.Headroom = common.Headroom(state.Nodes)
+	}
 	return state
 }
 
-// getDesiredState returns the desired state for an objective.
-func getDesiredState(objective common.Intent) common.State {
-	return common.State{Intent: objective}
+// getDesiredState returns the desired state for an objective - including the tolerances, weights and normalizations for each of the target values. Target values are taken from the schedule windows active at the given point in time.
+func getDesiredState(objective common.Intent, now time.Time) common.State {
+	desired := objective
+	desired.Objectives = make(map[string]float64, len(objective.Objectives))
+	desired.ActiveWindows = make(map[string]string)
+	for k, v := range objective.Objectives {
+		desired.Objectives[k] = v
+		if window, ok := common.ActiveWindow(objective.Schedules[k], now); ok {
+			desired.Objectives[k] = window.Value
+			desired.ActiveWindows[k] = window.Name
+		}
+	}
+	desired.Tolerations = make(map[string]float64, len(objective.Objectives))
+	desired.Weights = make(map[string]float64, len(objective.Objectives))
+	desired.Normalizations = make(map[string]common.Normalization, len(objective.Objectives))
+	for k := range objective.Objectives {
+		// objectives w/o an explicit tolerance need to be met exactly.
+		desired.Tolerations[k] = objective.Tolerations[k]
+		desired.Weights[k] = 1.0
+		if w, ok := objective.Weights[k]; ok && w > 0 {
+			desired.Weights[k] = w
+		}
+		desired.Normalizations[k] = objective.Normalizations[k]
+	}
+	return common.State{Intent: desired}
 }
@@ -71,6 +71,17 @@ func createDummies(targetKind string, selector map[string]string, nPods int) (ru
 				Selector: &metaV1.LabelSelector{MatchLabels: selector},
 			},
 		}
+	} else if targetKind == "StatefulSet" {
+		res = &appsV1.StatefulSet{
+			TypeMeta: metaV1.TypeMeta{APIVersion: appsV1.SchemeGroupVersion.String()},
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      "my-deployment",
+				Namespace: metaV1.NamespaceDefault,
+			},
+			Spec: appsV1.StatefulSetSpec{
+				Selector: &metaV1.LabelSelector{MatchLabels: selector},
+			},
+		}
 	} else {
 		res = &appsV1.ReplicaSet{
 			TypeMeta: metaV1.TypeMeta{APIVersion: appsV1.SchemeGroupVersion.String()},
@@ -105,6 +116,18 @@ func k8sShim(podSet runtime.Object, pods []*coreV1.Pod) (kubernetes.Interface, v
 	return client, informer.Core().V1().Pods()
 }
 
+// nodeShim returns an informer holding the given nodes.
+func nodeShim(nodes ...*coreV1.Node) v1.NodeInformer {
+	informer := informers.NewSharedInformerFactory(fake.NewSimpleClientset(), 0).Core().V1().Nodes()
+	for _, node := range nodes {
+		err := informer.Informer().GetIndexer().Add(node)
+		if err != nil {
+			klog.Fatal(err)
+		}
+	}
+	return informer
+}
+
 // Tests for success.
 
 // TestGetPodsForSuccess tests for success.
@@ -120,7 +143,7 @@ func TestGetDesiredStateForSuccess(_ *testing.T) {
 	objective := common.Intent{
 		Objectives: map[string]float64{"P99compliance": 100.0},
 	}
-	getDesiredState(objective)
+	getDesiredState(objective, time.Now())
 }
 
 // Tests for failure.
@@ -170,6 +193,14 @@ func TestGetPodsForSanity(t *testing.T) {
 	if len(podStates) != len(hosts) {
 		t.Errorf("All results should have the same length: %d, %d.", len(podStates), len(hosts))
 	}
+
+	// StatefulSet.
+	statefulSet, pods := createDummies("StatefulSet", map[string]string{"foo": "bar"}, 2)
+	client, informer = k8sShim(statefulSet, pods)
+	podStates, _, _, hosts = getPods(client, informer, "default/my-deployment", "StatefulSet", podErrors)
+	if len(podStates) != 2 || len(hosts) != 2 {
+		t.Errorf("Expected 2 PODs for the StatefulSet - got: %v.", podStates)
+	}
 }
 
 // TestGetCurrentStateForSanity tests for sanity
@@ -197,6 +228,7 @@ func TestGetCurrentStateForSanity(t *testing.T) {
 			"default/availability": 0.99,
 			"default/no-profile":   100,
 		},
+		DeniedActuators: []string{"rmpod"},
 	}
 	created, _ := time.Parse(time.RFC3339, "2022-02-16T10:00:00Z")
 	start, _ := time.Parse(time.RFC3339, "2022-02-16T11:00:00Z")
@@ -208,7 +240,8 @@ func TestGetCurrentStateForSanity(t *testing.T) {
 		"default/p99latency":   {Query: "", ProfileType: common.ProfileTypeFromText("latency"), Minimize: true},
 		"default/availability": {Query: "", ProfileType: common.ProfileTypeFromText("availability"), Minimize: false},
 	}
-	state := getCurrentState(cfg, client, informer, objective, errors, profiles)
+	indexPodsByNode(informer)
+	state := getCurrentState(cfg, client, informer, nodeShim(constrainedNode("node0", "4")), objective, errors, profiles)
 	if state.CurrentPods["my-deployment-0"].Availability != state.Intent.Objectives["default/availability"] || state.Intent.Objectives["availability"] == 1.0 {
 		t.Errorf("Availability should be set and below 1.0 - was %f", state.Intent.Objectives["default/availability"])
 	}
@@ -221,6 +254,71 @@ func TestGetCurrentStateForSanity(t *testing.T) {
 	if len(state.Resources) != 4 {
 		t.Errorf("Should see resources requests/limits for both containers - found %v", state.Resources)
 	}
+	if len(state.Intent.DeniedActuators) != 1 || state.Intent.DeniedActuators[0] != "rmpod" {
+		t.Errorf("Actuator filters should be carried over - found %v.", state.Intent.DeniedActuators)
+	}
+	if len(state.Nodes) != 1 || state.Headroom.AllocatableCPU != 4000 {
+		t.Errorf("Expected the capacity of the node - found %v.", state.Nodes)
+	}
+}
+
+// TestGetNodeCapacityForSanity tests for sanity.
+func TestGetNodeCapacityForSanity(t *testing.T) {
+	deployment, pods := createDummies("Deployment", map[string]string{"foo": "bar"}, 1)
+	pods[0].Spec.Containers[0].Resources.Requests = coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("1500m")}
+	pods[0].Spec.Containers[1].Resources.Requests = coreV1.ResourceList{coreV1.ResourceMemory: resource.MustParse("1Gi")}
+	_, informer := k8sShim(deployment, pods)
+	indexPodsByNode(informer)
+	spec := pods[0].Spec.DeepCopy()
+
+	// unknown without nodes.
+	if res := getNodeCapacity(nodeShim(), informer, spec, []string{"node0"}); res != nil {
+		t.Errorf("Expected the capacity to be unknown - got: %v.", res)
+	}
+
+	node := constrainedNode("node0", "4")
+	node.Status.Allocatable[coreV1.ResourceMemory] = resource.MustParse("4Gi")
+	cordoned := constrainedNode("node1", "2")
+	cordoned.Spec.Unschedulable = true
+	tainted := constrainedNode("node3", "2")
+	tainted.Labels = map[string]string{"disk": "ssd"}
+	tainted.Spec.Taints = []coreV1.Taint{{Key: "gpu", Effect: coreV1.TaintEffectNoSchedule}}
+	evicting := constrainedNode("node4", "2")
+	evicting.Spec.Taints = []coreV1.Taint{{Key: "maintenance", Effect: coreV1.TaintEffectNoExecute}}
+	preferred := constrainedNode("node5", "2")
+	preferred.Labels = map[string]string{"disk": "ssd"}
+	preferred.Spec.Taints = []coreV1.Taint{{Key: "spot", Effect: coreV1.TaintEffectPreferNoSchedule}}
+	nodes := nodeShim(node, cordoned, constrainedNode("node2", "2"), tainted, evicting, preferred)
+	res := getNodeCapacity(nodes, informer, spec, []string{"node0"})
+	expected := common.NodeCapacity{AllocatableCPU: 4000, AllocatableMemory: 4 * 1024 * 1024 * 1024 * 1000, RequestedCPU: 1500, RequestedMemory: 1024 * 1024 * 1024 * 1000, FreeCPU: 2500, FreeMemory: 3 * 1024 * 1024 * 1024 * 1000}
+	if len(res) != 3 || res["node0"] != expected || res["node2"].FreeCPU != 2000 || res["node5"].FreeCPU != 2000 {
+		t.Errorf("Expected the capacity of the schedulable nodes w/o taints - got: %v.", res)
+	}
+
+	// cordoned nodes are included if the PODs run on them.
+	if res = getNodeCapacity(nodes, informer, spec, []string{"node1"}); len(res) != 4 || res["node1"].FreeCPU != 2000 {
+		t.Errorf("Expected the capacity of the node the PODs run on - got: %v.", res)
+	}
+
+	// tainted nodes are included if the taints are tolerated - nodes need to match the node selector.
+	spec.Tolerations = []coreV1.Toleration{{Key: "gpu", Operator: coreV1.TolerationOpExists, Effect: coreV1.TaintEffectNoSchedule}}
+	spec.NodeSelector = map[string]string{"disk": "ssd"}
+	if res = getNodeCapacity(nodes, informer, spec, nil); len(res) != 2 || res["node3"].FreeCPU != 2000 || res["node5"].FreeCPU != 2000 {
+		t.Errorf("Expected the capacity of the nodes matching the node selector & tolerations - got: %v.", res)
+	}
+	spec.Tolerations = append(spec.Tolerations, coreV1.Toleration{Key: "maintenance", Operator: coreV1.TolerationOpExists})
+	spec.NodeSelector = nil
+	if res = getNodeCapacity(nodes, informer, spec, nil); len(res) != 5 {
+		t.Errorf("Expected the capacity of all uncordoned nodes - got: %v.", res)
+	}
+
+	// spec of the PODs of the target.
+	if res := podSpec(informer, "default/my-deployment", map[string]common.PodState{"my-deployment-0": {}}); len(res.Containers) != 2 {
+		t.Errorf("Expected the spec of the POD - got: %v.", res)
+	}
+	if res := podSpec(informer, "default/my-deployment", nil); len(res.Containers) != 0 {
+		t.Errorf("Expected an empty spec - got: %v.", res)
+	}
 }
 
 // TestGetDesiredStateForSanity test for sanity.
@@ -228,8 +326,43 @@ func TestGetDesiredStateForSanity(t *testing.T) {
 	objective := common.Intent{
 		Objectives: map[string]float64{"foo": 0.1},
 	}
-	res := getDesiredState(objective)
+	res := getDesiredState(objective, time.Now())
 	if res.Intent.Objectives["foo"] != objective.Objectives["foo"] {
 		t.Errorf("This should be equal: %v - %v.", res.Intent.Objectives, objective.Objectives)
 	}
+
+	// tolerances are carried over - objectives w/o one get a tolerance of 0.
+	objective.Objectives["bar"] = 10
+	objective.Tolerations = map[string]float64{"foo": 0.05}
+	res = getDesiredState(objective, time.Now())
+	if len(res.Intent.Tolerations) != 2 || res.Intent.Tolerations["foo"] != 0.05 || res.Intent.Tolerations["bar"] != 0.0 {
+		t.Errorf("Tolerances not carried over: %v.", res.Intent.Tolerations)
+	}
+	objective.Tolerations["foo"] = 0.5
+	if res.Intent.Tolerations["foo"] != 0.05 {
+		t.Error("Desired state should not share the tolerances with the intent.")
+	}
+
+	// weights default to 1.0.
+	objective.Weights = map[string]float64{"foo": 2.5}
+	objective.Normalizations = map[string]common.Normalization{"bar": common.TargetNormalization}
+	res = getDesiredState(objective, time.Now())
+	if res.Intent.Weights["foo"] != 2.5 || res.Intent.Weights["bar"] != 1.0 || res.Intent.Normalizations["bar"] != common.TargetNormalization || res.Intent.Normalizations["foo"] != common.NoNormalization {
+		t.Errorf("Weights & normalizations not carried over: %v - %v.", res.Intent.Weights, res.Intent.Normalizations)
+	}
+
+	// target values are taken from the active schedule window.
+	day, _ := common.ParseScheduleWindow("day", nil, "08:00", "20:00", "UTC", 0.05)
+	objective.Schedules = map[string][]common.ScheduleWindow{"foo": {day}}
+	res = getDesiredState(objective, time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC))
+	if res.Intent.Objectives["foo"] != 0.05 || res.Intent.ActiveWindows["foo"] != "day" || res.Intent.Objectives["bar"] != 10 {
+		t.Errorf("Expected target value from the schedule window - got: %v - %v.", res.Intent.Objectives, res.Intent.ActiveWindows)
+	}
+	if objective.Objectives["foo"] != 0.1 {
+		t.Error("Intent should not be altered.")
+	}
+	res = getDesiredState(objective, time.Date(2024, 5, 6, 22, 0, 0, 0, time.UTC))
+	if res.Intent.Objectives["foo"] != 0.1 || len(res.Intent.ActiveWindows) != 0 {
+		t.Errorf("Expected default target value outside of the schedule window - got: %v - %v.", res.Intent.Objectives, res.Intent.ActiveWindows)
+	}
 }
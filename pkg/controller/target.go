@@ -0,0 +1,797 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/intel/intent-driven-orchestration/pkg/planner"
+
+	jsonpatch "gopkg.in/evanphx/json-patch.v4"
+	appsV1 "k8s.io/api/apps/v1"
+	autoscalingV1 "k8s.io/api/autoscaling/v1"
+	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/scale"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+)
+
+// The kinds of workload resources that are supported natively. Any other resource exposing a scale subresource can be
+// referenced in the form "<resource>.<version>.<group>" - e.g. "rollouts.v1alpha1.argoproj.io".
+const (
+	KindDeployment  = "Deployment"
+	KindReplicaSet  = "ReplicaSet"
+	KindStatefulSet = "StatefulSet"
+	KindDaemonSet   = "DaemonSet"
+)
+
+// ErrNotSupported indicates that an operation is not supported for a kind of workload resource.
+var ErrNotSupported = errors.New("operation not supported for this kind of target")
+
+// splitTargetKey splits a target key of the form namespace/name.
+func splitTargetKey(key string) (string, string, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil || namespace == "" || name == "" {
+		return "", "", fmt.Errorf("invalid target key '%s' - expected namespace/name", key)
+	}
+	return namespace, name, nil
+}
+
+// customResource returns the group version resource for a kind of the form <resource>.<version>.<group>.
+func customResource(kind string) (schema.GroupVersionResource, error) {
+	gvr, _ := schema.ParseResourceArg(kind)
+	if gvr == nil || gvr.Resource == "" || gvr.Version == "" {
+		return schema.GroupVersionResource{}, fmt.Errorf("unknown kind of target: '%s'", kind)
+	}
+	return *gvr, nil
+}
+
+// TargetClient is a client set which also gives access to custom workload resources - through a dynamic client - and
+// their scale subresource - through a scale client.
+type TargetClient struct {
+	kubernetes.Interface
+	dynamic dynamic.Interface
+	scales  scale.ScalesGetter
+}
+
+// NewTargetClient wraps a client set together with the clients to access custom workload resources.
+func NewTargetClient(clientSet kubernetes.Interface, dynamicClient dynamic.Interface, scales scale.ScalesGetter) *TargetClient {
+	return &TargetClient{Interface: clientSet, dynamic: dynamicClient, scales: scales}
+}
+
+// NewTargetClientForConfig initializes a new TargetClient; the scale subresources of custom resources are looked up
+// using the discovery API.
+func NewTargetClientForConfig(config *rest.Config) (*TargetClient, error) {
+	clientSet, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	discoveryClient := memory.NewMemCacheClient(clientSet.Discovery())
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient)
+	scales, err := scale.NewForConfig(config, mapper, dynamic.LegacyAPIPathResolverFunc, scale.NewDiscoveryScaleKindResolver(discoveryClient))
+	if err != nil {
+		return nil, err
+	}
+	return NewTargetClient(clientSet, dynamicClient, scales), nil
+}
+
+// errNoCustomClient indicates that a client set does not give access to custom workload resources.
+var errNoCustomClient = errors.New("client set does not give access to custom resources")
+
+// customClients returns the clients to access custom workload resources - if the client set provides them.
+func customClients(clientSet kubernetes.Interface) (dynamic.Interface, scale.ScalesGetter, error) {
+	client, ok := clientSet.(*TargetClient)
+	if !ok || client.dynamic == nil || client.scales == nil {
+		return nil, nil, errNoCustomClient
+	}
+	return client.dynamic, client.scales, nil
+}
+
+// getScale returns the scale subresource of a custom resource.
+func getScale(clientSet kubernetes.Interface, gvr schema.GroupVersionResource, namespace string, name string) (*autoscalingV1.Scale, error) {
+	_, scales, err := customClients(clientSet)
+	if err != nil {
+		return nil, err
+	}
+	return scales.Scales(namespace).Get(context.TODO(), gvr.GroupResource(), name, metaV1.GetOptions{})
+}
+
+// getCustomResource returns a custom resource as an unstructured object.
+func getCustomResource(clientSet kubernetes.Interface, gvr schema.GroupVersionResource, namespace string, name string) (*unstructured.Unstructured, error) {
+	dynamicClient, _, err := customClients(clientSet)
+	if err != nil {
+		return nil, err
+	}
+	return dynamicClient.Resource(gvr).Namespace(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+}
+
+// GetTargetSelector returns the label selector for the PODs of a target workload resource.
+func GetTargetSelector(clientSet kubernetes.Interface, targetKind string, targetKey string) (labels.Selector, error) {
+	namespace, name, err := splitTargetKey(targetKey)
+	if err != nil {
+		return nil, err
+	}
+	var selector *metaV1.LabelSelector
+	switch targetKind {
+	case KindDeployment:
+		res, err := clientSet.AppsV1().Deployments(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector = res.Spec.Selector
+	case KindReplicaSet:
+		res, err := clientSet.AppsV1().ReplicaSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector = res.Spec.Selector
+	case KindStatefulSet:
+		res, err := clientSet.AppsV1().StatefulSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector = res.Spec.Selector
+	case KindDaemonSet:
+		res, err := clientSet.AppsV1().DaemonSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		selector = res.Spec.Selector
+	default:
+		gvr, err := customResource(targetKind)
+		if err != nil {
+			return nil, err
+		}
+		res, err := getScale(clientSet, gvr, namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		return labels.Parse(res.Status.Selector)
+	}
+	return metaV1.LabelSelectorAsSelector(selector)
+}
+
+// ListTargets returns the keys of all workload resources of a kind in a namespace which match the label selector.
+func ListTargets(clientSet kubernetes.Interface, targetKind string, namespace string, selector labels.Selector) ([]string, error) {
+	opts := metaV1.ListOptions{LabelSelector: selector.String()}
+	var names []string
+	switch targetKind {
+	case KindDeployment:
+		res, err := clientSet.AppsV1().Deployments(namespace).List(context.TODO(), opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range res.Items {
+			names = append(names, item.Name)
+		}
+	case KindReplicaSet:
+		res, err := clientSet.AppsV1().ReplicaSets(namespace).List(context.TODO(), opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range res.Items {
+			names = append(names, item.Name)
+		}
+	case KindStatefulSet:
+		res, err := clientSet.AppsV1().StatefulSets(namespace).List(context.TODO(), opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range res.Items {
+			names = append(names, item.Name)
+		}
+	case KindDaemonSet:
+		res, err := clientSet.AppsV1().DaemonSets(namespace).List(context.TODO(), opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range res.Items {
+			names = append(names, item.Name)
+		}
+	default:
+		gvr, err := customResource(targetKind)
+		if err != nil {
+			return nil, err
+		}
+		dynamicClient, _, err := customClients(clientSet)
+		if err != nil {
+			return nil, err
+		}
+		list, err := dynamicClient.Resource(gvr).Namespace(namespace).List(context.TODO(), opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range list.Items {
+			names = append(names, item.GetName())
+		}
+	}
+	var res []string
+	for _, name := range names {
+		res = append(res, namespace+"/"+name)
+	}
+	return res, nil
+}
+
+// GetPodTemplate returns the POD template of a target workload resource.
+func GetPodTemplate(clientSet kubernetes.Interface, targetKind string, targetKey string) (*coreV1.PodTemplateSpec, error) {
+	res, _, err := getPodTemplate(clientSet, targetKind, targetKey)
+	return res, err
+}
+
+// getPodTemplate returns the POD template and the generation of a target workload resource; for custom resources
+// without a POD template the generation is returned together with ErrNotSupported.
+func getPodTemplate(clientSet kubernetes.Interface, targetKind string, targetKey string) (*coreV1.PodTemplateSpec, int64, error) {
+	namespace, name, err := splitTargetKey(targetKey)
+	if err != nil {
+		return nil, 0, err
+	}
+	switch targetKind {
+	case KindDeployment:
+		res, err := clientSet.AppsV1().Deployments(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, 0, err
+		}
+		return &res.Spec.Template, res.Generation, nil
+	case KindReplicaSet:
+		res, err := clientSet.AppsV1().ReplicaSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, 0, err
+		}
+		return &res.Spec.Template, res.Generation, nil
+	case KindStatefulSet:
+		res, err := clientSet.AppsV1().StatefulSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, 0, err
+		}
+		return &res.Spec.Template, res.Generation, nil
+	case KindDaemonSet:
+		res, err := clientSet.AppsV1().DaemonSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+		if err != nil {
+			return nil, 0, err
+		}
+		return &res.Spec.Template, res.Generation, nil
+	default:
+		gvr, err := customResource(targetKind)
+		if err != nil {
+			return nil, 0, err
+		}
+		obj, err := getCustomResource(clientSet, gvr, namespace, name)
+		if err != nil {
+			return nil, 0, err
+		}
+		template, err := customPodTemplate(obj)
+		return template, obj.GetGeneration(), err
+	}
+}
+
+// customPodTemplate returns the POD template of a custom resource - if it defines one under spec.template.
+func customPodTemplate(obj *unstructured.Unstructured) (*coreV1.PodTemplateSpec, error) {
+	raw, found, err := unstructured.NestedMap(obj.Object, "spec", "template")
+	if err != nil || !found {
+		return nil, ErrNotSupported
+	}
+	template := &coreV1.PodTemplateSpec{}
+	if err = runtime.DefaultUnstructuredConverter.FromUnstructured(raw, template); err != nil {
+		return nil, fmt.Errorf("unable to parse POD template: %v", err)
+	}
+	return template, nil
+}
+
+// change describes the modification of a workload resource: the live object, the modified copy, and how to write the
+// modified copy back.
+type change struct {
+	kind        string
+	namespace   string
+	name        string
+	subresource string
+	original    interface{}
+	modified    interface{}
+	// schema is the Go type of the object used to calculate strategic merge patches; nil for custom resources.
+	schema interface{}
+	write  func() error
+}
+
+// apply writes a change back to the cluster.
+func apply(c change) error {
+	return c.write()
+}
+
+// patch returns the patch which would bring the live object to its modified state - without applying it.
+func (c change) patch() (planner.Patch, error) {
+	res := planner.Patch{Kind: c.kind, Namespace: c.namespace, Name: c.name, Subresource: c.subresource}
+	original, err := json.Marshal(c.original)
+	if err != nil {
+		return res, err
+	}
+	modified, err := json.Marshal(c.modified)
+	if err != nil {
+		return res, err
+	}
+	var data []byte
+	if c.schema != nil {
+		res.Type = string(types.StrategicMergePatchType)
+		data, err = strategicpatch.CreateTwoWayMergePatch(original, modified, c.schema)
+	} else {
+		res.Type = string(types.MergePatchType)
+		data, err = jsonpatch.CreateMergePatch(original, modified)
+	}
+	if err != nil {
+		return res, err
+	}
+	res.Data = string(data)
+	res.Diff, err = diffJSON(original, modified)
+	return res, err
+}
+
+// diffJSON lists the fields which differ between two JSON documents - in the form "<path>: <old> -> <new>".
+func diffJSON(original []byte, modified []byte) ([]string, error) {
+	var before, after interface{}
+	if err := json.Unmarshal(original, &before); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(modified, &after); err != nil {
+		return nil, err
+	}
+	var res []string
+	diffValues("", before, after, &res)
+	return res, nil
+}
+
+// diffValues recursively compares two values decoded from JSON; lists are compared element by element if their length
+// did not change.
+func diffValues(path string, before interface{}, after interface{}, res *[]string) {
+	switch old := before.(type) {
+	case map[string]interface{}:
+		if other, ok := after.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(old)+len(other))
+			for key := range old {
+				keys = append(keys, key)
+			}
+			for key := range other {
+				if _, ok := old[key]; !ok {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				diffValues(strings.TrimPrefix(path+"."+key, "."), old[key], other[key], res)
+			}
+			return
+		}
+	case []interface{}:
+		if other, ok := after.([]interface{}); ok && len(old) == len(other) {
+			for i := range old {
+				diffValues(fmt.Sprintf("%s[%d]", path, i), old[i], other[i], res)
+			}
+			return
+		}
+	}
+	if !reflect.DeepEqual(before, after) {
+		*res = append(*res, fmt.Sprintf("%s: %s -> %s", path, jsonValue(before), jsonValue(after)))
+	}
+}
+
+// jsonValue returns the JSON representation of a value; or <none> if not set.
+func jsonValue(value interface{}) string {
+	if value == nil {
+		return "<none>"
+	}
+	tmp, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(tmp)
+}
+
+// UpdatePodTemplate updates the POD template of a target workload resource using the given function; conflicts are retried.
+func UpdatePodTemplate(clientSet kubernetes.Interface, targetKind string, targetKey string, update func(template *coreV1.PodTemplateSpec)) error {
+	return changePodTemplate(clientSet, targetKind, targetKey, update, apply)
+}
+
+// PodTemplatePatch returns the patch UpdatePodTemplate would apply to the target workload resource - without applying
+// it.
+func PodTemplatePatch(clientSet kubernetes.Interface, targetKind string, targetKey string, update func(template *coreV1.PodTemplateSpec)) (planner.Patch, error) {
+	var res planner.Patch
+	err := changePodTemplate(clientSet, targetKind, targetKey, update, func(c change) error {
+		var err error
+		res, err = c.patch()
+		return err
+	})
+	return res, err
+}
+
+// changePodTemplate modifies the POD template of a target workload resource and hands the change over to the given
+// function.
+func changePodTemplate(clientSet kubernetes.Interface, targetKind string, targetKey string, update func(template *coreV1.PodTemplateSpec), handle func(change) error) error {
+	namespace, name, err := splitTargetKey(targetKey)
+	if err != nil {
+		return err
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		switch targetKind {
+		case KindDeployment:
+			res, err := clientSet.AppsV1().Deployments(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			modified := res.DeepCopy()
+			update(&modified.Spec.Template)
+			return handle(change{kind: targetKind, namespace: namespace, name: name, original: res, modified: modified, schema: appsV1.Deployment{}, write: func() error {
+				_, err := clientSet.AppsV1().Deployments(namespace).Update(context.TODO(), modified, metaV1.UpdateOptions{})
+				return err
+			}})
+		case KindReplicaSet:
+			res, err := clientSet.AppsV1().ReplicaSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			modified := res.DeepCopy()
+			update(&modified.Spec.Template)
+			return handle(change{kind: targetKind, namespace: namespace, name: name, original: res, modified: modified, schema: appsV1.ReplicaSet{}, write: func() error {
+				_, err := clientSet.AppsV1().ReplicaSets(namespace).Update(context.TODO(), modified, metaV1.UpdateOptions{})
+				return err
+			}})
+		case KindStatefulSet:
+			res, err := clientSet.AppsV1().StatefulSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			modified := res.DeepCopy()
+			update(&modified.Spec.Template)
+			return handle(change{kind: targetKind, namespace: namespace, name: name, original: res, modified: modified, schema: appsV1.StatefulSet{}, write: func() error {
+				_, err := clientSet.AppsV1().StatefulSets(namespace).Update(context.TODO(), modified, metaV1.UpdateOptions{})
+				return err
+			}})
+		case KindDaemonSet:
+			res, err := clientSet.AppsV1().DaemonSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			modified := res.DeepCopy()
+			update(&modified.Spec.Template)
+			return handle(change{kind: targetKind, namespace: namespace, name: name, original: res, modified: modified, schema: appsV1.DaemonSet{}, write: func() error {
+				_, err := clientSet.AppsV1().DaemonSets(namespace).Update(context.TODO(), modified, metaV1.UpdateOptions{})
+				return err
+			}})
+		default:
+			gvr, err := customResource(targetKind)
+			if err != nil {
+				return err
+			}
+			obj, err := getCustomResource(clientSet, gvr, namespace, name)
+			if err != nil {
+				return err
+			}
+			template, err := customPodTemplate(obj)
+			if err != nil {
+				return err
+			}
+			update(template)
+			raw, err := runtime.DefaultUnstructuredConverter.ToUnstructured(template)
+			if err != nil {
+				return err
+			}
+			modified := obj.DeepCopy()
+			if err = unstructured.SetNestedMap(modified.Object, raw, "spec", "template"); err != nil {
+				return err
+			}
+			return handle(change{kind: targetKind, namespace: namespace, name: name, original: obj, modified: modified, write: func() error {
+				dynamicClient, _, err := customClients(clientSet)
+				if err != nil {
+					return err
+				}
+				_, err = dynamicClient.Resource(gvr).Namespace(namespace).Update(context.TODO(), modified, metaV1.UpdateOptions{})
+				return err
+			}})
+		}
+	})
+}
+
+// UpdateReplicas sets the number of replicas of a target workload resource to the value returned by the given function; conflicts are retried.
+func UpdateReplicas(clientSet kubernetes.Interface, targetKind string, targetKey string, update func(replicas int32) int32) error {
+	return changeReplicas(clientSet, targetKind, targetKey, update, apply)
+}
+
+// ReplicasPatch returns the patch UpdateReplicas would apply to the target workload resource - without applying it.
+func ReplicasPatch(clientSet kubernetes.Interface, targetKind string, targetKey string, update func(replicas int32) int32) (planner.Patch, error) {
+	var res planner.Patch
+	err := changeReplicas(clientSet, targetKind, targetKey, update, func(c change) error {
+		var err error
+		res, err = c.patch()
+		return err
+	})
+	return res, err
+}
+
+// changeReplicas modifies the number of replicas of a target workload resource and hands the change over to the given
+// function.
+func changeReplicas(clientSet kubernetes.Interface, targetKind string, targetKey string, update func(replicas int32) int32, handle func(change) error) error {
+	namespace, name, err := splitTargetKey(targetKey)
+	if err != nil {
+		return err
+	}
+	// getReplicas returns the current number of replicas - defaults to 1 if not set.
+	getReplicas := func(replicas *int32) int32 {
+		if replicas == nil {
+			return 1
+		}
+		return *replicas
+	}
+	// newReplicas calculates & checks the new number of replicas.
+	newReplicas := func(current int32) (int32, error) {
+		res := update(current)
+		if res < 1 {
+			return 0, fmt.Errorf("invalid number of replicas for '%s': %d", targetKey, res)
+		}
+		return res, nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		switch targetKind {
+		case KindDeployment:
+			res, err := clientSet.AppsV1().Deployments(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			replicas, err := newReplicas(getReplicas(res.Spec.Replicas))
+			if err != nil {
+				return err
+			}
+			modified := res.DeepCopy()
+			modified.Spec.Replicas = &replicas
+			return handle(change{kind: targetKind, namespace: namespace, name: name, original: res, modified: modified, schema: appsV1.Deployment{}, write: func() error {
+				_, err := clientSet.AppsV1().Deployments(namespace).Update(context.TODO(), modified, metaV1.UpdateOptions{})
+				return err
+			}})
+		case KindReplicaSet:
+			res, err := clientSet.AppsV1().ReplicaSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			replicas, err := newReplicas(getReplicas(res.Spec.Replicas))
+			if err != nil {
+				return err
+			}
+			modified := res.DeepCopy()
+			modified.Spec.Replicas = &replicas
+			return handle(change{kind: targetKind, namespace: namespace, name: name, original: res, modified: modified, schema: appsV1.ReplicaSet{}, write: func() error {
+				_, err := clientSet.AppsV1().ReplicaSets(namespace).Update(context.TODO(), modified, metaV1.UpdateOptions{})
+				return err
+			}})
+		case KindStatefulSet:
+			res, err := clientSet.AppsV1().StatefulSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			replicas, err := newReplicas(getReplicas(res.Spec.Replicas))
+			if err != nil {
+				return err
+			}
+			modified := res.DeepCopy()
+			modified.Spec.Replicas = &replicas
+			return handle(change{kind: targetKind, namespace: namespace, name: name, original: res, modified: modified, schema: appsV1.StatefulSet{}, write: func() error {
+				_, err := clientSet.AppsV1().StatefulSets(namespace).Update(context.TODO(), modified, metaV1.UpdateOptions{})
+				return err
+			}})
+		case KindDaemonSet:
+			return ErrNotSupported
+		default:
+			gvr, err := customResource(targetKind)
+			if err != nil {
+				return err
+			}
+			res, err := getScale(clientSet, gvr, namespace, name)
+			if err != nil {
+				return err
+			}
+			replicas, err := newReplicas(res.Spec.Replicas)
+			if err != nil {
+				return err
+			}
+			modified := res.DeepCopy()
+			modified.Spec.Replicas = replicas
+			return handle(change{kind: targetKind, namespace: namespace, name: name, subresource: "scale", original: res, modified: modified, write: func() error {
+				_, scales, err := customClients(clientSet)
+				if err != nil {
+					return err
+				}
+				_, err = scales.Scales(namespace).Update(context.TODO(), gvr.GroupResource(), modified, metaV1.UpdateOptions{})
+				return err
+			}})
+		}
+	})
+}
+
+// Snapshot holds the POD template, the number of replicas and the generation of a target workload resource - so changes
+// to them can be reverted.
+type Snapshot struct {
+	// Template is nil for custom resources which do not define a POD template.
+	Template *coreV1.PodTemplateSpec
+	// Replicas is 0 for workload resources which are not scalable.
+	Replicas   int32
+	Generation int64
+}
+
+// ErrTargetChanged indicates that a target workload resource was changed since a snapshot was taken.
+var ErrTargetChanged = errors.New("target was changed since the snapshot was taken")
+
+// TakeSnapshot returns the POD template, the number of replicas and the generation of a target workload resource.
+func TakeSnapshot(clientSet kubernetes.Interface, targetKind string, targetKey string) (Snapshot, error) {
+	var res Snapshot
+	template, generation, err := getPodTemplate(clientSet, targetKind, targetKey)
+	if err != nil && !errors.Is(err, ErrNotSupported) {
+		return res, err
+	} else if err == nil {
+		res.Template = template.DeepCopy()
+	}
+	res.Generation = generation
+	if !IsScalable(targetKind) {
+		return res, nil
+	}
+	err = changeReplicas(clientSet, targetKind, targetKey, func(replicas int32) int32 {
+		res.Replicas = replicas
+		return replicas
+	}, func(change) error {
+		return nil
+	})
+	return res, err
+}
+
+// RestoreSnapshot reverts the changes made to a target workload resource between two snapshots - taken before and
+// after a plan was executed. Only the resources of the containers, the annotations of the POD template and the number
+// of replicas changed in between are reverted. Returns ErrTargetChanged - without reverting anything - if the target
+// was changed since the second snapshot was taken.
+func RestoreSnapshot(clientSet kubernetes.Interface, targetKind string, targetKey string, before Snapshot, after Snapshot) error {
+	live, err := TakeSnapshot(clientSet, targetKind, targetKey)
+	if err != nil {
+		return err
+	}
+	if live.Generation != after.Generation || live.Replicas != after.Replicas || !equality.Semantic.DeepEqual(live.Template, after.Template) {
+		return ErrTargetChanged
+	}
+	if before.Template != nil && after.Template != nil && !equality.Semantic.DeepEqual(before.Template, after.Template) {
+		err = UpdatePodTemplate(clientSet, targetKind, targetKey, func(template *coreV1.PodTemplateSpec) {
+			revertTemplate(template, before.Template, after.Template)
+		})
+		if err != nil {
+			return fmt.Errorf("unable to restore the POD template: %w", err)
+		}
+	}
+	if before.Replicas > 0 && before.Replicas != after.Replicas {
+		err = UpdateReplicas(clientSet, targetKind, targetKey, func(_ int32) int32 {
+			return before.Replicas
+		})
+		if err != nil {
+			return fmt.Errorf("unable to restore the replicas: %w", err)
+		}
+	}
+	return nil
+}
+
+// revertTemplate reverts the resources of the containers and the annotations of a POD template which differ between
+// the two given templates to those of the first one.
+func revertTemplate(template *coreV1.PodTemplateSpec, before *coreV1.PodTemplateSpec, after *coreV1.PodTemplateSpec) {
+	containers := func(spec coreV1.PodSpec) map[string]coreV1.Container {
+		res := make(map[string]coreV1.Container, len(spec.Containers))
+		for _, container := range spec.Containers {
+			res[container.Name] = container
+		}
+		return res
+	}
+	old, changed := containers(before.Spec), containers(after.Spec)
+	for i, container := range template.Spec.Containers {
+		previous, ok := old[container.Name]
+		if !ok || equality.Semantic.DeepEqual(previous.Resources, changed[container.Name].Resources) {
+			continue
+		}
+		template.Spec.Containers[i].Resources = *previous.Resources.DeepCopy()
+	}
+	keys := map[string]bool{}
+	for key := range before.Annotations {
+		keys[key] = true
+	}
+	for key := range after.Annotations {
+		keys[key] = true
+	}
+	for key := range keys {
+		value, ok := before.Annotations[key]
+		other, found := after.Annotations[key]
+		if ok == found && value == other {
+			continue
+		}
+		if !ok {
+			delete(template.Annotations, key)
+			continue
+		}
+		if template.Annotations == nil {
+			template.Annotations = map[string]string{}
+		}
+		template.Annotations[key] = value
+	}
+}
+
+// RolledOut checks if the latest change to a target workload resource has been rolled out - i.e. all replicas run the
+// latest POD template and are available. Custom resources are rolled out once their scale subresource reports the
+// desired number of replicas.
+func RolledOut(clientSet kubernetes.Interface, targetKind string, targetKey string) (bool, error) {
+	namespace, name, err := splitTargetKey(targetKey)
+	if err != nil {
+		return false, err
+	}
+	// desired returns the desired number of replicas - defaults to 1 if not set.
+	desired := func(replicas *int32) int32 {
+		if replicas == nil {
+			return 1
+		}
+		return *replicas
+	}
+	switch targetKind {
+	case KindDeployment:
+		res, err := clientSet.AppsV1().Deployments(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		replicas := desired(res.Spec.Replicas)
+		return res.Status.ObservedGeneration >= res.Generation && res.Status.Replicas == replicas &&
+			res.Status.UpdatedReplicas == replicas && res.Status.AvailableReplicas == replicas, nil
+	case KindReplicaSet:
+		res, err := clientSet.AppsV1().ReplicaSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		replicas := desired(res.Spec.Replicas)
+		return res.Status.ObservedGeneration >= res.Generation && res.Status.Replicas == replicas &&
+			res.Status.AvailableReplicas == replicas, nil
+	case KindStatefulSet:
+		res, err := clientSet.AppsV1().StatefulSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		replicas := desired(res.Spec.Replicas)
+		return res.Status.ObservedGeneration >= res.Generation && res.Status.CurrentRevision == res.Status.UpdateRevision &&
+			res.Status.UpdatedReplicas == replicas && res.Status.AvailableReplicas == replicas, nil
+	case KindDaemonSet:
+		res, err := clientSet.AppsV1().DaemonSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return res.Status.ObservedGeneration >= res.Generation &&
+			res.Status.UpdatedNumberScheduled == res.Status.DesiredNumberScheduled &&
+			res.Status.NumberAvailable == res.Status.DesiredNumberScheduled, nil
+	default:
+		gvr, err := customResource(targetKind)
+		if err != nil {
+			return false, err
+		}
+		res, err := getScale(clientSet, gvr, namespace, name)
+		if err != nil {
+			return false, err
+		}
+		return res.Status.Replicas == res.Spec.Replicas, nil
+	}
+}
+
+// IsScalable returns true if the number of replicas of a kind of workload resource can be changed.
+func IsScalable(targetKind string) bool {
+	return targetKind != KindDaemonSet
+}
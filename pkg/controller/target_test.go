@@ -0,0 +1,383 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+
+	appsV1 "k8s.io/api/apps/v1"
+	autoscalingV1 "k8s.io/api/autoscaling/v1"
+	coreV1 "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	scaleFake "k8s.io/client-go/scale/fake"
+	core "k8s.io/client-go/testing"
+)
+
+// rolloutKind is the kind of the custom resource used for testing.
+const rolloutKind = "rollouts.v1alpha1.argoproj.io"
+
+// newTestWorkloads returns a set of workload resources for testing.
+func newTestWorkloads() []runtime.Object {
+	replicas := int32(2)
+	selector := &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "foo"}}
+	template := coreV1.PodTemplateSpec{
+		ObjectMeta: metaV1.ObjectMeta{Labels: map[string]string{"app": "foo"}},
+		Spec:       coreV1.PodSpec{Containers: []coreV1.Container{{Name: "foo"}}},
+	}
+	meta := metaV1.ObjectMeta{Name: "foo", Namespace: metaV1.NamespaceDefault, Labels: map[string]string{"tier": "web"}}
+	return []runtime.Object{
+		&appsV1.Deployment{ObjectMeta: meta, Spec: appsV1.DeploymentSpec{Replicas: &replicas, Selector: selector, Template: template}},
+		&appsV1.ReplicaSet{ObjectMeta: meta, Spec: appsV1.ReplicaSetSpec{Replicas: &replicas, Selector: selector, Template: template}},
+		&appsV1.StatefulSet{ObjectMeta: meta, Spec: appsV1.StatefulSetSpec{Replicas: &replicas, Selector: selector, Template: template}},
+		&appsV1.DaemonSet{ObjectMeta: meta, Spec: appsV1.DaemonSetSpec{Selector: selector, Template: template}},
+	}
+}
+
+// rolloutResource is the group version resource of the custom resource used for testing.
+var rolloutResource = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}
+
+// rolloutScales mocks the scale subresource of the custom resource used for testing.
+type rolloutScales struct {
+	scaleFake.FakeScaleClient
+	replicas int32
+	conflict bool
+}
+
+// newRolloutShim returns a client set giving access to a custom resource with a scale subresource - and to another one
+// not matching the tier=web label selector.
+func newRolloutShim(withTemplate bool) (*TargetClient, *rolloutScales) {
+	spec := map[string]interface{}{"replicas": int64(2)}
+	if withTemplate {
+		spec["template"] = map[string]interface{}{
+			"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "foo"}},
+			"spec":     map[string]interface{}{"containers": []interface{}{map[string]interface{}{"name": "foo"}}},
+		}
+	}
+	rollout := func(name string, labels map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "argoproj.io/v1alpha1",
+			"kind":       "Rollout",
+			"metadata":   map[string]interface{}{"name": name, "namespace": "default", "labels": labels},
+			"spec":       spec,
+		}}
+	}
+	dynamicClient := dynamicFake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{rolloutResource: "RolloutList"},
+		rollout("my-rollout", map[string]interface{}{"tier": "web"}), rollout("other-rollout", nil))
+	scales := &rolloutScales{replicas: 2}
+	scales.AddReactor("get", rolloutResource.Resource, func(action core.Action) (bool, runtime.Object, error) {
+		if name := action.(core.GetAction).GetName(); name != "my-rollout" {
+			return true, nil, apiErrors.NewNotFound(rolloutResource.GroupResource(), name)
+		}
+		return true, &autoscalingV1.Scale{
+			Spec:   autoscalingV1.ScaleSpec{Replicas: scales.replicas},
+			Status: autoscalingV1.ScaleStatus{Replicas: scales.replicas, Selector: "app=foo"},
+		}, nil
+	})
+	scales.AddReactor("update", rolloutResource.Resource, func(action core.Action) (bool, runtime.Object, error) {
+		if scales.conflict {
+			scales.conflict = false
+			return true, nil, apiErrors.NewConflict(rolloutResource.GroupResource(), "my-rollout", errors.New("object was modified"))
+		}
+		res := action.(core.UpdateAction).GetObject().(*autoscalingV1.Scale)
+		scales.replicas = res.Spec.Replicas
+		return true, res, nil
+	})
+	return NewTargetClient(fake.NewSimpleClientset(), dynamicClient, scales), scales
+}
+
+// Tests for success.
+
+// TestGetTargetSelectorForSuccess tests for success.
+func TestGetTargetSelectorForSuccess(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestWorkloads()...)
+	for _, kind := range []string{KindDeployment, KindReplicaSet, KindStatefulSet, KindDaemonSet} {
+		selector, err := GetTargetSelector(client, kind, "default/foo")
+		if err != nil || selector.String() != "app=foo" {
+			t.Errorf("Expected selector for %s - got: %v - %v.", kind, selector, err)
+		}
+	}
+}
+
+// TestGetPodTemplateForSuccess tests for success.
+func TestGetPodTemplateForSuccess(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestWorkloads()...)
+	for _, kind := range []string{KindDeployment, KindReplicaSet, KindStatefulSet, KindDaemonSet} {
+		template, err := GetPodTemplate(client, kind, "default/foo")
+		if err != nil || template.Spec.Containers[0].Name != "foo" {
+			t.Errorf("Expected template for %s - got: %v - %v.", kind, template, err)
+		}
+	}
+}
+
+// TestUpdateReplicasForSuccess tests for success.
+func TestUpdateReplicasForSuccess(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestWorkloads()...)
+	for _, kind := range []string{KindDeployment, KindReplicaSet, KindStatefulSet} {
+		err := UpdateReplicas(client, kind, "default/foo", func(replicas int32) int32 {
+			return replicas + 1
+		})
+		if err != nil {
+			t.Errorf("Should have been able to scale %s: %v.", kind, err)
+		}
+	}
+}
+
+// TestListTargetsForSuccess tests for success.
+func TestListTargetsForSuccess(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestWorkloads()...)
+	for _, kind := range []string{KindDeployment, KindReplicaSet, KindStatefulSet, KindDaemonSet} {
+		res, err := ListTargets(client, kind, "default", labels.SelectorFromSet(map[string]string{"tier": "web"}))
+		if err != nil || len(res) != 1 || res[0] != "default/foo" {
+			t.Errorf("Expected one target for %s - got: %v - %v.", kind, res, err)
+		}
+	}
+}
+
+// Tests for failure.
+
+// TestTargetsForFailure tests for failure.
+func TestTargetsForFailure(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestWorkloads()...)
+	noop := func(_ *coreV1.PodTemplateSpec) {}
+	plusOne := func(replicas int32) int32 { return replicas + 1 }
+
+	// invalid keys & unknown kinds.
+	for _, item := range [][2]string{{KindDeployment, "foo"}, {KindDeployment, "default/"}, {"CronJob", "default/foo"}, {"foo.bar", "default/foo"}} {
+		if _, err := GetTargetSelector(client, item[0], item[1]); err == nil {
+			t.Errorf("Expected error for: %v.", item)
+		}
+		if _, err := GetPodTemplate(client, item[0], item[1]); err == nil {
+			t.Errorf("Expected error for: %v.", item)
+		}
+		if err := UpdatePodTemplate(client, item[0], item[1], noop); err == nil {
+			t.Errorf("Expected error for: %v.", item)
+		}
+		if err := UpdateReplicas(client, item[0], item[1], plusOne); err == nil {
+			t.Errorf("Expected error for: %v.", item)
+		}
+	}
+
+	// non existing objects.
+	for _, kind := range []string{KindDeployment, KindReplicaSet, KindStatefulSet, KindDaemonSet} {
+		if _, err := GetTargetSelector(client, kind, "default/bar"); err == nil {
+			t.Errorf("Expected error for: %s.", kind)
+		}
+		if err := UpdatePodTemplate(client, kind, "default/bar", noop); err == nil {
+			t.Errorf("Expected error for: %s.", kind)
+		}
+	}
+
+	// unknown kinds cannot be listed.
+	if _, err := ListTargets(client, "CronJob", "default", labels.Everything()); err == nil {
+		t.Error("Expected error for unknown kind.")
+	}
+
+	// DaemonSets cannot be scaled.
+	if err := UpdateReplicas(client, KindDaemonSet, "default/foo", plusOne); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Expected not supported error - got: %v.", err)
+	}
+
+	// replicas need to be positive.
+	if err := UpdateReplicas(client, KindDeployment, "default/foo", func(_ int32) int32 { return 0 }); err == nil {
+		t.Error("Expected error for 0 replicas.")
+	}
+
+	// custom resources need a client set giving access to them.
+	if _, err := GetTargetSelector(client, rolloutKind, "default/my-rollout"); !errors.Is(err, errNoCustomClient) {
+		t.Errorf("Expected error for missing custom resource clients - got: %v.", err)
+	}
+	if _, err := ListTargets(client, rolloutKind, "default", labels.Everything()); !errors.Is(err, errNoCustomClient) {
+		t.Errorf("Expected error for missing custom resource clients - got: %v.", err)
+	}
+
+	// custom resource w/o a POD template.
+	crClient, _ := newRolloutShim(false)
+	if _, err := GetPodTemplate(crClient, rolloutKind, "default/my-rollout"); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Expected not supported error - got: %v.", err)
+	}
+	if _, err := GetTargetSelector(crClient, rolloutKind, "default/foo"); err == nil {
+		t.Error("Expected error for non existing custom resource.")
+	}
+}
+
+// Tests for sanity.
+
+// TestUpdatePodTemplateForSanity tests for sanity.
+func TestUpdatePodTemplateForSanity(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestWorkloads()...)
+	for _, kind := range []string{KindDeployment, KindReplicaSet, KindStatefulSet, KindDaemonSet} {
+		err := UpdatePodTemplate(client, kind, "default/foo", func(template *coreV1.PodTemplateSpec) {
+			template.Annotations = map[string]string{"foo": kind}
+		})
+		if err != nil {
+			t.Errorf("Should have been able to update %s: %v.", kind, err)
+		}
+		template, _ := GetPodTemplate(client, kind, "default/foo")
+		if template.Annotations["foo"] != kind {
+			t.Errorf("Template of %s not updated: %v.", kind, template)
+		}
+	}
+}
+
+// TestUpdateReplicasForSanity tests for sanity.
+func TestUpdateReplicasForSanity(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestWorkloads()...)
+	_ = UpdateReplicas(client, KindStatefulSet, "default/foo", func(replicas int32) int32 {
+		return replicas + 2
+	})
+	res, _ := client.AppsV1().StatefulSets("default").Get(t.Context(), "foo", metaV1.GetOptions{})
+	if *res.Spec.Replicas != 4 {
+		t.Errorf("Expected 4 replicas - got: %d.", *res.Spec.Replicas)
+	}
+}
+
+// TestPatchesForSanity tests for sanity.
+func TestPatchesForSanity(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestWorkloads()...)
+	patch, err := ReplicasPatch(client, KindDeployment, "default/foo", func(replicas int32) int32 {
+		return replicas + 1
+	})
+	if err != nil || patch.Type != "application/strategic-merge-patch+json" || patch.Data != `{"spec":{"replicas":3}}` {
+		t.Errorf("Unexpected patch: %v - %v.", patch, err)
+	}
+	if len(patch.Diff) != 1 || patch.Diff[0] != "spec.replicas: 2 -> 3" {
+		t.Errorf("Unexpected diff: %v.", patch.Diff)
+	}
+
+	patch, err = PodTemplatePatch(client, KindStatefulSet, "default/foo", func(template *coreV1.PodTemplateSpec) {
+		template.Spec.Containers[0].Image = "bar"
+	})
+	if err != nil || patch.Kind != KindStatefulSet || patch.Namespace != "default" || patch.Name != "foo" {
+		t.Errorf("Unexpected patch: %v - %v.", patch, err)
+	}
+	if len(patch.Diff) != 1 || patch.Diff[0] != `spec.template.spec.containers[0].image: <none> -> "bar"` {
+		t.Errorf("Unexpected diff: %v.", patch.Diff)
+	}
+
+	// nothing got applied.
+	res, _ := client.AppsV1().Deployments("default").Get(t.Context(), "foo", metaV1.GetOptions{})
+	template, _ := GetPodTemplate(client, KindStatefulSet, "default/foo")
+	if *res.Spec.Replicas != 2 || template.Spec.Containers[0].Image != "" {
+		t.Errorf("Dry run should not have changed the workloads: %v - %v.", res, template)
+	}
+
+	// custom resources.
+	rollouts, scales := newRolloutShim(false)
+	patch, err = ReplicasPatch(rollouts, rolloutKind, "default/my-rollout", func(replicas int32) int32 {
+		return replicas + 1
+	})
+	if err != nil || patch.Subresource != "scale" || patch.Data != `{"spec":{"replicas":3}}` || scales.replicas != 2 {
+		t.Errorf("Unexpected patch: %v - %v.", patch, err)
+	}
+}
+
+// TestCustomResourceTargetForSanity tests for sanity.
+func TestCustomResourceTargetForSanity(t *testing.T) {
+	client, scales := newRolloutShim(true)
+
+	selector, err := GetTargetSelector(client, rolloutKind, "default/my-rollout")
+	if err != nil || selector.String() != "app=foo" {
+		t.Errorf("Expected selector from scale subresource - got: %v - %v.", selector, err)
+	}
+
+	// scale - including a conflict which should be retried.
+	scales.conflict = true
+	err = UpdateReplicas(client, rolloutKind, "default/my-rollout", func(replicas int32) int32 {
+		return replicas + 1
+	})
+	if err != nil || scales.replicas != 3 || scales.conflict {
+		t.Errorf("Expected 3 replicas - got: %d - %v.", scales.replicas, err)
+	}
+	if done, err := RolledOut(client, rolloutKind, "default/my-rollout"); err != nil || !done {
+		t.Errorf("Expected the custom resource to be rolled out - got: %v - %v.", done, err)
+	}
+
+	// POD template.
+	err = UpdatePodTemplate(client, rolloutKind, "default/my-rollout", func(template *coreV1.PodTemplateSpec) {
+		template.Spec.Containers[0].Image = "bar"
+	})
+	if err != nil {
+		t.Errorf("Should have been able to update template: %v.", err)
+	}
+	template, err := GetPodTemplate(client, rolloutKind, "default/my-rollout")
+	if err != nil || template.Spec.Containers[0].Image != "bar" || template.Labels["app"] != "foo" {
+		t.Errorf("Template not updated as expected: %v - %v.", template, err)
+	}
+}
+
+// TestListTargetsForSanity tests for sanity.
+func TestListTargetsForSanity(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestWorkloads()...)
+	res, err := ListTargets(client, KindDeployment, "default", labels.SelectorFromSet(map[string]string{"tier": "db"}))
+	if err != nil || len(res) != 0 {
+		t.Errorf("Expected no targets - got: %v - %v.", res, err)
+	}
+	res, err = ListTargets(client, KindDeployment, "other", labels.Everything())
+	if err != nil || len(res) != 0 {
+		t.Errorf("Expected no targets in other namespace - got: %v - %v.", res, err)
+	}
+
+	// custom resources.
+	crClient, _ := newRolloutShim(false)
+	res, err = ListTargets(crClient, rolloutKind, "default", labels.SelectorFromSet(map[string]string{"tier": "web"}))
+	if err != nil || len(res) != 1 || res[0] != "default/my-rollout" {
+		t.Errorf("Expected custom resource as target - got: %v - %v.", res, err)
+	}
+}
+
+// TestSnapshotForSanity tests for sanity.
+func TestSnapshotForSanity(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestWorkloads()...)
+	snapshot, err := TakeSnapshot(client, KindDeployment, "default/foo")
+	if err != nil || snapshot.Replicas != 2 || snapshot.Template == nil {
+		t.Fatalf("Should have taken a snapshot - got: %v - %v.", snapshot, err)
+	}
+	_ = UpdatePodTemplate(client, KindDeployment, "default/foo", func(template *coreV1.PodTemplateSpec) {
+		template.Spec.Containers[0].Image = "bar"
+		template.Spec.Containers[0].Resources.Requests = coreV1.ResourceList{coreV1.ResourceCPU: resource.MustParse("500m")}
+		template.Annotations = map[string]string{"foo": "bar"}
+	})
+	_ = UpdateReplicas(client, KindDeployment, "default/foo", func(replicas int32) int32 {
+		return replicas + 3
+	})
+	applied, _ := TakeSnapshot(client, KindDeployment, "default/foo")
+	if err = RestoreSnapshot(client, KindDeployment, "default/foo", snapshot, applied); err != nil {
+		t.Errorf("Should have restored the snapshot: %v.", err)
+	}
+	// only the resources, annotations & replicas are reverted.
+	res, _ := client.AppsV1().Deployments("default").Get(t.Context(), "foo", metaV1.GetOptions{})
+	if *res.Spec.Replicas != 2 || len(res.Spec.Template.Spec.Containers[0].Resources.Requests) != 0 || len(res.Spec.Template.Annotations) != 0 ||
+		res.Spec.Template.Spec.Containers[0].Image != "bar" {
+		t.Errorf("Expected the changes to be reverted - got: %v.", res.Spec)
+	}
+
+	// targets changed since are not reverted.
+	_ = UpdateReplicas(client, KindDeployment, "default/foo", func(replicas int32) int32 {
+		return replicas + 3
+	})
+	applied, _ = TakeSnapshot(client, KindDeployment, "default/foo")
+	res, _ = client.AppsV1().Deployments("default").Get(t.Context(), "foo", metaV1.GetOptions{})
+	res.Generation++
+	_, _ = client.AppsV1().Deployments("default").Update(t.Context(), res, metaV1.UpdateOptions{})
+	if err = RestoreSnapshot(client, KindDeployment, "default/foo", snapshot, applied); !errors.Is(err, ErrTargetChanged) {
+		t.Errorf("Expected the target to have changed - got: %v.", err)
+	}
+	res, _ = client.AppsV1().Deployments("default").Get(t.Context(), "foo", metaV1.GetOptions{})
+	if *res.Spec.Replicas != 5 {
+		t.Errorf("Expected the replicas not to be reverted - got: %d.", *res.Spec.Replicas)
+	}
+
+	// replicas of DaemonSets are not part of the snapshot.
+	snapshot, err = TakeSnapshot(client, KindDaemonSet, "default/foo")
+	if err != nil || snapshot.Replicas != 0 || snapshot.Template == nil {
+		t.Errorf("Expected a snapshot without replicas - got: %v - %v.", snapshot, err)
+	}
+}
@@ -9,9 +9,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/intel/intent-driven-orchestration/pkg/common"
+
 	"k8s.io/klog/v2"
 )
 
+// querySourceHost labels the metrics of the queries for the host telemetry.
+const querySourceHost = "host"
+
 // init makes sure we use the "real" http client when not testing.
 func init() {
 	Client = &http.Client{
@@ -26,23 +31,29 @@ func getHostTelemetry(endpoint string, query string, hosts []string, hostLabel s
 	request, err := http.NewRequest(http.MethodGet, endpoint+"?query="+url.QueryEscape(queryString), nil)
 	if err != nil {
 		klog.Errorf("Could not construct request.")
+		common.QueryFailures.WithLabelValues(querySourceHost).Inc()
 		return ret
 	}
+	start := time.Now()
 	response, err := Client.Do(request)
+	common.QueryDuration.WithLabelValues(querySourceHost).Observe(time.Since(start).Seconds())
 	if err != nil {
 		klog.Errorf("Could not perform request.")
+		common.QueryFailures.WithLabelValues(querySourceHost).Inc()
 		return ret
 	}
 	if response.StatusCode == 200 {
 		body, err := io.ReadAll(response.Body)
 		if err != nil {
 			klog.Errorf("Could not read body: %s.", err)
+			common.QueryFailures.WithLabelValues(querySourceHost).Inc()
 			return ret
 		}
 		var result prometheusResponse
 		err = json.Unmarshal(body, &result)
 		if err != nil {
 			klog.V(1).Infof("Could not unmarshal json: %s - %s.", err, body)
+			common.QueryFailures.WithLabelValues(querySourceHost).Inc()
 			return ret
 		}
 		for _, res := range result.Data.Result {
@@ -50,6 +61,8 @@ func getHostTelemetry(endpoint string, query string, hosts []string, hostLabel s
 			val := res.Value[1]
 			ret[host] = getFloat(val)
 		}
+	} else {
+		common.QueryFailures.WithLabelValues(querySourceHost).Inc()
 	}
 	return ret
 }
@@ -69,3 +69,23 @@ func TestGetHostTelemetryForSanity(t *testing.T) {
 		}
 	}
 }
+
+// TestQueryMetricsForSanity tests for sanity.
+func TestQueryMetricsForSanity(t *testing.T) {
+	query := "avg(collectd_cpu_percent{exported_instance=~\"%s\"})by(exported_instance)"
+	before := scrapeMetrics(t)
+	MockResponse("{\"data\": {\"result\": []}}", 200)
+	getHostTelemetry("127.0.0.1", query, []string{"node0"}, "exported_instance")
+	MockResponse("", 500)
+	getHostTelemetry("127.0.0.1", query, []string{"node0"}, "exported_instance")
+
+	metrics := scrapeMetrics(t)
+	count := metricValue(metrics, `ido_telemetry_query_duration_seconds_count{source="host"}`)
+	failures := metricValue(metrics, `ido_telemetry_query_failures_total{source="host"}`)
+	if count == "" || count == metricValue(before, `ido_telemetry_query_duration_seconds_count{source="host"}`) {
+		t.Errorf("Expected query duration to be observed - got: %s.", count)
+	}
+	if failures == "" || failures == metricValue(before, `ido_telemetry_query_failures_total{source="host"}`) {
+		t.Errorf("Expected failed query to be counted - got: %s.", failures)
+	}
+}
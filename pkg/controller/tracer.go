@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -21,12 +22,26 @@ const MongoURIForTesting string = "mongodb://foo:123"
 
 // Tracer allows us to trace events & hence keep a record of what the planner did.
 type Tracer interface {
-	// TraceEvent adds an event to the e.g. a database.
-	TraceEvent(current common.State, desired common.State, plan []planner.Action)
+	// TraceEvent adds an event to the e.g. a database - including the results of performing the plan, if it was.
+	TraceEvent(current common.State, desired common.State, plan []planner.Action, results []planner.Result)
 	// GetEffect returns the data describing the effect of an action.
 	GetEffect(name string, group string, profileName string, lookBackMinutes int, constructor func() interface{}) (interface{}, error)
 }
 
+// ShadowTracer can optionally be implemented by tracers to keep a record of the changes the planner would have applied
+// to the cluster for intents handled in shadow mode.
+type ShadowTracer interface {
+	// TraceShadowPlan adds the plan & the patches its execution would have applied to e.g. a database.
+	TraceShadowPlan(desired common.State, plan []planner.Action, patches []planner.Patch)
+}
+
+// RegressionTracer can optionally be implemented by tracers to record that the effect of an actuator's actions on a
+// workload was worse than predicted - so the effect can be recalculated.
+type RegressionTracer interface {
+	// TraceRegression records the regression against the effects the actuator group has for an intent.
+	TraceRegression(name string, group string, predicted common.State, observed common.State)
+}
+
 // MongoTracer wraps around a MongoDB client.
 type MongoTracer struct {
 	client *mongo.Client
@@ -49,7 +64,24 @@ func NewMongoTracer(mongoURI string) *MongoTracer {
 	return &MongoTracer{client}
 }
 
-func (t MongoTracer) TraceEvent(current common.State, desired common.State, plan []planner.Action) {
+// Close disconnects from the Mongo DB. Events are written synchronously, so nothing gets lost once no more events are
+// traced.
+func (t MongoTracer) Close(ctx context.Context) error {
+	if t.client == nil {
+		return nil
+	}
+	return t.client.Disconnect(ctx)
+}
+
+// Ping checks if the tracer is connected to the Mongo DB.
+func (t MongoTracer) Ping(ctx context.Context) error {
+	if t.client == nil {
+		return errors.New("not connected to the Mongo DB")
+	}
+	return t.client.Ping(ctx, readpref.Primary())
+}
+
+func (t MongoTracer) TraceEvent(current common.State, desired common.State, plan []planner.Action, results []planner.Result) {
 	doc := bson.D{
 		{Key: "name", Value: desired.Intent.Key},
 		{Key: "timestamp", Value: time.Now()},
@@ -60,6 +92,7 @@ func (t MongoTracer) TraceEvent(current common.State, desired common.State, plan
 		{Key: "pods", Value: current.CurrentPods},
 		{Key: "data", Value: current.CurrentData},
 		{Key: "plan", Value: plan},
+		{Key: "results", Value: results},
 	}
 	if t.client == nil {
 		klog.Errorf("client not connected or not right client")
@@ -72,6 +105,46 @@ func (t MongoTracer) TraceEvent(current common.State, desired common.State, plan
 	}
 }
 
+func (t MongoTracer) TraceShadowPlan(desired common.State, plan []planner.Action, patches []planner.Patch) {
+	doc := bson.D{
+		{Key: "name", Value: desired.Intent.Key},
+		{Key: "timestamp", Value: time.Now()},
+		{Key: "plan", Value: plan},
+		{Key: "patches", Value: patches},
+	}
+	if t.client == nil {
+		klog.Errorf("client not connected or not right client")
+		return
+	}
+	collection := t.client.Database("intents").Collection("shadow")
+	_, err := collection.InsertOne(context.TODO(), doc)
+	if err != nil {
+		klog.Errorf("Could not insert information into the database: %s.", err)
+	}
+}
+
+func (t MongoTracer) TraceRegression(name string, group string, predicted common.State, observed common.State) {
+	if t.client == nil {
+		klog.Errorf("client not connected or not right client")
+		return
+	}
+	collection := t.client.Database("intents").Collection("effects")
+	filter := bson.D{
+		{Key: "name", Value: name},
+		{Key: "group", Value: group},
+		{Key: "regression", Value: bson.M{"$exists": false}},
+	}
+	update := bson.D{{Key: "$set", Value: bson.D{{Key: "regression", Value: bson.D{
+		{Key: "timestamp", Value: time.Now()},
+		{Key: "predicted_objectives", Value: predicted.Intent.Objectives},
+		{Key: "observed_objectives", Value: observed.Intent.Objectives},
+	}}}}}
+	_, err := collection.UpdateMany(context.TODO(), filter, update)
+	if err != nil {
+		klog.Errorf("Could not record regression in the database: %s.", err)
+	}
+}
+
 func (t MongoTracer) GetEffect(name string, group string, profileName string, lookBackMinutes int, createType func() interface{}) (interface{}, error) {
 	if t.client == nil {
 		return nil, fmt.Errorf("client not connected or incorrect client")
@@ -86,6 +159,7 @@ func (t MongoTracer) GetEffect(name string, group string, profileName string, lo
 	filter := bson.D{{Key: "group", Value: group},
 		{Key: "name", Value: name},
 		{Key: "profileName", Value: profileName},
+		{Key: "regression", Value: bson.M{"$exists": false}}, // effects which led to a regression are not used.
 		{Key: "$or", Value: bson.A{ // either this a static doc or not very old,
 			bson.M{"static": true},
 			bson.M{"timestamp": bson.M{"$gt": lookBack}}},
@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/intel/intent-driven-orchestration/pkg/common"
 	"github.com/intel/intent-driven-orchestration/pkg/planner"
@@ -66,6 +67,7 @@ func TestTraceEventForSanity(t1 *testing.T) {
 		current common.State
 		desired common.State
 		plan    []planner.Action
+		results []planner.Result
 	}
 	tests := []struct {
 		name   string
@@ -81,7 +83,7 @@ func TestTraceEventForSanity(t1 *testing.T) {
 			t := MongoTracer{
 				client: tt.client,
 			}
-			t.TraceEvent(tt.args.current, tt.args.desired, tt.args.plan)
+			t.TraceEvent(tt.args.current, tt.args.desired, tt.args.plan, tt.args.results)
 		})
 	}
 }
@@ -107,3 +109,28 @@ func TestNewMongoTracerForSanity(t *testing.T) {
 		})
 	}
 }
+
+// TestCloseForSanity tests for sanity.
+func TestCloseForSanity(t *testing.T) {
+	tracer := NewMongoTracer(MongoURIForTesting)
+	if err := tracer.Close(context.Background()); err != nil {
+		t.Errorf("Should have disconnected: %v.", err)
+	}
+	if err := (&MongoTracer{nil}).Close(context.Background()); err != nil {
+		t.Errorf("Should be fine if not connected: %v.", err)
+	}
+}
+
+// TestPingForSanity tests for sanity.
+func TestPingForSanity(t *testing.T) {
+	if err := (&MongoTracer{nil}).Ping(context.Background()); err == nil {
+		t.Error("Should fail if not connected.")
+	}
+	tracer := NewMongoTracer(MongoURIForTesting)
+	defer tracer.Close(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := tracer.Ping(ctx); err == nil {
+		t.Error("Should fail if the Mongo DB cannot be reached.")
+	}
+}
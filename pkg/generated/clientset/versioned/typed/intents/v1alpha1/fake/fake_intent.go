@@ -98,6 +98,18 @@ func (c *FakeIntents) Update(ctx context.Context, intent *v1alpha1.Intent, opts
 	return obj.(*v1alpha1.Intent), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeIntents) UpdateStatus(ctx context.Context, intent *v1alpha1.Intent, opts v1.UpdateOptions) (*v1alpha1.Intent, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(intentsResource, "status", c.ns, intent), &v1alpha1.Intent{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.Intent), err
+}
+
 // Delete takes name of the intent and deletes it. Returns an error if one occurs.
 func (c *FakeIntents) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
@@ -0,0 +1,138 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/intel/intent-driven-orchestration/pkg/api/intents/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeIntentGroups implements IntentGroupInterface
+type FakeIntentGroups struct {
+	Fake *FakeIdoV1alpha1
+	ns   string
+}
+
+var intentgroupsResource = v1alpha1.SchemeGroupVersion.WithResource("intentgroups")
+
+var intentgroupsKind = v1alpha1.SchemeGroupVersion.WithKind("IntentGroup")
+
+// Get takes name of the intentGroup, and returns the corresponding intentGroup object, and an error if there is any.
+func (c *FakeIntentGroups) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.IntentGroup, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(intentgroupsResource, c.ns, name), &v1alpha1.IntentGroup{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.IntentGroup), err
+}
+
+// List takes label and field selectors, and returns the list of IntentGroups that match those selectors.
+func (c *FakeIntentGroups) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.IntentGroupList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(intentgroupsResource, intentgroupsKind, c.ns, opts), &v1alpha1.IntentGroupList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.IntentGroupList{ListMeta: obj.(*v1alpha1.IntentGroupList).ListMeta}
+	for _, item := range obj.(*v1alpha1.IntentGroupList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested intentGroups.
+func (c *FakeIntentGroups) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(intentgroupsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a intentGroup and creates it.  Returns the server's representation of the intentGroup, and an error, if there is any.
+func (c *FakeIntentGroups) Create(ctx context.Context, intentGroup *v1alpha1.IntentGroup, opts v1.CreateOptions) (result *v1alpha1.IntentGroup, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(intentgroupsResource, c.ns, intentGroup), &v1alpha1.IntentGroup{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.IntentGroup), err
+}
+
+// Update takes the representation of a intentGroup and updates it. Returns the server's representation of the intentGroup, and an error, if there is any.
+func (c *FakeIntentGroups) Update(ctx context.Context, intentGroup *v1alpha1.IntentGroup, opts v1.UpdateOptions) (result *v1alpha1.IntentGroup, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(intentgroupsResource, c.ns, intentGroup), &v1alpha1.IntentGroup{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.IntentGroup), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeIntentGroups) UpdateStatus(ctx context.Context, intentGroup *v1alpha1.IntentGroup, opts v1.UpdateOptions) (*v1alpha1.IntentGroup, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(intentgroupsResource, "status", c.ns, intentGroup), &v1alpha1.IntentGroup{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.IntentGroup), err
+}
+
+// Delete takes name of the intentGroup and deletes it. Returns an error if one occurs.
+func (c *FakeIntentGroups) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(intentgroupsResource, c.ns, name, opts), &v1alpha1.IntentGroup{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeIntentGroups) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(intentgroupsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.IntentGroupList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched intentGroup.
+func (c *FakeIntentGroups) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.IntentGroup, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(intentgroupsResource, c.ns, name, pt, data, subresources...), &v1alpha1.IntentGroup{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.IntentGroup), err
+}
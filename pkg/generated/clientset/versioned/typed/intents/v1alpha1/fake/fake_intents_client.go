@@ -29,6 +29,10 @@ func (c *FakeIdoV1alpha1) Intents(namespace string) v1alpha1.IntentInterface {
 	return &FakeIntents{c, namespace}
 }
 
+func (c *FakeIdoV1alpha1) IntentGroups(namespace string) v1alpha1.IntentGroupInterface {
+	return &FakeIntentGroups{c, namespace}
+}
+
 func (c *FakeIdoV1alpha1) KPIProfiles(namespace string) v1alpha1.KPIProfileInterface {
 	return &FakeKPIProfiles{c, namespace}
 }
@@ -17,4 +17,6 @@ package v1alpha1
 
 type IntentExpansion interface{}
 
+type IntentGroupExpansion interface{}
+
 type KPIProfileExpansion interface{}
@@ -37,6 +37,7 @@ type IntentsGetter interface {
 type IntentInterface interface {
 	Create(ctx context.Context, intent *v1alpha1.Intent, opts v1.CreateOptions) (*v1alpha1.Intent, error)
 	Update(ctx context.Context, intent *v1alpha1.Intent, opts v1.UpdateOptions) (*v1alpha1.Intent, error)
+	UpdateStatus(ctx context.Context, intent *v1alpha1.Intent, opts v1.UpdateOptions) (*v1alpha1.Intent, error)
 	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.Intent, error)
@@ -132,6 +133,22 @@ func (c *intents) Update(ctx context.Context, intent *v1alpha1.Intent, opts v1.U
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *intents) UpdateStatus(ctx context.Context, intent *v1alpha1.Intent, opts v1.UpdateOptions) (result *v1alpha1.Intent, err error) {
+	result = &v1alpha1.Intent{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("intents").
+		Name(intent.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(intent).
+		Do(ctx).
+		Into(result)
+	return
+}
+
 // Delete takes name of the intent and deletes it. Returns an error if one occurs.
 func (c *intents) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return c.client.Delete().
@@ -0,0 +1,192 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/intel/intent-driven-orchestration/pkg/api/intents/v1alpha1"
+	scheme "github.com/intel/intent-driven-orchestration/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// IntentGroupsGetter has a method to return a IntentGroupInterface.
+// A group's client should implement this interface.
+type IntentGroupsGetter interface {
+	IntentGroups(namespace string) IntentGroupInterface
+}
+
+// IntentGroupInterface has methods to work with IntentGroup resources.
+type IntentGroupInterface interface {
+	Create(ctx context.Context, intentGroup *v1alpha1.IntentGroup, opts v1.CreateOptions) (*v1alpha1.IntentGroup, error)
+	Update(ctx context.Context, intentGroup *v1alpha1.IntentGroup, opts v1.UpdateOptions) (*v1alpha1.IntentGroup, error)
+	UpdateStatus(ctx context.Context, intentGroup *v1alpha1.IntentGroup, opts v1.UpdateOptions) (*v1alpha1.IntentGroup, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.IntentGroup, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.IntentGroupList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.IntentGroup, err error)
+	IntentGroupExpansion
+}
+
+// intentGroups implements IntentGroupInterface
+type intentGroups struct {
+	client rest.Interface
+	ns     string
+}
+
+// newIntentGroups returns a IntentGroups
+func newIntentGroups(c *IdoV1alpha1Client, namespace string) *intentGroups {
+	return &intentGroups{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the intentGroup, and returns the corresponding intentGroup object, and an error if there is any.
+func (c *intentGroups) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.IntentGroup, err error) {
+	result = &v1alpha1.IntentGroup{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("intentgroups").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of IntentGroups that match those selectors.
+func (c *intentGroups) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.IntentGroupList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.IntentGroupList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("intentgroups").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested intentGroups.
+func (c *intentGroups) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("intentgroups").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a intentGroup and creates it.  Returns the server's representation of the intentGroup, and an error, if there is any.
+func (c *intentGroups) Create(ctx context.Context, intentGroup *v1alpha1.IntentGroup, opts v1.CreateOptions) (result *v1alpha1.IntentGroup, err error) {
+	result = &v1alpha1.IntentGroup{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("intentgroups").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(intentGroup).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a intentGroup and updates it. Returns the server's representation of the intentGroup, and an error, if there is any.
+func (c *intentGroups) Update(ctx context.Context, intentGroup *v1alpha1.IntentGroup, opts v1.UpdateOptions) (result *v1alpha1.IntentGroup, err error) {
+	result = &v1alpha1.IntentGroup{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("intentgroups").
+		Name(intentGroup.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(intentGroup).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *intentGroups) UpdateStatus(ctx context.Context, intentGroup *v1alpha1.IntentGroup, opts v1.UpdateOptions) (result *v1alpha1.IntentGroup, err error) {
+	result = &v1alpha1.IntentGroup{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("intentgroups").
+		Name(intentGroup.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(intentGroup).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the intentGroup and deletes it. Returns an error if one occurs.
+func (c *intentGroups) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("intentgroups").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *intentGroups) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("intentgroups").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched intentGroup.
+func (c *intentGroups) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.IntentGroup, err error) {
+	result = &v1alpha1.IntentGroup{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("intentgroups").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -26,6 +26,7 @@ import (
 type IdoV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	IntentsGetter
+	IntentGroupsGetter
 	KPIProfilesGetter
 }
 
@@ -38,6 +39,10 @@ func (c *IdoV1alpha1Client) Intents(namespace string) IntentInterface {
 	return newIntents(c, namespace)
 }
 
+func (c *IdoV1alpha1Client) IntentGroups(namespace string) IntentGroupInterface {
+	return newIntentGroups(c, namespace)
+}
+
 func (c *IdoV1alpha1Client) KPIProfiles(namespace string) KPIProfileInterface {
 	return newKPIProfiles(c, namespace)
 }
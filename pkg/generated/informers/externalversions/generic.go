@@ -52,6 +52,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=ido.intel.com, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithResource("intents"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ido().V1alpha1().Intents().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("intentgroups"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ido().V1alpha1().IntentGroups().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("kpiprofiles"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ido().V1alpha1().KPIProfiles().Informer()}, nil
 
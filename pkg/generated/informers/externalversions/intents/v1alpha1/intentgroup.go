@@ -0,0 +1,87 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	intentsv1alpha1 "github.com/intel/intent-driven-orchestration/pkg/api/intents/v1alpha1"
+	versioned "github.com/intel/intent-driven-orchestration/pkg/generated/clientset/versioned"
+	internalinterfaces "github.com/intel/intent-driven-orchestration/pkg/generated/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/intel/intent-driven-orchestration/pkg/generated/listers/intents/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// IntentGroupInformer provides access to a shared informer and lister for
+// IntentGroups.
+type IntentGroupInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.IntentGroupLister
+}
+
+type intentGroupInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewIntentGroupInformer constructs a new informer for IntentGroup type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewIntentGroupInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredIntentGroupInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredIntentGroupInformer constructs a new informer for IntentGroup type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredIntentGroupInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.IdoV1alpha1().IntentGroups(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.IdoV1alpha1().IntentGroups(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&intentsv1alpha1.IntentGroup{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *intentGroupInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredIntentGroupInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *intentGroupInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&intentsv1alpha1.IntentGroup{}, f.defaultInformer)
+}
+
+func (f *intentGroupInformer) Lister() v1alpha1.IntentGroupLister {
+	return v1alpha1.NewIntentGroupLister(f.Informer().GetIndexer())
+}
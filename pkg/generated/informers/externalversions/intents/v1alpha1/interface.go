@@ -23,6 +23,8 @@ import (
 type Interface interface {
 	// Intents returns a IntentInformer.
 	Intents() IntentInformer
+	// IntentGroups returns a IntentGroupInformer.
+	IntentGroups() IntentGroupInformer
 	// KPIProfiles returns a KPIProfileInformer.
 	KPIProfiles() KPIProfileInformer
 }
@@ -43,6 +45,11 @@ func (v *version) Intents() IntentInformer {
 	return &intentInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// IntentGroups returns a IntentGroupInformer.
+func (v *version) IntentGroups() IntentGroupInformer {
+	return &intentGroupInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // KPIProfiles returns a KPIProfileInformer.
 func (v *version) KPIProfiles() KPIProfileInformer {
 	return &kPIProfileInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -23,6 +23,14 @@ type IntentListerExpansion interface{}
 // IntentNamespaceLister.
 type IntentNamespaceListerExpansion interface{}
 
+// IntentGroupListerExpansion allows custom methods to be added to
+// IntentGroupLister.
+type IntentGroupListerExpansion interface{}
+
+// IntentGroupNamespaceListerExpansion allows custom methods to be added to
+// IntentGroupNamespaceLister.
+type IntentGroupNamespaceListerExpansion interface{}
+
 // KPIProfileListerExpansion allows custom methods to be added to
 // KPIProfileLister.
 type KPIProfileListerExpansion interface{}
@@ -0,0 +1,96 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/intel/intent-driven-orchestration/pkg/api/intents/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// IntentGroupLister helps list IntentGroups.
+// All objects returned here must be treated as read-only.
+type IntentGroupLister interface {
+	// List lists all IntentGroups in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.IntentGroup, err error)
+	// IntentGroups returns an object that can list and get IntentGroups.
+	IntentGroups(namespace string) IntentGroupNamespaceLister
+	IntentGroupListerExpansion
+}
+
+// intentGroupLister implements the IntentGroupLister interface.
+type intentGroupLister struct {
+	indexer cache.Indexer
+}
+
+// NewIntentGroupLister returns a new IntentGroupLister.
+func NewIntentGroupLister(indexer cache.Indexer) IntentGroupLister {
+	return &intentGroupLister{indexer: indexer}
+}
+
+// List lists all IntentGroups in the indexer.
+func (s *intentGroupLister) List(selector labels.Selector) (ret []*v1alpha1.IntentGroup, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.IntentGroup))
+	})
+	return ret, err
+}
+
+// IntentGroups returns an object that can list and get IntentGroups.
+func (s *intentGroupLister) IntentGroups(namespace string) IntentGroupNamespaceLister {
+	return intentGroupNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// IntentGroupNamespaceLister helps list and get IntentGroups.
+// All objects returned here must be treated as read-only.
+type IntentGroupNamespaceLister interface {
+	// List lists all IntentGroups in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.IntentGroup, err error)
+	// Get retrieves the IntentGroup from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.IntentGroup, error)
+	IntentGroupNamespaceListerExpansion
+}
+
+// intentGroupNamespaceLister implements the IntentGroupNamespaceLister
+// interface.
+type intentGroupNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all IntentGroups in the indexer for a given namespace.
+func (s intentGroupNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.IntentGroup, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.IntentGroup))
+	})
+	return ret, err
+}
+
+// Get retrieves the IntentGroup from the indexer for a given namespace and name.
+func (s intentGroupNamespaceLister) Get(name string) (*v1alpha1.IntentGroup, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("intentgroup"), name)
+	}
+	return obj.(*v1alpha1.IntentGroup), nil
+}
@@ -29,13 +29,30 @@ THRESHOLD = 3
 logging.basicConfig(format=FORMAT, level=logging.INFO)
 
 
+def _last_regression(dbs, name, group):
+    """
+    Return when the effect of the group was last marked as regressed for the
+    workload - events from before are not used for training a new model.
+    """
+    item = dbs['effects'].find_one(
+        {'name': name, 'group': group, 'regression': {'$exists': True}},
+        {'regression.timestamp': 1}, sort=[('regression.timestamp', -1)])
+    if item is None:
+        return None
+    return item['regression']['timestamp']
+
+
 def _get_data(args):
     client = pymongo.MongoClient(args.mongo_uri)
     dbs = client['intents']
     coll = dbs['events']
 
+    query = {'name': args.name}
+    since = _last_regression(dbs, args.name, 'energy')
+    if since is not None:
+        query['timestamp'] = {'$gt': since}
     tmp = {}
-    res = coll.find({'name': args.name},
+    res = coll.find(query,
                     {'current_objectives': 1,
                      'data': 1,
                      'resources': 1,
@@ -65,6 +65,8 @@ def _get_model(name, target):
         {'group': 'energy',
          'name': name,
          'profileName': target,
+         # effects which led to a regression are not used.
+         'regression': {'$exists': False},
          '$or': [{'static': True}, {'timestamp': {'$gt': lookback}}],
          },
         {'data': {'model': 1}, 'timestamp': 1})
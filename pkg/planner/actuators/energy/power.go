@@ -2,7 +2,6 @@ package energy
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -17,8 +16,8 @@ import (
 	"github.com/intel/intent-driven-orchestration/pkg/common"
 	"github.com/intel/intent-driven-orchestration/pkg/controller"
 	"github.com/intel/intent-driven-orchestration/pkg/planner"
+	"github.com/intel/intent-driven-orchestration/pkg/planner/actuators"
 	coreV1 "k8s.io/api/core/v1"
-	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 )
@@ -43,13 +42,17 @@ type PowerActuatorConfig struct {
 	PluginManagerEndpoint string   `json:"plugin_manager_endpoint"`
 	PluginManagerPort     int      `json:"plugin_manager_port"`
 	MongoEndpoint         string   `json:"mongo_endpoint"`
+	ProbesAddress         string   `json:"probes_address"`
 }
 
 // PowerActuator is an actuator that can handle power management related settings.
 type PowerActuator struct {
+	controller.EventEmitter
 	config PowerActuatorConfig
 	client kubernetes.Interface
 	Cmd    *exec.Cmd
+	// process tracks whether the prediction web service is still running.
+	process *actuators.ProcessMonitor
 }
 
 // requestBody represents the json send to prediction function.
@@ -101,6 +104,16 @@ func (power PowerActuator) Group() string {
 	return groupName
 }
 
+// InfluencedProfileTypes returns the types of the objectives this actuator predicts.
+func (power PowerActuator) InfluencedProfileTypes() []common.ProfileType {
+	return []common.ProfileType{common.Latency, common.Power}
+}
+
+// Healthy checks if the prediction web service is still running.
+func (power PowerActuator) Healthy() error {
+	return power.process.Alive()
+}
+
 // contains figures out if a value is part of a slice.
 func contains(slice []string, value string) bool {
 	for _, v := range slice {
@@ -308,32 +321,20 @@ func (power PowerActuator) NextState(state *common.State, goal *common.State, pr
 	return nil, nil, nil
 }
 
-func (power PowerActuator) Perform(state *common.State, plan []planner.Action) {
-	klog.V(2).Infof("Perform called.")
-	var profile string
-	found := false
+// planProfile returns the power profile the plan asks for - if any.
+func (power PowerActuator) planProfile(plan []planner.Action) (string, bool) {
 	for _, item := range plan {
 		if item.Name == power.Name() {
-			profile = item.Properties.(map[string]string)["profile"]
-			found = true
-			break
+			return item.Properties.(map[string]string)["profile"], true
 		}
 	}
-	if !found {
-		return
-	}
-	tmp := strings.Split(state.Intent.TargetKey, "/")
-	namespace := tmp[0]
-	name := tmp[1]
+	return "", false
+}
 
-	if state.Intent.TargetKind == "Deployment" {
-		depl, err := power.client.AppsV1().Deployments(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
-		if err != nil {
-			klog.Errorf("Failed to retrieve deployment: %v", err)
-			return
-		}
-		newDepl := depl.DeepCopy()
-		for _, container := range newDepl.Spec.Template.Spec.Containers {
+// profileUpdate returns the function swapping the power profile resources of all containers in the POD template.
+func (power PowerActuator) profileUpdate(profile string) func(template *coreV1.PodTemplateSpec) {
+	return func(template *coreV1.PodTemplateSpec) {
+		for _, container := range template.Spec.Containers {
 			resourceRequests := container.Resources.Requests
 			resourceLimits := container.Resources.Limits
 
@@ -350,15 +351,41 @@ func (power PowerActuator) Perform(state *common.State, plan []planner.Action) {
 			container.Resources.Requests = resourceRequests
 			container.Resources.Limits = resourceLimits
 		}
-		_, err = power.client.AppsV1().Deployments(namespace).Update(context.TODO(), newDepl, metaV1.UpdateOptions{})
-		if err != nil {
-			klog.Errorf("Failed to update deployment: %v", err)
-		}
-	} else {
-		klog.Warningf("Can only update Deployment for now! Your type was: %s.", state.Intent.TargetKind)
 	}
 }
 
+func (power PowerActuator) Perform(state *common.State, plan []planner.Action) planner.Result {
+	klog.V(2).Infof("Perform called.")
+	profile, found := power.planProfile(plan)
+	if !found {
+		return actuators.Skipped(power.Name(), "no power profile in plan")
+	}
+	object := actuators.Object(state.Intent.TargetKind, state.Intent.TargetKey)
+	err := controller.UpdatePodTemplate(power.client, state.Intent.TargetKind, state.Intent.TargetKey, power.profileUpdate(profile))
+	if err != nil {
+		klog.Errorf("Failed to update %s %s: %v", state.Intent.TargetKind, state.Intent.TargetKey, err)
+		power.IntentEvent(state.Intent, coreV1.EventTypeWarning, controller.ReasonPerformFailed,
+			"%s failed to set power profile %s on %s %s: %v", power.Name(), profile, state.Intent.TargetKind, state.Intent.TargetKey, err)
+		return actuators.Failed(power.Name(), err, object)
+	}
+	return actuators.Applied(power.Name(), object)
+}
+
+// DryRun returns the patch Perform would apply to the resources of the POD template.
+func (power PowerActuator) DryRun(state *common.State, plan []planner.Action) []planner.Patch {
+	profile, found := power.planProfile(plan)
+	if !found {
+		return nil
+	}
+	patch, err := controller.PodTemplatePatch(power.client, state.Intent.TargetKind, state.Intent.TargetKey, power.profileUpdate(profile))
+	if err != nil {
+		klog.Errorf("Failed to determine patch for %s %s: %v", state.Intent.TargetKind, state.Intent.TargetKey, err)
+		return nil
+	}
+	patch.Actuator = power.Name()
+	return []planner.Patch{patch}
+}
+
 func (power PowerActuator) Effect(state *common.State, profiles map[string]common.Profile) {
 	klog.V(2).Infof("Effect called.")
 	var names []string
@@ -387,8 +414,9 @@ func NewPowerActuator(core kubernetes.Interface, _ controller.Tracer, cfg PowerA
 	time.Sleep(500 * time.Millisecond)
 
 	return &PowerActuator{
-		config: cfg,
-		client: core,
-		Cmd:    cmd,
+		config:  cfg,
+		client:  core,
+		Cmd:     cmd,
+		process: actuators.MonitorProcess(cmd),
 	}
 }
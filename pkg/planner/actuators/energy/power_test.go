@@ -186,6 +186,28 @@ func TestPowerEffectForFailure(t *testing.T) {
 
 // Tests for sanity.
 
+// TestPowerDryRunForSanity tests for sanity.
+func TestPowerDryRunForSanity(t *testing.T) {
+	f := newPowerActuatorFixture(t)
+	f.objects = []runtime.Object{f.createDeploymentObject("my-function")}
+	actuator := f.newPowerTestActuator(false)
+	defer f.cleanUp(actuator)
+	state := common.State{
+		Intent: common.Intent{TargetKey: "default/my-function", TargetKind: "Deployment"},
+	}
+	plan := []planner.Action{{Name: actuator.Name(), Properties: map[string]string{"profile": "power.intel.com/performance"}}}
+	patches := actuator.DryRun(&state, plan)
+	if len(patches) != 1 || patches[0].Actuator != actuator.Name() || len(patches[0].Diff) == 0 {
+		t.Fatalf("Expected a patch - got: %v.", patches)
+	}
+
+	// nothing got applied.
+	res, _ := f.client.AppsV1().Deployments("default").Get(t.Context(), "my-function", metaV1.GetOptions{})
+	if _, ok := res.Spec.Template.Spec.Containers[0].Resources.Requests["power.intel.com/balance-power"]; !ok {
+		t.Errorf("Dry run should not have changed the deployment: %v.", res.Spec.Template)
+	}
+}
+
 // TestPowerFindProfileForSanity tests for sanity.
 func TestPowerFindProfileForSanity(t *testing.T) {
 	f := newPowerActuatorFixture(t)
@@ -485,12 +507,12 @@ func TestPowerPerformForSanity(t *testing.T) {
 		}
 	}
 
-	// not a deployment...
+	// not a deployment; other kinds are supported as well - but this ReplicaSet does not exist...
 	f.client.ClearActions()
 	state.Intent.TargetKind = "ReplicaSet"
 	actuator.Perform(&state, plan)
-	if len(f.client.Actions()) != 0 {
-		t.Errorf("This is not expected - should see nothing...: %v", f.client.Actions())
+	if len(f.client.Actions()) != 1 || f.client.Actions()[0].GetVerb() != "get" {
+		t.Errorf("This is not expected - should see a get only...: %v", f.client.Actions())
 	}
 }
 
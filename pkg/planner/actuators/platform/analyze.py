@@ -55,6 +55,19 @@ def _parse_load(data, name):
     return res
 
 
+def _last_regression(dbs, name, group):
+    """
+    Return when the effect of the group was last marked as regressed for the
+    workload - events from before are not used for training a new model.
+    """
+    item = dbs['effects'].find_one(
+        {'name': name, 'group': group, 'regression': {'$exists': True}},
+        {'regression.timestamp': 1}, sort=[('regression.timestamp', -1)])
+    if item is None:
+        return None
+    return item['regression']['timestamp']
+
+
 def get_data(args):
     """
     Return data from MongoDB. This is for demo purposes only, in production
@@ -65,8 +78,12 @@ def get_data(args):
     dbs = client['intents']
     coll = dbs['events']
 
+    query = {'name': args.name}
+    since = _last_regression(dbs, args.name, 'rdt')
+    if since is not None:
+        query['timestamp'] = {'$gt': since}
     tmp = {}
-    res = coll.find({'name': args.name},
+    res = coll.find(query,
                     {'current_objectives': 1,
                      'data': 1,
                      'pods': 1,
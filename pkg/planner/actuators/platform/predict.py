@@ -59,10 +59,12 @@ def _get_model(name, target):
     client = pymongo.MongoClient(MONGO_URI)
     dbs = client["intents"]
     coll = dbs["effects"]
+    # effects which led to a regression are not used.
     items = coll.find(
         {'group': 'rdt',
          'name': name,
-         'profileName': target},
+         'profileName': target,
+         'regression': {'$exists': False}},
         {'data': {'model': 1, 'features_map': 1},
          'timestamp': 1}).sort('_id', pymongo.DESCENDING).limit(1)
     items = list(items)
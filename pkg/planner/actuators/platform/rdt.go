@@ -2,7 +2,6 @@ package platform
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -15,8 +14,9 @@ import (
 	"github.com/intel/intent-driven-orchestration/pkg/common"
 	"github.com/intel/intent-driven-orchestration/pkg/controller"
 	"github.com/intel/intent-driven-orchestration/pkg/planner"
+	"github.com/intel/intent-driven-orchestration/pkg/planner/actuators"
 
-	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 )
@@ -41,14 +41,18 @@ type RdtConfig struct {
 	PluginManagerEndpoint string   `json:"plugin_manager_endpoint"`
 	PluginManagerPort     int      `json:"plugin_manager_port"`
 	MongoEndpoint         string   `json:"mongo_endpoint"`
+	ProbesAddress         string   `json:"probes_address"`
 }
 
 // RdtActuator represents the actual RDT actuator.
 type RdtActuator struct {
+	controller.EventEmitter
 	config RdtConfig
 	tracer controller.Tracer
 	k8s    kubernetes.Interface
 	Cmd    *exec.Cmd
+	// process tracks whether the prediction script is still running.
+	process *actuators.ProcessMonitor
 }
 
 func (rdt RdtActuator) Name() string {
@@ -59,6 +63,16 @@ func (rdt RdtActuator) Group() string {
 	return rdtGroupName
 }
 
+// InfluencedProfileTypes returns the types of the objectives this actuator predicts.
+func (rdt RdtActuator) InfluencedProfileTypes() []common.ProfileType {
+	return []common.ProfileType{common.Latency}
+}
+
+// Healthy checks if the prediction script is still running.
+func (rdt RdtActuator) Healthy() error {
+	return rdt.process.Alive()
+}
+
 // requestBody represents the json send to prediction function.
 type requestBody struct {
 	Name     string  `json:"name"`
@@ -227,68 +241,66 @@ func (rdt RdtActuator) NextState(state *common.State, goal *common.State, profil
 	return states, utilities, actions
 }
 
-func (rdt RdtActuator) Perform(state *common.State, plan []planner.Action) {
-	klog.V(2).Infof("%s-%s - performing plan: %v", rdt.Group(), rdt.Name(), plan)
+// planOption returns the option the plan asks for; or n/a if the plan does not contain an action for this actuator.
+func (rdt RdtActuator) planOption(plan []planner.Action) string {
 	option := "n/a"
 	for _, item := range plan {
 		if item.Name == rdt.Name() {
 			option = item.Properties.(map[string]string)["option"]
 		}
 	}
-	if option == "n/a" {
-		klog.V(2).Infof("Nothing to do for: %v.", state.Intent.TargetKey)
-		return
-	}
-	tmp := strings.Split(state.Intent.TargetKey, "/")
-	namespace := tmp[0]
+	return option
+}
 
-	// set annotation on deployment - will cause a POD restart atm; required atm as containerd/cri-o only handle setting RDT settings on POD starts.
-	if state.Intent.TargetKind == "Deployment" {
-		deployment, err := rdt.k8s.AppsV1().Deployments(namespace).Get(context.TODO(), tmp[1], metaV1.GetOptions{})
-		if err != nil {
-			klog.Errorf("failed to get deployment: %s - %v", state.Intent.TargetKey, err)
-			return
-		}
-		newDeployment := deployment.DeepCopy()
-		podAnnotations := newDeployment.Spec.Template.ObjectMeta.Annotations
-		if podAnnotations == nil {
-			podAnnotations = make(map[string]string)
-		}
-		if option != "None" {
-			podAnnotations[rdt.config.AnnotationName] = option
-		} else {
-			delete(podAnnotations, rdt.config.AnnotationName)
-		}
-		_, err = rdt.k8s.AppsV1().Deployments(deployment.ObjectMeta.Namespace).Update(context.TODO(), newDeployment, metaV1.UpdateOptions{})
-		if err != nil {
-			klog.Errorf("failed to update deployment: %v", err)
-			return
-		}
-	} else if state.Intent.TargetKind == "ReplicaSet" {
-		replicaSet, err := rdt.k8s.AppsV1().ReplicaSets(namespace).Get(context.TODO(), tmp[1], metaV1.GetOptions{})
-		if err != nil {
-			klog.Errorf("failed to get replicaset: %s - %v", state.Intent.TargetKey, err)
-			return
-		}
-		newReplicaSet := replicaSet.DeepCopy()
-		podAnnotations := newReplicaSet.Spec.Template.ObjectMeta.Annotations
-		if podAnnotations == nil {
-			podAnnotations = make(map[string]string)
+// annotationUpdate returns the function setting - or removing - the annotation on the POD template.
+func (rdt RdtActuator) annotationUpdate(option string) func(template *coreV1.PodTemplateSpec) {
+	return func(template *coreV1.PodTemplateSpec) {
+		if template.ObjectMeta.Annotations == nil {
+			template.ObjectMeta.Annotations = make(map[string]string)
 		}
 		if option != "None" {
-			podAnnotations[rdt.config.AnnotationName] = option
+			template.ObjectMeta.Annotations[rdt.config.AnnotationName] = option
 		} else {
-			delete(podAnnotations, rdt.config.AnnotationName)
-		}
-		_, err = rdt.k8s.AppsV1().ReplicaSets(replicaSet.ObjectMeta.Namespace).Update(context.TODO(), newReplicaSet, metaV1.UpdateOptions{})
-		if err != nil {
-			klog.Errorf("failed to update replicaset: %v", err)
-			return
+			delete(template.ObjectMeta.Annotations, rdt.config.AnnotationName)
 		}
 	}
+}
+
+func (rdt RdtActuator) Perform(state *common.State, plan []planner.Action) planner.Result {
+	klog.V(2).Infof("%s-%s - performing plan: %v", rdt.Group(), rdt.Name(), plan)
+	option := rdt.planOption(plan)
+	if option == "n/a" {
+		klog.V(2).Infof("Nothing to do for: %v.", state.Intent.TargetKey)
+		return actuators.Skipped(rdt.Name(), "no RDT option in plan")
+	}
+	// set annotation on the POD template - will cause a POD restart atm; required atm as containerd/cri-o only handle setting RDT settings on POD starts.
+	object := actuators.Object(state.Intent.TargetKind, state.Intent.TargetKey)
+	err := controller.UpdatePodTemplate(rdt.k8s, state.Intent.TargetKind, state.Intent.TargetKey, rdt.annotationUpdate(option))
+	if err != nil {
+		klog.Errorf("failed to update %s %s: %v", state.Intent.TargetKind, state.Intent.TargetKey, err)
+		rdt.IntentEvent(state.Intent, coreV1.EventTypeWarning, controller.ReasonPerformFailed,
+			"%s failed to set option %s on %s %s: %v", rdt.Name(), option, state.Intent.TargetKind, state.Intent.TargetKey, err)
+		return actuators.Failed(rdt.Name(), err, object)
+	}
 
 	// TODO: add NFD/NPD requirement label.
 	// TODO: set any hints for scheduler.
+	return actuators.Applied(rdt.Name(), object)
+}
+
+// DryRun returns the patch Perform would apply to the annotations of the POD template.
+func (rdt RdtActuator) DryRun(state *common.State, plan []planner.Action) []planner.Patch {
+	option := rdt.planOption(plan)
+	if option == "n/a" {
+		return nil
+	}
+	patch, err := controller.PodTemplatePatch(rdt.k8s, state.Intent.TargetKind, state.Intent.TargetKey, rdt.annotationUpdate(option))
+	if err != nil {
+		klog.Errorf("failed to determine patch for %s %s: %v", state.Intent.TargetKind, state.Intent.TargetKey, err)
+		return nil
+	}
+	patch.Actuator = rdt.Name()
+	return []planner.Patch{patch}
 }
 
 func (rdt RdtActuator) Effect(state *common.State, profiles map[string]common.Profile) {
@@ -322,9 +334,10 @@ func NewRdtActuator(client kubernetes.Interface, tracer controller.Tracer, cfg R
 	klog.Infof("PID is: %d", cmd.Process.Pid)
 
 	return &RdtActuator{
-		config: cfg,
-		tracer: tracer,
-		k8s:    client,
-		Cmd:    cmd,
+		config:  cfg,
+		tracer:  tracer,
+		k8s:     client,
+		Cmd:     cmd,
+		process: actuators.MonitorProcess(cmd),
 	}
 }
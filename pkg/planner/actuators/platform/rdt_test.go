@@ -2,6 +2,7 @@ package platform
 
 import (
 	"testing"
+	"time"
 
 	"github.com/intel/intent-driven-orchestration/pkg/common"
 	"github.com/intel/intent-driven-orchestration/pkg/planner"
@@ -17,7 +18,7 @@ import (
 // dummyTracer allows us to control what information to give to the actuator.
 type dummyTracer struct{}
 
-func (d dummyTracer) TraceEvent(_ common.State, _ common.State, _ []planner.Action) {
+func (d dummyTracer) TraceEvent(_ common.State, _ common.State, _ []planner.Action, _ []planner.Result) {
 	klog.Fatalf("Not needed.")
 }
 
@@ -556,3 +557,23 @@ func TestRdtEffectForSanity(_ *testing.T) {
 	// will cause a logging warning.
 	actuator.Effect(&state, profiles)
 }
+
+// TestRdtHealthyForSanity tests for sanity.
+func TestRdtHealthyForSanity(t *testing.T) {
+	f := newRdtActuatorFixture()
+	actuator := f.newRdtTestActuator()
+	if err := actuator.Healthy(); err != nil {
+		t.Errorf("Prediction script should be running: %v.", err)
+	}
+
+	// prediction script died.
+	f.cleanUp(actuator)
+	var err error
+	for i := 0; i < 50 && err == nil; i++ {
+		time.Sleep(10 * time.Millisecond)
+		err = actuator.Healthy()
+	}
+	if err == nil {
+		t.Error("Should not be healthy once the prediction script is gone.")
+	}
+}
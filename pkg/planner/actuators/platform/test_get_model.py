@@ -0,0 +1,36 @@
+"""
+Unittests for looking up the models in the knowledge base.
+"""
+
+import sys
+import unittest
+
+from unittest import mock
+
+sys.modules.setdefault('pymongo', mock.MagicMock())
+
+import predict  # noqa: E402 - needs the stubbed pymongo module.
+
+
+class TestGetModel(unittest.TestCase):
+    """
+    Tests the look-up of the models.
+    """
+
+    @mock.patch('predict.pymongo')
+    def test_get_model_for_sanity(self, pymongo):
+        """
+        Effects which led to a regression are not used.
+        """
+        coll = pymongo.MongoClient.return_value['intents']['effects']
+        coll.find.return_value.sort.return_value.limit.return_value = []
+        self.assertEqual(predict._get_model('default/my-intent',
+                                            'default/p99'),
+                         (None, None))
+        query = coll.find.call_args[0][0]
+        self.assertEqual(query['regression'], {'$exists': False})
+        self.assertEqual(query['group'], 'rdt')
+
+
+if __name__ == '__main__':
+    unittest.main()
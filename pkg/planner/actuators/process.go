@@ -0,0 +1,45 @@
+package actuators
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+)
+
+// ProcessMonitor keeps track of whether a subprocess - e.g. a prediction web service - is still running.
+type ProcessMonitor struct {
+	done chan struct{}
+	err  error
+}
+
+// MonitorProcess starts waiting for the given (already started) command to exit.
+func MonitorProcess(cmd *exec.Cmd) *ProcessMonitor {
+	monitor := &ProcessMonitor{done: make(chan struct{})}
+	if cmd == nil || cmd.Process == nil {
+		monitor.err = errors.New("process was not started")
+		close(monitor.done)
+		return monitor
+	}
+	go func() {
+		err := cmd.Wait()
+		if err == nil {
+			err = errors.New("process exited")
+		}
+		monitor.err = fmt.Errorf("process %d is not running: %w", cmd.Process.Pid, err)
+		close(monitor.done)
+	}()
+	return monitor
+}
+
+// Alive returns an error if the process has exited.
+func (m *ProcessMonitor) Alive() error {
+	if m == nil {
+		return errors.New("process is not monitored")
+	}
+	select {
+	case <-m.done:
+		return m.err
+	default:
+		return nil
+	}
+}
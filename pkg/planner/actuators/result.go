@@ -0,0 +1,42 @@
+package actuators
+
+import (
+	"context"
+	"errors"
+
+	"github.com/intel/intent-driven-orchestration/pkg/planner"
+
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Object returns the reference to an object used in the results - e.g. "Deployment default/my-deployment".
+func Object(kind string, key string) string {
+	return kind + " " + key
+}
+
+// Applied returns the result of an actuator which applied its actions to the given objects.
+func Applied(actuator string, objects ...string) planner.Result {
+	return planner.Result{Actuator: actuator, Status: planner.ResultApplied, Objects: objects}
+}
+
+// Skipped returns the result of an actuator which had nothing to do.
+func Skipped(actuator string, reason string) planner.Result {
+	return planner.Result{Actuator: actuator, Status: planner.ResultSkipped, Reason: reason}
+}
+
+// Failed returns the result of an actuator which failed to apply its actions to the given objects.
+func Failed(actuator string, err error, objects ...string) planner.Result {
+	return planner.Result{Actuator: actuator, Status: planner.ResultFailed, Reason: err.Error(), Transient: IsTransient(err), Objects: objects}
+}
+
+// IsTransient checks if an error is likely to go away when trying again - e.g. conflicts, timeouts or throttling by
+// the API server.
+func IsTransient(err error) bool {
+	return apiErrors.IsConflict(err) ||
+		apiErrors.IsServerTimeout(err) ||
+		apiErrors.IsTimeout(err) ||
+		apiErrors.IsTooManyRequests(err) ||
+		apiErrors.IsServiceUnavailable(err) ||
+		apiErrors.IsInternalError(err) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
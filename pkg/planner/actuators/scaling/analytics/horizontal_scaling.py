@@ -41,6 +41,19 @@ def latency_func(data, p_0, p_1, p_2, p_3):
     return (p_0 * np.exp(p_1 * tput)) / (p_2 * np.exp(p_3 * tput * n_pods))
 
 
+def _last_regression(dbs, name, group):
+    """
+    Return when the effect of the group was last marked as regressed for the
+    workload - events from before are not used for training a new model.
+    """
+    item = dbs["effects"].find_one(
+        {"name": name, "group": group, "regression": {"$exists": True}},
+        {"regression.timestamp": 1}, sort=[("regression.timestamp", -1)])
+    if item is None:
+        return None
+    return item["regression"]["timestamp"]
+
+
 def get_data(args):
     """
     Return data from MongoDB. This is for demo purposes only, in production
@@ -51,8 +64,12 @@ def get_data(args):
     dbs = client["intents"]
     coll = dbs["events"]
 
+    query = {"name": args.name}
+    since = _last_regression(dbs, args.name, "scaling")
+    if since is not None:
+        query["timestamp"] = {"$gt": since}
     tmp = {}
-    res = coll.find({"name": args.name},
+    res = coll.find(query,
                     {"current_objectives": 1,
                      "pods": 1,
                      "timestamp": 1,
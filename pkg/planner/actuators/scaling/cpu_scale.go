@@ -1,7 +1,6 @@
 package scaling
 
 import (
-	"context"
 	"fmt"
 	"math"
 	"math/rand"
@@ -10,14 +9,13 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
-	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/util/retry"
 
 	"os/exec"
 
 	"github.com/intel/intent-driven-orchestration/pkg/common"
 	"github.com/intel/intent-driven-orchestration/pkg/controller"
 	"github.com/intel/intent-driven-orchestration/pkg/planner"
+	"github.com/intel/intent-driven-orchestration/pkg/planner/actuators"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 )
@@ -48,6 +46,7 @@ type CPUScaleConfig struct {
 	PluginManagerEndpoint      string  `json:"plugin_manager_endpoint"`
 	PluginManagerPort          int     `json:"plugin_manager_port"`
 	MongoEndpoint              string  `json:"mongo_endpoint"`
+	ProbesAddress              string  `json:"probes_address"`
 }
 
 // CPUScaleEffect describes the data that is stored in the knowledge base.
@@ -63,6 +62,7 @@ type CPUScaleEffect struct {
 
 // CPUScaleActuator is an actuator supporting the resource scaling.
 type CPUScaleActuator struct {
+	controller.EventEmitter
 	cfg          CPUScaleConfig
 	tracer       controller.Tracer
 	apps         kubernetes.Interface
@@ -77,6 +77,11 @@ func (cs CPUScaleActuator) Group() string {
 	return groupName
 }
 
+// InfluencedProfileTypes returns the types of the objectives this actuator predicts.
+func (cs CPUScaleActuator) InfluencedProfileTypes() []common.ProfileType {
+	return []common.ProfileType{common.Latency}
+}
+
 // predictLatency uses the knowledge base to calculate the latency. It does use the parameters popt
 // that are obtained when sum of the squared residuals which is minimized. More info in:
 // https://docs.scipy.org/doc/scipy/reference/generated/scipy.optimize.curve_fit.html
@@ -125,74 +130,40 @@ func getResourceValues(state *common.State) (int64, int) {
 	return cpuRequest, lastIndex
 }
 
-// setResourceValues tweaks the resource requests limits on the workload.
-func (cs CPUScaleActuator) setResourceValues(state *common.State, newValue int) {
-	tmp := strings.Split(state.Intent.TargetKey, "/")
-	namespace := tmp[0]
-
-	if state.Intent.TargetKind == "Deployment" {
-		client := cs.apps.AppsV1().Deployments(namespace)
-		retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			deployment, err := client.Get(context.TODO(), tmp[1], metaV1.GetOptions{})
-			if err != nil {
-				klog.Errorf("Failed to get latest version of Deployment: %v", err)
-				return nil
-			}
-			updatedDeployment := deployment.DeepCopy()
-
-			request := resource.NewMilliQuantity(int64(newValue), resource.DecimalSI).DeepCopy()
-			if len(updatedDeployment.Spec.Template.Spec.Containers[len(updatedDeployment.Spec.Template.Spec.Containers)-1].Resources.Requests) == 0 {
-				updatedDeployment.Spec.Template.Spec.Containers[len(updatedDeployment.Spec.Template.Spec.Containers)-1].Resources.Requests = make(map[v1.ResourceName]resource.Quantity)
-			}
-			updatedDeployment.Spec.Template.Spec.Containers[len(updatedDeployment.Spec.Template.Spec.Containers)-1].Resources.Requests["cpu"] = request
-
-			if cs.cfg.BoostFactor >= 1.0 {
-				limit := resource.NewMilliQuantity(int64(float64(newValue)*cs.cfg.BoostFactor), resource.DecimalSI).DeepCopy()
-				if len(updatedDeployment.Spec.Template.Spec.Containers[len(updatedDeployment.Spec.Template.Spec.Containers)-1].Resources.Limits) == 0 {
-					updatedDeployment.Spec.Template.Spec.Containers[len(updatedDeployment.Spec.Template.Spec.Containers)-1].Resources.Limits = make(map[v1.ResourceName]resource.Quantity)
-				}
-				updatedDeployment.Spec.Template.Spec.Containers[len(updatedDeployment.Spec.Template.Spec.Containers)-1].Resources.Limits["cpu"] = limit
-			}
-
-			_, updateErr := client.Update(context.TODO(), updatedDeployment, metaV1.UpdateOptions{})
-			return updateErr
-		})
-		if retryErr != nil {
-			klog.Errorf("Update of deployment %s failed: %v.", state.Intent.TargetKey, retryErr)
+// resourceUpdate returns the function setting the resource requests & limits of the last container in the POD template.
+func (cs CPUScaleActuator) resourceUpdate(newValue int) func(template *v1.PodTemplateSpec) {
+	return func(template *v1.PodTemplateSpec) {
+		if len(template.Spec.Containers) == 0 {
+			return
 		}
-	} else if state.Intent.TargetKind == "ReplicaSet" {
-		client := cs.apps.AppsV1().ReplicaSets(namespace)
-		retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			replicaSet, err := client.Get(context.TODO(), tmp[1], metaV1.GetOptions{})
-			if err != nil {
-				klog.Errorf("Failed to get latest version of ReplicaSet: %v", err)
-				return nil
-			}
-			updatedReplicaSet := replicaSet.DeepCopy()
-
-			request := resource.NewMilliQuantity(int64(newValue), resource.DecimalSI).DeepCopy()
-			if len(updatedReplicaSet.Spec.Template.Spec.Containers[len(updatedReplicaSet.Spec.Template.Spec.Containers)-1].Resources.Requests) == 0 {
-				updatedReplicaSet.Spec.Template.Spec.Containers[len(updatedReplicaSet.Spec.Template.Spec.Containers)-1].Resources.Requests = make(map[v1.ResourceName]resource.Quantity)
-			}
-			updatedReplicaSet.Spec.Template.Spec.Containers[len(updatedReplicaSet.Spec.Template.Spec.Containers)-1].Resources.Requests["cpu"] = request
+		container := &template.Spec.Containers[len(template.Spec.Containers)-1]
+		request := resource.NewMilliQuantity(int64(newValue), resource.DecimalSI).DeepCopy()
+		if len(container.Resources.Requests) == 0 {
+			container.Resources.Requests = make(map[v1.ResourceName]resource.Quantity)
+		}
+		container.Resources.Requests["cpu"] = request
 
-			if cs.cfg.BoostFactor >= 1.0 {
-				limit := resource.NewMilliQuantity(int64(float64(newValue)*cs.cfg.BoostFactor), resource.DecimalSI).DeepCopy()
-				if len(updatedReplicaSet.Spec.Template.Spec.Containers[len(updatedReplicaSet.Spec.Template.Spec.Containers)-1].Resources.Limits) == 0 {
-					updatedReplicaSet.Spec.Template.Spec.Containers[len(updatedReplicaSet.Spec.Template.Spec.Containers)-1].Resources.Limits = make(map[v1.ResourceName]resource.Quantity)
-				}
-				updatedReplicaSet.Spec.Template.Spec.Containers[len(updatedReplicaSet.Spec.Template.Spec.Containers)-1].Resources.Limits["cpu"] = limit
+		if cs.cfg.BoostFactor >= 1.0 {
+			limit := resource.NewMilliQuantity(int64(float64(newValue)*cs.cfg.BoostFactor), resource.DecimalSI).DeepCopy()
+			if len(container.Resources.Limits) == 0 {
+				container.Resources.Limits = make(map[v1.ResourceName]resource.Quantity)
 			}
-
-			_, updateErr := client.Update(context.TODO(), updatedReplicaSet, metaV1.UpdateOptions{})
-			return updateErr
-		})
-		if retryErr != nil {
-			klog.Errorf("Update of ReplicaSet %s failed: %v.", state.Intent.TargetKey, retryErr)
+			container.Resources.Limits["cpu"] = limit
 		}
 	}
 }
 
+// setResourceValues tweaks the resource requests limits on the workload.
+func (cs CPUScaleActuator) setResourceValues(state *common.State, newValue int) error {
+	err := controller.UpdatePodTemplate(cs.apps, state.Intent.TargetKind, state.Intent.TargetKey, cs.resourceUpdate(newValue))
+	if err != nil {
+		klog.Errorf("Update of %s %s failed: %v.", state.Intent.TargetKind, state.Intent.TargetKey, err)
+		cs.IntentEvent(state.Intent, v1.EventTypeWarning, controller.ReasonPerformFailed,
+			"%s failed to update the CPU resources of %s %s: %v", cs.Name(), state.Intent.TargetKind, state.Intent.TargetKey, err)
+	}
+	return err
+}
+
 // roundUpCores returns the next better cpu allocation.
 func roundUpCores(n int64, fraction int64) int64 {
 	a := (n / fraction) * fraction
@@ -281,6 +252,11 @@ func (cs CPUScaleActuator) findStates(
 					newState.Resources[strings.Join([]string{strconv.Itoa(containerIndex), "cpu", "limits"}, delimiter)] = int64(float64(newCPUValue) * cs.cfg.BoostFactor)
 				}
 				newState.CurrentData[cs.Name()] = map[string]float64{cs.Name(): 1}
+				newState.UpdateCapacity(state)
+				if overcommitted := newState.Overcommitted(state); len(overcommitted) > 0 {
+					klog.V(2).Infof("CPU requests of %dm would not fit the nodes - not enough %s.", newCPUValue, strings.Join(overcommitted, ", "))
+					continue
+				}
 
 				// utility function.
 				utility := float64(newCPUValue) / float64(cs.cfg.CPUMax)
@@ -382,16 +358,42 @@ func (cs CPUScaleActuator) NextState(state *common.State, goal *common.State,
 	return nil, nil, nil
 }
 
-func (cs CPUScaleActuator) Perform(state *common.State, plan []planner.Action) {
+// planValue returns the CPU resources the plan asks for - if any.
+func planValue(plan []planner.Action) (int, bool) {
 	for _, item := range plan {
 		if item.Name == actionName {
-			a := item.Properties.(map[string]int64)
-			if val, ok := a["value"]; ok {
-				cs.setResourceValues(state, int(val))
-			}
-			break
+			val, ok := item.Properties.(map[string]int64)["value"]
+			return int(val), ok
 		}
 	}
+	return 0, false
+}
+
+func (cs CPUScaleActuator) Perform(state *common.State, plan []planner.Action) planner.Result {
+	val, ok := planValue(plan)
+	if !ok {
+		return actuators.Skipped(cs.Name(), "no CPU scale action in plan")
+	}
+	object := actuators.Object(state.Intent.TargetKind, state.Intent.TargetKey)
+	if err := cs.setResourceValues(state, val); err != nil {
+		return actuators.Failed(cs.Name(), err, object)
+	}
+	return actuators.Applied(cs.Name(), object)
+}
+
+// DryRun returns the patch Perform would apply to the CPU resources of the workload.
+func (cs CPUScaleActuator) DryRun(state *common.State, plan []planner.Action) []planner.Patch {
+	val, ok := planValue(plan)
+	if !ok {
+		return nil
+	}
+	patch, err := controller.PodTemplatePatch(cs.apps, state.Intent.TargetKind, state.Intent.TargetKey, cs.resourceUpdate(val))
+	if err != nil {
+		klog.Errorf("Could not determine patch for %s %s: %v.", state.Intent.TargetKind, state.Intent.TargetKey, err)
+		return nil
+	}
+	patch.Actuator = cs.Name()
+	return []planner.Patch{patch}
 }
 
 func (cs CPUScaleActuator) Effect(state *common.State, profiles map[string]common.Profile) {
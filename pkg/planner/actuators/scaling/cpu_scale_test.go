@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/intel/intent-driven-orchestration/pkg/controller"
@@ -25,7 +26,7 @@ import (
 // dummyTracerCpu allows us to control what information we give to the actuator.
 type dummyTracerCPU struct{}
 
-func (d dummyTracerCPU) TraceEvent(_ common.State, _ common.State, _ []planner.Action) {
+func (d dummyTracerCPU) TraceEvent(_ common.State, _ common.State, _ []planner.Action, _ []planner.Result) {
 	klog.Fatalf("implement me")
 }
 
@@ -317,6 +318,23 @@ func TestCPUScaleGetResourcesForFailure(t *testing.T) {
 
 // Tests for sanity.
 
+// TestCPUScaleDryRunForSanity tests for sanity.
+func TestCPUScaleDryRunForSanity(t *testing.T) {
+	f := newCPUScaleActuatorFixture(t)
+	f.objects = append(f.objects, createDeployment())
+	actuator := f.newCPUScaleTestActuator(false)
+	s0 := common.State{Intent: common.Intent{TargetKey: "default/my-deployment", TargetKind: "Deployment"}}
+	patches := actuator.DryRun(&s0, []planner.Action{{Name: actionName, Properties: map[string]int64{"value": 2000}}})
+	if len(patches) != 1 || len(patches[0].Diff) == 0 {
+		t.Fatalf("Expected a patch - got: %v.", patches)
+	}
+	for _, item := range patches[0].Diff {
+		if !strings.HasPrefix(item, "spec.template.spec.containers[1].resources.") {
+			t.Errorf("Expected only the resources of the last container to change - got: %s.", item)
+		}
+	}
+}
+
 // TestCPUScaleNextStateForSanity tests for sanity.
 func TestCPUScaleNextStateForSanity(t *testing.T) {
 	f := newCPUScaleActuatorFixture(t)
@@ -374,9 +392,24 @@ func TestCPUScaleNextStateForSanity(t *testing.T) {
 		t.Errorf("Expected one action to set 800 - got: %v", actions)
 	}
 
+	// scaling up needs to fit the node.
+	state.Resources["1_cpu_requests"] = 400
+	state.Nodes = map[string]common.NodeCapacity{"node0": {AllocatableCPU: 2000, RequestedCPU: 1800, FreeCPU: 200}}
+	_, _, actions = actuator.NextState(&state, &goal, profiles)
+	if len(actions) != 0 {
+		t.Errorf("Should not scale up beyond the free CPU of the node - got: %v", actions)
+	}
+	state.Nodes["node0"] = common.NodeCapacity{AllocatableCPU: 2000, RequestedCPU: 1000, FreeCPU: 1000}
+	states, utilities, actions := actuator.NextState(&state, &goal, profiles)
+	if len(actions) != 1 || len(utilities) != 1 || states[0].Nodes["node0"].FreeCPU != 600 {
+		t.Errorf("Expected one action taking 400m of the free CPU - got: %v, %v", actions, states)
+	}
+	state.Resources["1_cpu_requests"] = 1600
+	state.Nodes = nil
+
 	// to strict of a goal.
 	goal.Intent.Objectives["default/p99"] = 1.0
-	states, utilities, actions := actuator.NextState(&state, &goal, profiles)
+	states, utilities, actions = actuator.NextState(&state, &goal, profiles)
 	if len(states) != 0 || len(utilities) != 0 || len(actions) != 0 {
 		t.Errorf("Resultsets should be empty: %v, %v, %v.", states, utilities, actions)
 	}
@@ -7,7 +7,9 @@ import (
 	"github.com/intel/intent-driven-orchestration/pkg/common"
 	"github.com/intel/intent-driven-orchestration/pkg/controller"
 	"github.com/intel/intent-driven-orchestration/pkg/planner"
+	"github.com/intel/intent-driven-orchestration/pkg/planner/actuators"
 
+	coreV1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/util/retry"
@@ -26,10 +28,12 @@ type RmPodConfig struct {
 	PluginManagerEndpoint string `json:"plugin_manager_endpoint"`
 	PluginManagerPort     int    `json:"plugin_manager_port"`
 	MongoEndpoint         string `json:"mongo_endpoint"`
+	ProbesAddress         string `json:"probes_address"`
 }
 
 // RmPodActuator is an actuator that can remove particular PODs.
 type RmPodActuator struct {
+	controller.EventEmitter
 	cfg    RmPodConfig
 	tracer controller.Tracer
 	core   kubernetes.Interface
@@ -43,11 +47,21 @@ func (rm RmPodActuator) Group() string {
 	return scalingGroupName
 }
 
+// InfluencedProfileTypes returns the types of the objectives this actuator predicts.
+func (rm RmPodActuator) InfluencedProfileTypes() []common.ProfileType {
+	return []common.ProfileType{common.Latency, common.Availability, common.Throughput}
+}
+
 func (rm RmPodActuator) NextState(state *common.State, goal *common.State, profiles map[string]common.Profile) ([]common.State, []float64, []planner.Action) {
 	var states []common.State
 	var utilities []float64
 	var actions []planner.Action
 
+	if !controller.IsScalable(state.Intent.TargetKind) {
+		// removing a POD will not reduce the number of replicas.
+		return states, utilities, actions
+	}
+
 	var throughputObjective string
 	for k := range state.Intent.Objectives {
 		if profiles[k].ProfileType == common.ProfileTypeFromText("throughput") {
@@ -65,6 +79,7 @@ func (rm RmPodActuator) NextState(state *common.State, goal *common.State, profi
 		}
 		newState := state.DeepCopy()
 		delete(newState.CurrentPods, podName)
+		newState.UpdateCapacity(state)
 
 		util := 1.0
 		for k := range state.Intent.Objectives {
@@ -92,9 +107,11 @@ func (rm RmPodActuator) NextState(state *common.State, goal *common.State, profi
 	return states, utilities, actions
 }
 
-func (rm RmPodActuator) Perform(state *common.State, plan []planner.Action) {
+func (rm RmPodActuator) Perform(state *common.State, plan []planner.Action) planner.Result {
 	tmp := strings.Split(state.Intent.TargetKey, "/")
 	namespace := tmp[0]
+	var deleted []string
+	var failed *planner.Result
 	for _, item := range plan {
 		if item.Name == rm.Name() {
 			name := item.Properties.(map[string]string)["name"]
@@ -104,9 +121,50 @@ func (rm RmPodActuator) Perform(state *common.State, plan []planner.Action) {
 			})
 			if retryErr != nil {
 				klog.Errorf("failed to delete POD: %v", retryErr)
+				rm.IntentEvent(state.Intent, coreV1.EventTypeWarning, controller.ReasonPerformFailed,
+					"%s failed to delete POD %s: %v", rm.Name(), name, retryErr)
+				if failed == nil {
+					res := actuators.Failed(rm.Name(), retryErr)
+					failed = &res
+				}
+				failed.Objects = append(failed.Objects, actuators.Object("Pod", namespace+"/"+name))
+				continue
 			}
+			deleted = append(deleted, actuators.Object("Pod", namespace+"/"+name))
+		}
+	}
+	if failed != nil {
+		return *failed
+	}
+	if len(deleted) == 0 {
+		return actuators.Skipped(rm.Name(), "no PODs to remove")
+	}
+	return actuators.Applied(rm.Name(), deleted...)
+}
+
+// DryRun returns a deletion for each POD Perform would remove - if it still exists.
+func (rm RmPodActuator) DryRun(state *common.State, plan []planner.Action) []planner.Patch {
+	tmp := strings.Split(state.Intent.TargetKey, "/")
+	namespace := tmp[0]
+	var res []planner.Patch
+	for _, item := range plan {
+		if item.Name == rm.Name() {
+			name := item.Properties.(map[string]string)["name"]
+			if _, err := rm.core.CoreV1().Pods(namespace).Get(context.TODO(), name, metaV1.GetOptions{}); err != nil {
+				klog.Errorf("failed to get POD: %v", err)
+				continue
+			}
+			res = append(res, planner.Patch{
+				Actuator:  rm.Name(),
+				Kind:      "Pod",
+				Namespace: namespace,
+				Name:      name,
+				Type:      planner.PatchTypeDelete,
+				Diff:      []string{name + ": exists -> <deleted>"},
+			})
 		}
 	}
+	return res
 }
 
 func (rm RmPodActuator) Effect(_ *common.State, _ map[string]common.Profile) {
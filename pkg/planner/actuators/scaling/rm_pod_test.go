@@ -90,7 +90,10 @@ func TestRmPerformForSuccess(t *testing.T) {
 	}
 	plan := []planner.Action{{Name: rmPodActionName, Properties: map[string]string{"name": "pod_0"}}}
 	actuator := f.newRmPodTestActuator()
-	actuator.Perform(&s0, plan)
+	result := actuator.Perform(&s0, plan)
+	if result.Status != planner.ResultApplied || len(result.Objects) != 1 || result.Objects[0] != "Pod default/pod_0" {
+		t.Errorf("Expected the POD to be removed - got: %v.", result)
+	}
 }
 
 // TestRmEffectForSuccess tests for success.
@@ -160,14 +163,43 @@ func TestRmPerformForFailure(t *testing.T) {
 	}
 	plan := []planner.Action{{Name: rmPodActionName, Properties: map[string]string{"name": "pod_0"}}}
 	actuator := f.newRmPodTestActuator()
-	actuator.Perform(&s0, plan)
+	result := actuator.Perform(&s0, plan)
 	if len(f.client.Actions()) != 1 {
 		t.Errorf("This is not expected: %v", f.client.Actions())
 	}
+	if result.Status != planner.ResultFailed || result.Transient || len(result.Objects) != 1 {
+		t.Errorf("Expected a permanent failure - got: %v.", result)
+	}
+
+	// nothing to do.
+	if result = actuator.Perform(&s0, nil); result.Status != planner.ResultSkipped {
+		t.Errorf("Expected the actuator to skip - got: %v.", result)
+	}
 }
 
 // Tests for sanity.
 
+// TestRmDryRunForSanity tests for sanity.
+func TestRmDryRunForSanity(t *testing.T) {
+	f := newRmPodActuatorFixture(t)
+	f.objects = []runtime.Object{
+		&coreV1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "pod_0", Namespace: "default"}},
+	}
+	s0 := common.State{Intent: common.Intent{TargetKey: "default/my-deployment", TargetKind: "Deployment"}}
+	plan := []planner.Action{
+		{Name: rmPodActionName, Properties: map[string]string{"name": "pod_0"}},
+		{Name: rmPodActionName, Properties: map[string]string{"name": "pod_1"}},
+	}
+	actuator := f.newRmPodTestActuator()
+	patches := actuator.DryRun(&s0, plan)
+	if len(patches) != 1 || patches[0].Name != "pod_0" || patches[0].Type != planner.PatchTypeDelete {
+		t.Errorf("Expected the deletion of pod_0 only - got: %v.", patches)
+	}
+	if _, err := f.client.CoreV1().Pods("default").Get(t.Context(), "pod_0", metaV1.GetOptions{}); err != nil {
+		t.Errorf("Dry run should not have deleted the POD: %v.", err)
+	}
+}
+
 func TestRmNextStateForSanity(t *testing.T) {
 	f := newRmPodActuatorFixture(t)
 	start := common.State{
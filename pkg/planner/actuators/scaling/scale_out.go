@@ -1,7 +1,6 @@
 package scaling
 
 import (
-	"context"
 	"fmt"
 	"math"
 	"os/exec"
@@ -11,10 +10,10 @@ import (
 	"github.com/intel/intent-driven-orchestration/pkg/common"
 	"github.com/intel/intent-driven-orchestration/pkg/controller"
 	"github.com/intel/intent-driven-orchestration/pkg/planner"
+	"github.com/intel/intent-driven-orchestration/pkg/planner/actuators"
 
-	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 )
 
@@ -43,6 +42,7 @@ type ScaleOutConfig struct {
 	PluginManagerEndpoint  string  `json:"plugin_manager_endpoint"`
 	PluginManagerPort      int     `json:"plugin_manager_port"`
 	MongoEndpoint          string  `json:"mongo_endpoint"`
+	ProbesAddress          string  `json:"probes_address"`
 }
 
 // ScaleOutEffect describes the data that is stored in the knowledge base.
@@ -59,6 +59,7 @@ type ScaleOutEffect struct {
 
 // ScaleOutActuator is an actuator supporting horizontal scaling.
 type ScaleOutActuator struct {
+	controller.EventEmitter
 	cfg    ScaleOutConfig
 	tracer controller.Tracer
 	apps   kubernetes.Interface
@@ -72,6 +73,11 @@ func (scale ScaleOutActuator) Group() string {
 	return scalingGroupName
 }
 
+// InfluencedProfileTypes returns the types of the objectives this actuator predicts.
+func (scale ScaleOutActuator) InfluencedProfileTypes() []common.ProfileType {
+	return []common.ProfileType{common.Latency, common.Availability, common.Throughput}
+}
+
 // averageAvailability calculates the average availability for a set of PODs.
 func averageAvailability(pods map[string]common.PodState) float64 {
 	res := 0.0
@@ -102,6 +108,12 @@ func (scale ScaleOutActuator) findStates(state *common.State, goal *common.State
 		found := false
 		newState := last.DeepCopy()
 		newState.CurrentPods["dummy@"+strconv.Itoa(i)] = common.PodState{Availability: averageAvailability(newState.CurrentPods)}
+		newState.UpdateCapacity(last)
+		if overcommitted := newState.Overcommitted(last); len(overcommitted) > 0 {
+			// more replicas will not fit either.
+			klog.V(2).Infof("%d replicas would not fit the nodes - not enough %s.", len(newState.CurrentPods), strings.Join(overcommitted, ", "))
+			break
+		}
 		for k := range state.Intent.Objectives {
 			if profiles[k].ProfileType == common.ProfileTypeFromText("latency") {
 				res, err := scale.tracer.GetEffect(state.Intent.Key, scale.Group(), k, scale.cfg.LookBack, func() interface{} {
@@ -147,6 +159,10 @@ func (scale ScaleOutActuator) NextState(state *common.State, goal *common.State,
 	if state.IsBetter(goal, profiles) {
 		return nil, nil, nil
 	}
+	if !controller.IsScalable(state.Intent.TargetKind) {
+		klog.V(2).Infof("Cannot scale workload resources of kind: %s.", state.Intent.TargetKind)
+		return nil, nil, nil
+	}
 
 	var throughputObjective string
 	for k := range state.Intent.Objectives {
@@ -166,6 +182,11 @@ func (scale ScaleOutActuator) NextState(state *common.State, goal *common.State,
 			klog.Infof("Trying a proactive scale-out to see if I can learn sth.")
 			tempState := state.DeepCopy()
 			tempState.CurrentPods["proactiveTemp"] = common.PodState{Availability: averageAvailability(tempState.CurrentPods)}
+			tempState.UpdateCapacity(state)
+			if overcommitted := tempState.Overcommitted(state); len(overcommitted) > 0 {
+				klog.V(2).Infof("Proactive scale-out would not fit the nodes - not enough %s.", strings.Join(overcommitted, ", "))
+				return nil, nil, nil
+			}
 			for name := range tempState.Intent.Objectives {
 				if profiles[name].ProfileType == common.ProfileTypeFromText("latency") {
 					tempState.Intent.Objectives[name] *= scale.cfg.ProActiveLatencyFactor
@@ -178,10 +199,9 @@ func (scale ScaleOutActuator) NextState(state *common.State, goal *common.State,
 	return states, utils, actions
 }
 
-func (scale ScaleOutActuator) Perform(state *common.State, plan []planner.Action) {
-	// calculate the scale factor
+// scaleFactor calculates by how many replicas the plan changes the workload.
+func scaleFactor(plan []planner.Action) int64 {
 	var factor int64
-	factor = 0
 	for _, item := range plan {
 		if item.Name == rmPodActionName {
 			factor--
@@ -189,52 +209,47 @@ func (scale ScaleOutActuator) Perform(state *common.State, plan []planner.Action
 			factor += item.Properties.(map[string]int64)["factor"]
 		}
 	}
+	return factor
+}
+
+// replicasUpdate returns the function updating the number of replicas by the given factor.
+func replicasUpdate(factor int64) func(replicas int32) int32 {
+	return func(replicas int32) int32 {
+		// conversion to int32 is ok - as we have a MaxPods defined
+		return replicas + int32(factor) // #nosec G115
+	}
+}
 
+func (scale ScaleOutActuator) Perform(state *common.State, plan []planner.Action) planner.Result {
+	factor := scaleFactor(plan)
+	if factor == 0 {
+		return actuators.Skipped(scale.Name(), "no scale action in plan")
+	}
 	// set replicas.
-	if !strings.Contains(state.Intent.TargetKey, "/") && strings.Count(state.Intent.TargetKey, "/") != 1 {
-		klog.Errorf("not a valid format")
-		return
+	object := actuators.Object(state.Intent.TargetKind, state.Intent.TargetKey)
+	err := controller.UpdateReplicas(scale.apps, state.Intent.TargetKind, state.Intent.TargetKey, replicasUpdate(factor))
+	if err != nil {
+		klog.Errorf("failed to update: %v", err)
+		scale.IntentEvent(state.Intent, coreV1.EventTypeWarning, controller.ReasonPerformFailed,
+			"%s failed to update the replicas of %s %s: %v", scale.Name(), state.Intent.TargetKind, state.Intent.TargetKey, err)
+		return actuators.Failed(scale.Name(), err, object)
 	}
-	tmp := strings.Split(state.Intent.TargetKey, "/")
-	namespace := tmp[0]
-	name := tmp[1]
-	if state.Intent.TargetKind == "Deployment" {
-		retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			res, err := scale.apps.AppsV1().Deployments(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
-			if err != nil {
-				klog.Errorf("failed to get latest version of: %v", err)
-				return err
-			}
-			// conversion to int32 is ok - as we have a MaxPods defined
-			res.Spec.Replicas = getInt32Pointer(*res.Spec.Replicas + int32(factor)) // #nosec G115
-			if *res.Spec.Replicas > 0 {
-				_, updateErr := scale.apps.AppsV1().Deployments(namespace).Update(context.TODO(), res, metaV1.UpdateOptions{})
-				return updateErr
-			}
-			return nil
-		})
-		if retryErr != nil {
-			klog.Errorf("failed to update: %v", retryErr)
-		}
-	} else if state.Intent.TargetKind == "ReplicaSet" {
-		retryErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-			res, err := scale.apps.AppsV1().ReplicaSets(namespace).Get(context.TODO(), name, metaV1.GetOptions{})
-			if err != nil {
-				klog.Errorf("failed to get latest version of: %v", err)
-				return err
-			}
-			// conversion to int32 is ok - as we have a MaxPods defined
-			res.Spec.Replicas = getInt32Pointer(*res.Spec.Replicas + int32(factor)) // #nosec G115
-			if *res.Spec.Replicas > 0 {
-				_, updateErr := scale.apps.AppsV1().ReplicaSets(namespace).Update(context.TODO(), res, metaV1.UpdateOptions{})
-				return updateErr
-			}
-			return nil
-		})
-		if retryErr != nil {
-			klog.Errorf("failed to update: %v", retryErr)
-		}
+	return actuators.Applied(scale.Name(), object)
+}
+
+// DryRun returns the patch Perform would apply to the replicas of the workload.
+func (scale ScaleOutActuator) DryRun(state *common.State, plan []planner.Action) []planner.Patch {
+	factor := scaleFactor(plan)
+	if factor == 0 {
+		return nil
+	}
+	patch, err := controller.ReplicasPatch(scale.apps, state.Intent.TargetKind, state.Intent.TargetKey, replicasUpdate(factor))
+	if err != nil {
+		klog.Errorf("failed to determine patch: %v", err)
+		return nil
 	}
+	patch.Actuator = scale.Name()
+	return []planner.Patch{patch}
 }
 
 func (scale ScaleOutActuator) Effect(state *common.State, profiles map[string]common.Profile) {
@@ -1,24 +1,31 @@
 package scaling
 
 import (
+	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/intel/intent-driven-orchestration/pkg/common"
+	"github.com/intel/intent-driven-orchestration/pkg/controller"
 	"github.com/intel/intent-driven-orchestration/pkg/planner"
 
 	appsV1 "k8s.io/api/apps/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 )
 
 // dummyTracer allows us to control what information we give to the actuator.
 type dummyTracer struct{}
 
-func (d dummyTracer) TraceEvent(_ common.State, _ common.State, _ []planner.Action) {
+func (d dummyTracer) TraceEvent(_ common.State, _ common.State, _ []planner.Action, _ []planner.Result) {
 	klog.Fatalf("implement me")
 }
 
@@ -91,7 +98,9 @@ func TestScalePerformForSuccess(t *testing.T) {
 		CurrentPods: map[string]common.PodState{"pod_0": {}},
 	}
 	var plan []planner.Action
-	actuator.Perform(&s0, plan)
+	if result := actuator.Perform(&s0, plan); result.Status != planner.ResultSkipped {
+		t.Errorf("Expected the actuator to skip - got: %v.", result)
+	}
 }
 
 // TestScaleEffectForSuccess tests for success.
@@ -147,6 +156,13 @@ func TestScaleNextStateForFailure(t *testing.T) {
 	if len(states) > 0 {
 		t.Errorf("Expected empty results set as knowledge base is corrupt/empty. - got: %v", states)
 	}
+
+	// DaemonSets cannot be scaled.
+	state.Intent.TargetKind = "DaemonSet"
+	states, _, _ = actuator.NextState(&state, &goal, profiles)
+	if len(states) > 0 {
+		t.Errorf("Expected empty results set as DaemonSets cannot be scaled - got: %v", states)
+	}
 }
 
 // TestScalePerformForFailure tests for failure.
@@ -154,10 +170,12 @@ func TestScalePerformForFailure(t *testing.T) {
 	f := newScaleOutActuatorFixture(t)
 	f.objects = []runtime.Object{}
 	actuator := f.newScaleOutTestActuator()
+	recorder := record.NewFakeRecorder(1)
+	actuator.SetEventRecorder(recorder)
 
 	// deployment does not exist!
 	s0 := common.State{
-		Intent:      common.Intent{TargetKey: "default/my-deployment", TargetKind: "Deployment"},
+		Intent:      common.Intent{Key: "default/my-objective", TargetKey: "default/my-deployment", TargetKind: "Deployment"},
 		CurrentPods: map[string]common.PodState{"pod_0": {}},
 	}
 	plan := []planner.Action{
@@ -168,6 +186,9 @@ func TestScalePerformForFailure(t *testing.T) {
 	if len(f.client.Actions()) != 1 {
 		t.Errorf("This is not expected: %v", f.client.Actions())
 	}
+	if len(recorder.Events) != 1 || !strings.Contains(<-recorder.Events, controller.ReasonPerformFailed) {
+		t.Error("Expected an event stating that the actuator failed to perform.")
+	}
 
 	// replicaSet does not exist!
 	actuator = f.newScaleOutTestActuator()
@@ -192,6 +213,34 @@ func TestScaleEffectForFailure(t *testing.T) {
 
 // Tests for sanity.
 
+// TestScaleDryRunForSanity tests for sanity.
+func TestScaleDryRunForSanity(t *testing.T) {
+	f := newScaleOutActuatorFixture(t)
+	f.objects = []runtime.Object{
+		&appsV1.Deployment{
+			ObjectMeta: metaV1.ObjectMeta{Name: "my-deployment", Namespace: "default"},
+			Spec:       appsV1.DeploymentSpec{Replicas: getInt32Pointer(1)},
+		},
+	}
+	actuator := f.newScaleOutTestActuator()
+	s0 := common.State{Intent: common.Intent{TargetKey: "default/my-deployment", TargetKind: "Deployment"}}
+
+	// nothing to do.
+	if patches := actuator.DryRun(&s0, []planner.Action{{Name: "foo"}}); len(patches) != 0 {
+		t.Errorf("Expected no patches - got: %v.", patches)
+	}
+
+	// scale out - but do not apply it.
+	patches := actuator.DryRun(&s0, []planner.Action{{Name: scaleOutActionName, Properties: map[string]int64{"factor": 2}}})
+	if len(patches) != 1 || patches[0].Actuator != actuator.Name() || patches[0].Data != `{"spec":{"replicas":3}}` {
+		t.Errorf("Unexpected patches: %v.", patches)
+	}
+	res, _ := f.client.AppsV1().Deployments("default").Get(t.Context(), "my-deployment", metaV1.GetOptions{})
+	if *res.Spec.Replicas != 1 {
+		t.Errorf("Dry run should not have changed the replicas - got: %d.", *res.Spec.Replicas)
+	}
+}
+
 // TestScaleNextStateForSanity tests for sanity.
 func TestScaleNextStateForSanity(t *testing.T) {
 	f := newScaleOutActuatorFixture(t)
@@ -277,6 +326,51 @@ func TestScaleNextStateForSanity(t *testing.T) {
 	}
 }
 
+// TestScaleNodeCapacityForSanity tests for sanity.
+func TestScaleNodeCapacityForSanity(t *testing.T) {
+	f := newScaleOutActuatorFixture(t)
+	actuator := f.newScaleOutTestActuator()
+
+	state := common.State{
+		Intent: common.Intent{
+			Key:        "default/my-objective",
+			Priority:   1.0,
+			TargetKey:  "default/my-deployment",
+			TargetKind: "Deployment",
+			Objectives: map[string]float64{"default/p99": 10.0, "default/rps": 100.0, "default/availability": 1.0},
+		},
+		CurrentPods: map[string]common.PodState{
+			"pod0": {NodeName: "node0", Availability: 1.0},
+		},
+		Resources: map[string]int64{"0_cpu_requests": 1000},
+		Nodes:     map[string]common.NodeCapacity{"node0": {AllocatableCPU: 3000, RequestedCPU: 2000, FreeCPU: 1000}},
+	}
+	goal := common.State{}
+	goal.Intent.Priority = 1.0
+	goal.Intent.Objectives = map[string]float64{"default/p99": 3.0, "default/rps": 0.0, "default/availability": 0.999}
+	profiles := map[string]common.Profile{
+		"default/p99":          {ProfileType: common.ProfileTypeFromText("latency"), Minimize: true},
+		"default/rps":          {ProfileType: common.ProfileTypeFromText("throughput"), Minimize: false},
+		"default/availability": {ProfileType: common.ProfileTypeFromText("availability"), Minimize: false},
+	}
+
+	// only 1 more replica fits the node.
+	states, _, actions := actuator.NextState(&state, &goal, profiles)
+	if len(actions) != 1 || actions[0].Properties.(map[string]int64)["factor"] != 1 {
+		t.Errorf("Expected to scale out by 1 replica - got: %v.", actions)
+	}
+	if len(states) != 1 || states[0].Nodes["node0"].FreeCPU != 0 || states[0].Headroom.FreeCPU != 0 {
+		t.Errorf("Expected the new replica to take the free CPU - got: %v.", states)
+	}
+
+	// no room for another replica - not even for a proactive scale-out.
+	state.Nodes["node0"] = common.NodeCapacity{AllocatableCPU: 3000, RequestedCPU: 2500, FreeCPU: 500}
+	states, _, actions = actuator.NextState(&state, &goal, profiles)
+	if len(states) != 0 || len(actions) != 0 {
+		t.Errorf("Should not scale out if the replicas do not fit - got: %v, %v.", states, actions)
+	}
+}
+
 // TestScalePerformForSanity tests for sanity.
 func TestScalePerformForSanity(t *testing.T) {
 	f := newScaleOutActuatorFixture(t)
@@ -299,6 +393,15 @@ func TestScalePerformForSanity(t *testing.T) {
 				Replicas: getInt32Pointer(1),
 			},
 		},
+		&appsV1.StatefulSet{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      "my-statefulset",
+				Namespace: "default",
+			},
+			Spec: appsV1.StatefulSetSpec{
+				Replicas: getInt32Pointer(1),
+			},
+		},
 	}
 	actuator := f.newScaleOutTestActuator()
 
@@ -330,6 +433,28 @@ func TestScalePerformForSanity(t *testing.T) {
 			t.Errorf("Expected %s - got %s.", expectedActions[i], action)
 		}
 	}
+
+	// test for statefulset.
+	actuator = f.newScaleOutTestActuator()
+	s0.Intent.TargetKey = "default/my-statefulset"
+	s0.Intent.TargetKind = "StatefulSet"
+	result := actuator.Perform(&s0, plan)
+	res, _ := f.client.AppsV1().StatefulSets("default").Get(context.TODO(), "my-statefulset", metaV1.GetOptions{})
+	if *res.Spec.Replicas != 2 {
+		t.Errorf("Expected 2 replicas - got %d.", *res.Spec.Replicas)
+	}
+	if result.Status != planner.ResultApplied || len(result.Objects) != 1 || result.Objects[0] != "StatefulSet default/my-statefulset" {
+		t.Errorf("Expected the replicas to be updated - got: %v.", result)
+	}
+
+	// API server times out - might work when trying again.
+	actuator = f.newScaleOutTestActuator()
+	f.client.PrependReactor("update", "statefulsets", func(_ core.Action) (bool, runtime.Object, error) {
+		return true, nil, apiErrors.NewServerTimeout(schema.GroupResource{Resource: "statefulsets"}, "update", 1)
+	})
+	if result = actuator.Perform(&s0, plan); result.Status != planner.ResultFailed || !result.Transient {
+		t.Errorf("Expected a transient failure - got: %v.", result)
+	}
 }
 
 // TestScaleEffectForSanity tests for sanity.
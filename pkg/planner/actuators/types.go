@@ -13,13 +13,53 @@ type Plugin interface {
 	Group() string
 }
 
+// ProfileTypeInfluencer can optionally be implemented by actuators to declare the types of KPI profiles they can
+// influence. The planner keeps the current values of all other objectives in the states proposed by the actuator.
+type ProfileTypeInfluencer interface {
+	// InfluencedProfileTypes returns the profile types this actuator can predict changes for.
+	InfluencedProfileTypes() []common.ProfileType
+}
+
+// HealthChecker can optionally be implemented by actuators which rely on e.g. a subprocess to report whether they are
+// still able to serve.
+type HealthChecker interface {
+	// Healthy returns an error if the actuator cannot serve anymore.
+	Healthy() error
+}
+
+// DryRunner can optionally be implemented by actuators which can report the changes they would apply to the cluster;
+// used when the planner runs in shadow mode.
+type DryRunner interface {
+	// DryRun returns the patches the actuator would apply when performing those actions of the plan it is in charge of.
+	DryRun(state *common.State, plan []planner.Action) []planner.Patch
+}
+
+// Influences checks if an actuator can influence objectives of the given profile type; actuators which do not declare
+// any types are assumed to influence all of them.
+func Influences(actuator Actuator, profileType common.ProfileType) bool {
+	influencer, ok := actuator.(ProfileTypeInfluencer)
+	if !ok {
+		return true
+	}
+	types := influencer.InfluencedProfileTypes()
+	if len(types) == 0 {
+		return true
+	}
+	for _, item := range types {
+		if item == profileType {
+			return true
+		}
+	}
+	return false
+}
+
 // Actuator defines the interface for the actuators.
 type Actuator interface {
 	Plugin
 	// NextState should return a set of potential follow-up states for a given state if this actuator would potentially be used.
 	NextState(state *common.State, goal *common.State, profiles map[string]common.Profile) ([]common.State, []float64, []planner.Action)
-	// Perform should perform those actions of the plan that it is in charge off.
-	Perform(state *common.State, plan []planner.Action)
+	// Perform should perform those actions of the plan that it is in charge off and report the outcome.
+	Perform(state *common.State, plan []planner.Action) planner.Result
 	// Effect should (optionally) recalculate the effect this actuator has for ALL objectives for this workload.
 	Effect(state *common.State, profiles map[string]common.Profile)
 }
@@ -3,12 +3,18 @@ package astar
 import (
 	"container/heap"
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	coreV1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 
 	plugins "github.com/intel/intent-driven-orchestration/pkg/api/plugins/v1alpha1"
 
 	"github.com/intel/intent-driven-orchestration/pkg/common"
+	"github.com/intel/intent-driven-orchestration/pkg/controller"
 	"github.com/intel/intent-driven-orchestration/pkg/planner"
 	"github.com/intel/intent-driven-orchestration/pkg/planner/actuators"
 )
@@ -21,6 +27,7 @@ const opportunisticActionName string = "opportunistic"
 
 // APlanner represent a planner using the A* algorithm.
 type APlanner struct {
+	controller.EventEmitter
 	cfg common.Config
 	pm  plugins.ActuatorsPluginManager
 }
@@ -49,16 +56,41 @@ func getNodeForState(sg stateGraph, state common.State) (Node, bool) {
 	// reverse as we expect items to show up at end of list; hence we can break out of loop faster!
 	for i := len(sg.nodes) - 1; i >= 0; i-- {
 		node := sg.nodes[i]
-		// TODO: check perf impact of reflect!
-		if reflect.DeepEqual(*(node.value.(*common.State)), state) {
+		if sameState(node.value.(*common.State), &state) {
 			return node, true
 		}
 	}
 	return Node{&state}, false
 }
 
-// generateStateGraph creates the overall state graph.
-func (p APlanner) generateStateGraph(start common.State, goal common.State, profiles map[string]common.Profile) (stateGraph, Node, Node, bool) {
+// sameState checks if two states are equal - apart from the capacity of the nodes & the nodes new PODs were placed on,
+// which depend on the path that led to a state.
+func sameState(one *common.State, other *common.State) bool {
+	if len(one.CurrentPods) != len(other.CurrentPods) {
+		return false
+	}
+	for name, pod := range one.CurrentPods {
+		otherPod, ok := other.CurrentPods[name]
+		if !ok {
+			return false
+		}
+		pod.NodeName, otherPod.NodeName = "", ""
+		if pod != otherPod {
+			return false
+		}
+	}
+	a, b := *one, *other
+	a.CurrentPods, b.CurrentPods = nil, nil
+	a.Nodes, b.Nodes = nil, nil
+	a.Headroom, b.Headroom = common.NodeCapacity{}, common.NodeCapacity{}
+	// TODO: check perf impact of reflect!
+	return reflect.DeepEqual(a, b)
+}
+
+// generateStateGraph creates the overall state graph using the actuators permitted by the intent. Candidate states
+// breaking the budget of the intent are pruned - the budget constraints which caused states to be pruned are returned.
+// Candidate states whose PODs do not fit the capacity of the nodes are pruned as well.
+func (p APlanner) generateStateGraph(start common.State, goal common.State, profiles map[string]common.Profile) (stateGraph, Node, Node, bool, []string) {
 	// let planning algorithm expand successors in future - for now this is easier to "knit in" the goal state, deal with duplicate states, etc.
 	sg := newStateGraph()
 	startNode := Node{&start}
@@ -68,6 +100,7 @@ func (p APlanner) generateStateGraph(start common.State, goal common.State, prof
 	queue := []Node{startNode}
 
 	hasGoal := false
+	binding := map[string]bool{}
 
 	for len(queue) > 0 && len(sg.nodes) < p.cfg.Planner.AStar.MaxStates {
 		// current element...
@@ -75,10 +108,31 @@ func (p APlanner) generateStateGraph(start common.State, goal common.State, prof
 		queue = queue[1:]
 		// find all success elements using actuators...
 		itFct := func(a actuators.Actuator) {
+			if !goal.Intent.PermitsActuator(a.Name(), a.Group()) || goal.Intent.CoolingDown(a.Name(), a.Group()) {
+				return
+			}
+			start := time.Now()
 			candidates, utils, actions := a.NextState(current.value.(*common.State), &goal, profiles)
+			common.NextStateDuration.WithLabelValues(a.Name()).Observe(time.Since(start).Seconds())
 			i := 0
 			for i < len(candidates) && i < p.cfg.Planner.AStar.MaxCandidates {
 				state := candidates[i]
+				retainUninfluenced(a, current.value.(*common.State), &state, profiles)
+				if exceeded := goal.Intent.Budget.Exceeded(current.value.(*common.State), &state); len(exceeded) > 0 {
+					klog.V(2).Infof("Pruning state as it breaks the budget: %v.", exceeded)
+					for _, constraint := range exceeded {
+						binding[constraint] = true
+					}
+					i++
+					continue
+				}
+				// actuators might not keep track of the capacity of the nodes - so let's make sure the state fits.
+				state.UpdateCapacity(current.value.(*common.State))
+				if overcommitted := state.Overcommitted(current.value.(*common.State)); len(overcommitted) > 0 {
+					klog.V(2).Infof("Pruning state as it does not fit the nodes - not enough %s.", strings.Join(overcommitted, ", "))
+					i++
+					continue
+				}
 				// TODO: add safeguard - we do not need 10 actions which lead to the same outcome.
 				stateNode, found := getNodeForState(*sg, state)
 				if !found {
@@ -96,6 +150,10 @@ func (p APlanner) generateStateGraph(start common.State, goal common.State, prof
 		}
 		p.pm.Iter(itFct)
 	}
+	if len(queue) > 0 {
+		klog.V(2).Infof("Reached the max number of states: %d.", p.cfg.Planner.AStar.MaxStates)
+		common.MaxStatesReached.Inc()
+	}
 	// if desired > goal we also add a shortcut path with the cost of the depth of the graph. Additionally, we add a
 	// little costs if any action in the current graph would have modified sth.
 	if start.IsBetter(&goal, profiles) && len(sg.successors) > 0 {
@@ -117,7 +175,25 @@ func (p APlanner) generateStateGraph(start common.State, goal common.State, prof
 			hasGoal = true
 		}
 	}
-	return *sg, startNode, endNode, hasGoal
+	var constraints []string
+	for constraint := range binding {
+		constraints = append(constraints, constraint)
+	}
+	sort.Strings(constraints)
+	return *sg, startNode, endNode, hasGoal, constraints
+}
+
+// retainUninfluenced resets the objectives of a candidate state, which the actuator cannot influence, to their current
+// values; this way e.g. objectives of profile types an actuator does not know are not misread.
+func retainUninfluenced(a actuators.Actuator, current *common.State, candidate *common.State, profiles map[string]common.Profile) {
+	for key := range candidate.Intent.Objectives {
+		if actuators.Influences(a, profiles[key].ProfileType) {
+			continue
+		}
+		if value, ok := current.Intent.Objectives[key]; ok {
+			candidate.Intent.Objectives[key] = value
+		}
+	}
 }
 
 // heuristic for finding shorted path.
@@ -125,13 +201,13 @@ func hEmpty(_ Node, _ Node, _ map[string]common.Profile) float64 {
 	return 0.0
 }
 
-// heuristic based on the distance of the states.
-func h(one Node, other Node, profiles map[string]common.Profile) float64 {
+// heuristic based on the distance of the states; the goal state defines the per objective weights & normalizations.
+func h(one Node, goal Node, profiles map[string]common.Profile) float64 {
 	state := one.value.(*common.State)
-	return state.Distance(other.value.(*common.State), profiles)
+	return state.Distance(goal.value.(*common.State), profiles)
 }
 
-// addAdditionalStates will add edges between n states with the closest distance to the goal state to the state graph.
+// addAdditionalStates will add edges between n states with the closest (weighted & normalized) distance to the goal state to the state graph.
 func (p APlanner) addAdditionalStates(sg stateGraph, start Node, goal Node, profiles map[string]common.Profile) stateGraph {
 	minDistances := make(PriorityQueue, 0)
 	heap.Init(&minDistances)
@@ -156,51 +232,124 @@ func (p APlanner) addAdditionalStates(sg stateGraph, start Node, goal Node, prof
 }
 
 func (p APlanner) CreatePlan(current common.State, desired common.State, profiles map[string]common.Profile) []planner.Action {
+	plan, _ := p.CreatePredictedPlan(current, desired, profiles)
+	return plan
+}
+
+// CreatePredictedPlan creates a plan - and returns the state each actual action of the plan leads to.
+func (p APlanner) CreatePredictedPlan(current common.State, desired common.State, profiles map[string]common.Profile) ([]planner.Action, []common.State) {
 	klog.V(2).Infof("Trying to create a plan to get from %v to %v.", current, desired)
+	var path []Node
 	var plan []planner.Action
 
-	sg, s0, g0, goal := p.generateStateGraph(current, desired, profiles)
+	sg, s0, g0, goal, binding := p.generateStateGraph(current, desired, profiles)
 	klog.V(2).Infof("State graph has %d nodes.", len(sg.nodes))
+	common.StateGraphNodes.Observe(float64(len(sg.nodes)))
+	common.StateGraphEdges.Observe(float64(sg.numEdges()))
+	if len(binding) > 0 {
+		klog.Infof("Budget of %s was the binding constraint: %s.", desired.Intent.Key, strings.Join(binding, ", "))
+		p.IntentEvent(desired.Intent, coreV1.EventTypeNormal, controller.ReasonBudgetBinding,
+			"Budget limited the plan - pruned states exceeding the %s.", strings.Join(binding, ", "))
+	}
 	if goal {
-		_, actions := solve(sg, s0, g0, h, true, profiles)
-		plan = actions
+		path, plan = solve(sg, s0, g0, h, true, profiles)
 	} else {
 		klog.Warning("No path to goal state possible!")
+		p.IntentEvent(desired.Intent, coreV1.EventTypeWarning, controller.ReasonNoPathToGoal,
+			"No path to goal state possible - explored %d states.", len(sg.nodes))
 		if p.cfg.Planner.AStar.OpportunisticCandidates > 0 {
 			klog.Infof("Opportunistic planning is enabled - will add %d states with closest distance to the "+
 				"desired state to the state graph.", p.cfg.Planner.AStar.OpportunisticCandidates)
 			sg = p.addAdditionalStates(sg, s0, g0, profiles)
-			_, actions := solve(sg, s0, g0, h, true, profiles)
-			plan = actions
+			path, plan = solve(sg, s0, g0, h, true, profiles)
 		}
 	}
 	var finalPlan []planner.Action
-	for _, item := range plan {
+	var predicted []common.State
+	for i, item := range plan {
 		if item.Name == emptyActionName || item.Name == opportunisticActionName {
 			continue
 		}
 		finalPlan = append(finalPlan, item)
+		// the path holds the start node followed by the node each action leads to.
+		predicted = append(predicted, path[i+1].value.(*common.State).DeepCopy())
 	}
 	klog.V(2).Infof("A*star planner found: %v.", finalPlan)
-	return finalPlan
+	return finalPlan, predicted
 }
 
-func (p APlanner) ExecutePlan(state common.State, plan []planner.Action) {
+func (p APlanner) ExecutePlan(state common.State, plan []planner.Action) []planner.Result {
 	klog.V(2).Info("Execute plan called.")
+	var res []planner.Result
 	itFct := func(a actuators.Actuator) {
-		a.Perform(&state, plan)
+		if !state.Intent.PermitsActuator(a.Name(), a.Group()) {
+			klog.V(2).Infof("Actuator %s is not permitted for %s.", a.Name(), state.Intent.Key)
+			result := actuators.Skipped(a.Name(), "actuator not permitted")
+			result.Group = a.Group()
+			res = append(res, result)
+			return
+		}
+		if state.Intent.CoolingDown(a.Name(), a.Group()) {
+			klog.V(2).Infof("Actuator %s is cooling down for %s.", a.Name(), state.Intent.Key)
+			result := actuators.Skipped(a.Name(), "actuator cooling down")
+			result.Group = a.Group()
+			res = append(res, result)
+			return
+		}
+		for _, action := range plan {
+			if action.Name == a.Name() {
+				common.ActionsPerformed.WithLabelValues(a.Name()).Inc()
+			}
+		}
+		result := a.Perform(&state, plan)
+		if result.Actuator == "" {
+			result.Actuator = a.Name()
+		}
+		result.Group = a.Group()
+		res = append(res, result)
 	}
 	p.pm.Iter(itFct)
+	return res
 }
 
+// DryRunPlan asks all permitted actuators which support dry runs for the patches they would apply when performing the
+// plan.
+func (p APlanner) DryRunPlan(state common.State, plan []planner.Action) []planner.Patch {
+	klog.V(2).Info("Dry run of plan called.")
+	var res []planner.Patch
+	itFct := func(a actuators.Actuator) {
+		if !state.Intent.PermitsActuator(a.Name(), a.Group()) || state.Intent.CoolingDown(a.Name(), a.Group()) {
+			return
+		}
+		dryRunner, ok := a.(actuators.DryRunner)
+		if !ok {
+			klog.V(2).Infof("Actuator %s does not support dry runs.", a.Name())
+			return
+		}
+		res = append(res, dryRunner.DryRun(&state, plan)...)
+	}
+	p.pm.Iter(itFct)
+	return res
+}
+
+// TriggerEffect triggers the re-calculation of the effects on all actuators in parallel - and waits for them to finish.
 func (p APlanner) TriggerEffect(current common.State, profiles map[string]common.Profile) {
 	klog.V(2).Info("Trigger effect re-calculation on all actuators.")
+	var wg sync.WaitGroup
 	itFct := func(a actuators.Actuator) {
-		// TODO fix go routines, spawn them in the actuators
-		go a.Effect(&current, profiles)
-		// TODO do we want to have a wait channel here and block until everything done?
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.Effect(&current, profiles)
+		}()
 	}
 	p.pm.Iter(itFct)
+	wg.Wait()
+}
+
+// Ready checks if the plugin manager is listening for plugin registrations.
+func (p APlanner) Ready() error {
+	return p.pm.Ready()
 }
 
 func (p APlanner) Stop() {
@@ -1,6 +1,7 @@
 package astar
 
 import (
+	"context"
 	"encoding/base64"
 
 	"github.com/intel/intent-driven-orchestration/pkg/controller"
@@ -10,6 +11,9 @@ import (
 	"github.com/intel/intent-driven-orchestration/pkg/planner"
 	"github.com/intel/intent-driven-orchestration/pkg/planner/actuators"
 
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 
 	"crypto/rand"
@@ -17,6 +21,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -109,13 +114,14 @@ func (rm rmAction) NextState(state *common.State, _ *common.State, _ map[string]
 	return followUpStates, utilities, actions
 }
 
-func (rm rmAction) Perform(_ *common.State, plan []planner.Action) {
+func (rm rmAction) Perform(_ *common.State, plan []planner.Action) planner.Result {
 	for _, action := range plan {
 		if action.Name == rm.Name() {
 			rm.actionTrigger <- rm.Name()
-			return
+			return planner.Result{Status: planner.ResultApplied}
 		}
 	}
+	return planner.Result{Status: planner.ResultSkipped}
 }
 
 func (rm rmAction) Effect(state *common.State, _ map[string]common.Profile) {
@@ -195,13 +201,14 @@ func (scale scaleAction) NextState(state *common.State, goal *common.State, _ ma
 	return followUpStates, utilities, actions
 }
 
-func (scale scaleAction) Perform(_ *common.State, plan []planner.Action) {
+func (scale scaleAction) Perform(_ *common.State, plan []planner.Action) planner.Result {
 	for _, action := range plan {
 		if action.Name == scale.Name() {
 			scale.actionTrigger <- scale.Name()
-			return
+			return planner.Result{Status: planner.ResultApplied}
 		}
 	}
+	return planner.Result{Status: planner.ResultSkipped}
 }
 
 func (scale scaleAction) Effect(state *common.State, _ map[string]common.Profile) {
@@ -252,13 +259,14 @@ func (res resourceAction) NextState(state *common.State, _ *common.State, _ map[
 	return followUpStates, utilities, actions
 }
 
-func (res resourceAction) Perform(_ *common.State, plan []planner.Action) {
+func (res resourceAction) Perform(_ *common.State, plan []planner.Action) planner.Result {
 	for _, action := range plan {
 		if action.Name == res.Name() {
 			res.actionTrigger <- res.Name()
-			return
+			return planner.Result{Status: planner.ResultApplied}
 		}
 	}
+	return planner.Result{Status: planner.ResultSkipped}
 }
 
 func (res resourceAction) Effect(state *common.State, _ map[string]common.Profile) {
@@ -313,14 +321,89 @@ func (faulty faultyAction) NextState(state *common.State, _ *common.State, _ map
 	return followUpStates, utilities, actions
 }
 
-func (faulty faultyAction) Perform(_ *common.State, _ []planner.Action) {
+func (faulty faultyAction) Perform(_ *common.State, _ []planner.Action) planner.Result {
 	klog.Fatal("implement me")
+	return planner.Result{}
 }
 
 func (faulty faultyAction) Effect(_ *common.State, _ map[string]common.Profile) {
 	klog.Fatalf("implement me")
 }
 
+// tradeOffAction represents a dummy action trading latency for throughput and vice versa.
+type tradeOffAction struct{}
+
+func (tradeOff tradeOffAction) Name() string {
+	return "trade_off"
+}
+
+func (tradeOff tradeOffAction) Group() string {
+	return "scaling"
+}
+
+func (tradeOff tradeOffAction) NextState(state *common.State, _ *common.State, _ map[string]common.Profile) ([]common.State, []float64, []planner.Action) {
+	if state.Intent.Objectives["p99latency"] != 150 {
+		return nil, nil, nil
+	}
+	cutLatency := state.DeepCopy()
+	cutLatency.Intent.Objectives["p99latency"] = 100
+	cutLatency.Intent.Objectives["rps"] = 900
+	boostRps := state.DeepCopy()
+	boostRps.Intent.Objectives["p99latency"] = 160
+	boostRps.Intent.Objectives["rps"] = 1100
+	return []common.State{cutLatency, boostRps}, []float64{1.0, 1.0}, []planner.Action{
+		{Name: tradeOff.Name(), Properties: map[string]string{"favor": "latency"}},
+		{Name: tradeOff.Name(), Properties: map[string]string{"favor": "throughput"}},
+	}
+}
+
+func (tradeOff tradeOffAction) Perform(_ *common.State, _ []planner.Action) planner.Result {
+	return planner.Result{Status: planner.ResultSkipped}
+}
+
+func (tradeOff tradeOffAction) Effect(_ *common.State, _ map[string]common.Profile) {}
+
+// blockingEffectAction represents a dummy action whose effect calculation blocks until released.
+type blockingEffectAction struct {
+	tradeOffAction
+	started chan struct{}
+	release chan struct{}
+	running *atomic.Int32
+}
+
+func (blocking blockingEffectAction) Name() string {
+	return "blocking_effect"
+}
+
+func (blocking blockingEffectAction) Effect(_ *common.State, _ map[string]common.Profile) {
+	blocking.running.Add(1)
+	defer blocking.running.Add(-1)
+	select {
+	case blocking.started <- struct{}{}:
+	default:
+	}
+	<-blocking.release
+}
+
+// nopTracer for testing.
+type nopTracer struct{}
+
+func (t nopTracer) TraceEvent(_ common.State, _ common.State, _ []planner.Action, _ []planner.Result) {
+}
+
+func (t nopTracer) GetEffect(_ string, _ string, _ string, _ int, _ func() interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+// latencyAction represents a dummy action declaring that it can only influence latency objectives.
+type latencyAction struct {
+	rmAction
+}
+
+func (latency latencyAction) InfluencedProfileTypes() []common.ProfileType {
+	return []common.ProfileType{common.Latency}
+}
+
 // newTestPlanner
 func (f *aStarPlannerFixture) newTestPlanner(enableOpportunistic bool) *APlanner {
 	channel := f.triggerUpdate()
@@ -337,6 +420,7 @@ func (f *aStarPlannerFixture) newTestPlanner(enableOpportunistic bool) *APlanner
 
 func createPlugin(name string, port int, actuator actuators.Actuator, serverPort int) (*plugins.ActuatorPluginStub, error) {
 	stub := plugins.NewActuatorPluginStub(name, "localhost", port, "localhost", serverPort)
+	stub.SetGroup(actuator.Group())
 	stub.SetNextStateFunc(actuator.NextState)
 	stub.SetPerformFunc(actuator.Perform)
 	stub.SetEffectFunc(actuator.Effect)
@@ -430,6 +514,28 @@ func getPlannerTestCases(enableOpportunistic bool) []testCaseData {
 	}
 }
 
+// sampleCount returns the number of observations of a histogram, or the value of a counter, summed over all series.
+func sampleCount(name string) float64 {
+	families, err := common.MetricsRegistry.Gather()
+	if err != nil {
+		return -1
+	}
+	res := 0.0
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			if metric.Histogram != nil {
+				res += float64(metric.GetHistogram().GetSampleCount())
+			} else if metric.Counter != nil {
+				res += metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return res
+}
+
 // Tests for success.
 
 // TestGetNodeForStateForSuccess tests for sanity.
@@ -490,6 +596,23 @@ func TestExecutePlanForSuccess(_ *testing.T) {
 	aPlanner.ExecutePlan(state, plan)
 }
 
+// TestDryRunPlanForSuccess tests for success.
+func TestDryRunPlanForSuccess(t *testing.T) {
+	f := newAStarPlannerFixture()
+	state := common.State{Intent: common.Intent{
+		Key:        "foo",
+		Priority:   0,
+		TargetKey:  "",
+		TargetKind: "",
+		Objectives: map[string]float64{"p99": 100},
+	}}
+	aPlanner := f.newTestPlanner(false)
+	defer aPlanner.Stop()
+	if patches := aPlanner.DryRunPlan(state, nil); len(patches) != 0 {
+		t.Errorf("Expected no patches - got: %v.", patches)
+	}
+}
+
 // TestTriggerEffectForSuccess tests for success.
 func TestTriggerEffectForSuccess(_ *testing.T) {
 	f := newAStarPlannerFixture()
@@ -557,6 +680,25 @@ func TestGetNodeForStateForSanity(t *testing.T) {
 	if found == true {
 		t.Errorf("Should be true.")
 	}
+
+	// node data depends on the path which led to a state - and is not taken into account.
+	state3 := state0.DeepCopy()
+	state3.CurrentPods = map[string]common.PodState{"dummy@1": {NodeName: "node0"}}
+	state3.Nodes = map[string]common.NodeCapacity{"node0": {FreeCPU: 1000}}
+	state3.Headroom = common.Headroom(state3.Nodes)
+	node3 := Node{&state3}
+	sg.addNode(node3)
+	state4 := state3.DeepCopy()
+	state4.CurrentPods["dummy@1"] = common.PodState{NodeName: "node1"}
+	state4.Nodes = map[string]common.NodeCapacity{"node1": {FreeCPU: 500}}
+	state4.Headroom = common.Headroom(state4.Nodes)
+	if res, found = getNodeForState(*sg, state4); !found || res != node3 {
+		t.Errorf("Expected the state to be found - got: %v.", res)
+	}
+	state4.CurrentPods["dummy@1"] = common.PodState{NodeName: "node1", State: "Running"}
+	if _, found = getNodeForState(*sg, state4); found {
+		t.Error("Expected the state not to be found.")
+	}
 }
 
 // TestCreatePlanForSuccess tests for sanity.
@@ -601,6 +743,12 @@ func TestCreatePlanForSanity(t *testing.T) {
 				}
 			}
 
+			// state the plan leads to is predicted.
+			plan, predicted := testCase.planner.CreatePredictedPlan(start, goal, profiles)
+			if len(plan) != 1 || len(predicted) != 1 || predicted[0].Intent.Objectives["p99latency"] != 40 || len(predicted[0].CurrentPods) != 3 {
+				t.Errorf("Expected the state after scaling to be predicted - got: %v.", predicted)
+			}
+
 			// no path to goal state possible.
 			start1 := start.DeepCopy()
 			delete(start1.Intent.Objectives, "p99latency")
@@ -626,6 +774,214 @@ func TestCreatePlanForSanity(t *testing.T) {
 	}
 }
 
+// TestBudgetForSanity tests for sanity.
+func TestBudgetForSanity(t *testing.T) {
+	testCases := getPlannerTestCases(false)
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			start := common.State{
+				Intent: common.Intent{
+					Key:        "default/my-objective",
+					TargetKey:  "default/my-deployment",
+					TargetKind: "Deployment",
+					Objectives: map[string]float64{"p99latency": 150},
+				},
+				CurrentPods: map[string]common.PodState{"pod_0": {Availability: 1.0}, "pod_1": {Availability: 1.0}},
+				CurrentData: map[string]map[string]float64{},
+			}
+			goal := common.State{
+				Intent: common.Intent{
+					Key:        "default/my-objective",
+					Priority:   1.0,
+					Objectives: map[string]float64{"p99latency": 50},
+					Budget:     &common.Budget{MaxReplicas: 2},
+				},
+			}
+			profiles := map[string]common.Profile{"p99latency": {ProfileType: common.ProfileTypeFromText("latency"), Minimize: true}}
+			testCase.planner = testCase.plannerCrt(testCase.fixture)
+			testCase.stubs = testCase.stubsCrt(testCase.fixture)
+			recorder := record.NewFakeRecorder(2)
+			testCase.planner.SetEventRecorder(recorder)
+
+			// scaling out breaks the budget.
+			res := testCase.planner.CreatePlan(start, goal, profiles)
+			if len(res) != 0 {
+				t.Errorf("Expected no plan - got: %v.", res)
+			}
+			if len(recorder.Events) != 2 || !strings.Contains(<-recorder.Events, controller.ReasonBudgetBinding+" Budget limited the plan - pruned states exceeding the max replicas of 2.") {
+				t.Error("Expected an event stating that the budget was binding.")
+			}
+			<-recorder.Events
+
+			// enough budget.
+			goal.Intent.Budget.MaxReplicas = 3
+			res = testCase.planner.CreatePlan(start, goal, profiles)
+			if len(res) != 1 || res[0].Name != "set_replicas" {
+				t.Errorf("Expected to scale out - got: %v.", res)
+			}
+			if len(recorder.Events) != 0 {
+				t.Errorf("Budget should not have been binding - got: %s.", <-recorder.Events)
+			}
+		})
+		testCase.stop()
+		testCase.planner.Stop()
+	}
+}
+
+// TestNodeCapacityForSanity tests for sanity.
+func TestNodeCapacityForSanity(t *testing.T) {
+	testCases := getPlannerTestCases(false)
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			start := common.State{
+				Intent: common.Intent{
+					Key:        "default/my-objective",
+					TargetKey:  "default/my-deployment",
+					TargetKind: "Deployment",
+					Objectives: map[string]float64{"p99latency": 150},
+				},
+				CurrentPods: map[string]common.PodState{"pod_0": {Availability: 1.0, NodeName: "node0"}, "pod_1": {Availability: 1.0, NodeName: "node0"}},
+				CurrentData: map[string]map[string]float64{},
+				Resources:   map[string]int64{"0_cpu_requests": 1000},
+				Nodes:       map[string]common.NodeCapacity{"node0": {AllocatableCPU: 3000, RequestedCPU: 2500, FreeCPU: 500}},
+			}
+			goal := common.State{
+				Intent: common.Intent{
+					Key:        "default/my-objective",
+					Priority:   1.0,
+					Objectives: map[string]float64{"p99latency": 50},
+				},
+			}
+			profiles := map[string]common.Profile{"p99latency": {ProfileType: common.ProfileTypeFromText("latency"), Minimize: true}}
+			testCase.planner = testCase.plannerCrt(testCase.fixture)
+			testCase.stubs = testCase.stubsCrt(testCase.fixture)
+
+			// another replica does not fit the node.
+			res := testCase.planner.CreatePlan(start, goal, profiles)
+			if len(res) != 0 {
+				t.Errorf("Expected no plan - got: %v.", res)
+			}
+
+			// enough free CPU.
+			start.Nodes["node0"] = common.NodeCapacity{AllocatableCPU: 3000, RequestedCPU: 2000, FreeCPU: 1000}
+			res, steps := testCase.planner.CreatePredictedPlan(start, goal, profiles)
+			if len(res) != 1 || res[0].Name != "set_replicas" {
+				t.Errorf("Expected to scale out - got: %v.", res)
+			}
+			if len(steps) != 1 || steps[0].Nodes["node0"].FreeCPU != 0 || steps[0].Headroom.RequestedCPU != 3000 {
+				t.Errorf("Expected the new replica to take the free CPU - got: %v.", steps)
+			}
+		})
+		testCase.stop()
+		testCase.planner.Stop()
+	}
+}
+
+// TestActuatorFilterForSanity tests for sanity.
+func TestActuatorFilterForSanity(t *testing.T) {
+	testCases := getPlannerTestCases(false)
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			start := common.State{
+				Intent: common.Intent{
+					Key:        "default/my-objective",
+					TargetKey:  "default/my-deployment",
+					TargetKind: "Deployment",
+					Objectives: map[string]float64{"p99latency": 150},
+				},
+				CurrentPods: map[string]common.PodState{"pod_0": {Availability: 1.0}, "pod_1": {Availability: 1.0}},
+				CurrentData: map[string]map[string]float64{},
+			}
+			goal := common.State{
+				Intent: common.Intent{
+					Key:        "default/my-objective",
+					Priority:   1.0,
+					Objectives: map[string]float64{"p99latency": 50},
+				},
+			}
+			profiles := map[string]common.Profile{"p99latency": {ProfileType: common.ProfileTypeFromText("latency"), Minimize: true}}
+			testCase.planner = testCase.plannerCrt(testCase.fixture)
+			testCase.stubs = testCase.stubsCrt(testCase.fixture)
+
+			// only removing PODs is allowed - which will not get us to the goal.
+			goal.Intent.AllowedActuators = []string{"rm_pod"}
+			res := testCase.planner.CreatePlan(start, goal, profiles)
+			if len(res) != 0 {
+				t.Errorf("Expected no plan - got: %v.", res)
+			}
+
+			// whole group is denied.
+			goal.Intent.AllowedActuators = nil
+			goal.Intent.DeniedActuators = []string{"scaling"}
+			res = testCase.planner.CreatePlan(start, goal, profiles)
+			if len(res) != 0 {
+				t.Errorf("Expected no plan - got: %v.", res)
+			}
+
+			// group is allowed, but removing PODs is denied.
+			goal.Intent.AllowedActuators = []string{"scaling"}
+			goal.Intent.DeniedActuators = []string{"rm_pod"}
+			res = testCase.planner.CreatePlan(start, goal, profiles)
+			if len(res) != 1 || res[0].Name != "set_replicas" {
+				t.Errorf("Expected to scale out - got: %v.", res)
+			}
+
+			// actuators cooling down are not used.
+			goal.Intent.DeniedActuators = nil
+			goal.Intent.Cooldowns = map[string]time.Time{"scaling": time.Now().Add(time.Minute)}
+			res = testCase.planner.CreatePlan(start, goal, profiles)
+			if len(res) != 0 {
+				t.Errorf("Expected no plan - got: %v.", res)
+			}
+
+			// denied actuators should not perform.
+			start.Intent.DeniedActuators = []string{"scaling"}
+			testCase.planner.ExecutePlan(start, []planner.Action{{Name: "rm_pod", Properties: nil}})
+			time.Sleep(timeout * time.Millisecond)
+			testCase.fixture.waitGroup.Wait()
+			if len(testCase.fixture.triggeredUpdates) != 0 {
+				t.Errorf("Expected no action to be performed - got: %v.", testCase.fixture.triggeredUpdates)
+			}
+
+			// ... neither should actuators cooling down.
+			start.Intent.DeniedActuators = nil
+			start.Intent.Cooldowns = map[string]time.Time{"scaling": {}}
+			for _, result := range testCase.planner.ExecutePlan(start, []planner.Action{{Name: "rm_pod", Properties: nil}}) {
+				if result.Group == "scaling" && (result.Status != planner.ResultSkipped || result.Reason != "actuator cooling down") {
+					t.Errorf("Expected actuator to be skipped - got: %v.", result)
+				}
+			}
+		})
+		testCase.stop()
+		testCase.planner.Stop()
+	}
+}
+
+// TestRetainUninfluencedForSanity tests for sanity.
+func TestRetainUninfluencedForSanity(t *testing.T) {
+	profiles := map[string]common.Profile{
+		"p99latency": {ProfileType: common.Latency, Minimize: true},
+		"errors":     {ProfileType: common.ErrorRate, Minimize: true},
+	}
+	current := common.State{Intent: common.Intent{Objectives: map[string]float64{"p99latency": 100, "errors": 0.1}}}
+
+	// actuators which declare the types they influence can only change those.
+	candidate := current.DeepCopy()
+	candidate.Intent.Objectives["p99latency"] = 50
+	candidate.Intent.Objectives["errors"] = 0.5
+	retainUninfluenced(latencyAction{}, &current, &candidate, profiles)
+	if candidate.Intent.Objectives["p99latency"] != 50 || candidate.Intent.Objectives["errors"] != 0.1 {
+		t.Errorf("Expected error rate to be retained - got: %v.", candidate.Intent.Objectives)
+	}
+
+	// actuators which do not declare anything can change all.
+	candidate.Intent.Objectives["errors"] = 0.5
+	retainUninfluenced(rmAction{}, &current, &candidate, profiles)
+	if candidate.Intent.Objectives["p99latency"] != 50 || candidate.Intent.Objectives["errors"] != 0.5 {
+		t.Errorf("Expected all objectives to be changed - got: %v.", candidate.Intent.Objectives)
+	}
+}
+
 // TestShortCutForSanity tests for sanity.
 func TestShortCutForSanity(t *testing.T) {
 	testCases := getPlannerTestCases(false)
@@ -704,7 +1060,12 @@ func TestOpportunisticPlannerForSanity(t *testing.T) {
 			profiles := map[string]common.Profile{"p99latency": {ProfileType: common.ProfileTypeFromText("latency"), Minimize: true}}
 			testCase.planner = testCase.plannerCrt(testCase.fixture)
 			testCase.stubs = testCase.stubsCrt(testCase.fixture)
+			recorder := record.NewFakeRecorder(1)
+			testCase.planner.SetEventRecorder(recorder)
 			res := testCase.planner.CreatePlan(start, goal, profiles)
+			if len(recorder.Events) != 1 || !strings.Contains(<-recorder.Events, controller.ReasonNoPathToGoal) {
+				t.Error("Expected an event stating that there is no path to the goal state.")
+			}
 			expectedActions := []string{"set_replicas"}
 			if len(res) != 1 {
 				t.Errorf("Expected at least one action - got: %d.", len(res))
@@ -720,6 +1081,52 @@ func TestOpportunisticPlannerForSanity(t *testing.T) {
 	}
 }
 
+// TestWeightedObjectivesForSanity tests for sanity.
+func TestWeightedObjectivesForSanity(t *testing.T) {
+	cfg := common.Config{Generic: common.GenericConfig{MongoEndpoint: controller.MongoURIForTesting}}
+	cfg.Planner.AStar.MaxCandidates = 10
+	cfg.Planner.AStar.MaxStates = 100
+	cfg.Planner.AStar.OpportunisticCandidates = 1
+	aPlanner := NewAPlanner([]actuators.Actuator{tradeOffAction{}}, cfg)
+	defer aPlanner.Stop()
+
+	start := common.State{Intent: common.Intent{Key: "default/my-intent", Objectives: map[string]float64{"p99latency": 150, "rps": 1000}}}
+	profiles := map[string]common.Profile{
+		"p99latency": {ProfileType: common.ProfileTypeFromText("latency"), Minimize: true},
+		"rps":        {ProfileType: common.ProfileTypeFromText("throughput"), Minimize: false},
+	}
+	// neither latency nor throughput target can be reached.
+	newGoal := func() common.State {
+		return common.State{Intent: common.Intent{Key: "goal", Objectives: map[string]float64{"p99latency": 10, "rps": 2000}}}
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(goal *common.State)
+		expected string
+	}{
+		{"unweighted", func(_ *common.State) {}, "throughput"},
+		{"weighted", func(goal *common.State) { goal.Intent.Weights = map[string]float64{"p99latency": 100} }, "latency"},
+		{"normalized", func(goal *common.State) {
+			goal.Intent.Normalizations = map[string]common.Normalization{"p99latency": common.TargetNormalization, "rps": common.TargetNormalization}
+		}, "latency"},
+		{"normalized & weighted", func(goal *common.State) {
+			goal.Intent.Normalizations = map[string]common.Normalization{"p99latency": common.TargetNormalization, "rps": common.TargetNormalization}
+			goal.Intent.Weights = map[string]float64{"rps": 5000}
+		}, "throughput"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goal := newGoal()
+			tt.modify(&goal)
+			res := aPlanner.CreatePlan(start, goal, profiles)
+			if len(res) != 1 || res[0].Properties.(map[string]string)["favor"] != tt.expected {
+				t.Errorf("Expected plan favoring %s - got: %v.", tt.expected, res)
+			}
+		})
+	}
+}
+
 // TestFaultyActuatorForSanity tests for sanity
 func TestFaultyActuatorForSanity(t *testing.T) {
 	f := newAStarPlannerFixture()
@@ -851,12 +1258,24 @@ func TestExecutePlanForSanity(t *testing.T) {
 			}
 			testCase.planner = testCase.plannerCrt(testCase.fixture)
 			testCase.stubs = testCase.stubsCrt(testCase.fixture)
-			testCase.planner.ExecutePlan(state, plan)
+			results := testCase.planner.ExecutePlan(state, plan)
 			time.Sleep(timeout * time.Millisecond)
 			testCase.fixture.waitGroup.Wait()
 			if testCase.fixture.triggeredUpdates[0] != "rm_pod" {
 				t.Errorf("Expected rm_pod action to have been called!")
 			}
+			applied := 0
+			for _, result := range results {
+				if result.Status == planner.ResultApplied {
+					applied++
+					if result.Actuator != "rm_pod" {
+						t.Errorf("Expected only rm_pod to apply - got: %v.", result)
+					}
+				}
+			}
+			if applied != 1 {
+				t.Errorf("Expected one actuator to apply the plan - got: %v.", results)
+			}
 		})
 		testCase.stop()
 		testCase.planner.Stop()
@@ -903,3 +1322,80 @@ func TestTriggerEffectForSanity(t *testing.T) {
 		testCase.planner.Stop()
 	}
 }
+
+// TestPlannerMetricsForSanity tests for sanity.
+func TestPlannerMetricsForSanity(t *testing.T) {
+	f := newAStarPlannerFixture()
+	channel := f.triggerUpdate()
+	cfg := common.Config{Generic: common.GenericConfig{MongoEndpoint: controller.MongoURIForTesting}}
+	cfg.Planner.AStar.MaxCandidates = 2
+	cfg.Planner.AStar.MaxStates = 10
+	aPlanner := NewAPlanner([]actuators.Actuator{newFaultyAction(channel)}, cfg)
+	defer aPlanner.Stop()
+
+	maxStates := sampleCount("ido_state_graph_max_states_reached_total")
+	nodes := sampleCount("ido_state_graph_nodes")
+	edges := sampleCount("ido_state_graph_edges")
+	nextStates := sampleCount("ido_next_state_duration_seconds")
+	aPlanner.CreatePlan(common.State{}, common.State{}, map[string]common.Profile{})
+	if sampleCount("ido_state_graph_max_states_reached_total") != maxStates+1 {
+		t.Errorf("Expected the max states to be reached.")
+	}
+	if sampleCount("ido_state_graph_nodes") != nodes+1 || sampleCount("ido_state_graph_edges") != edges+1 {
+		t.Errorf("Expected the size of the state graph to be observed.")
+	}
+	if sampleCount("ido_next_state_duration_seconds") <= nextStates {
+		t.Errorf("Expected the latency of the NextState calls to be observed.")
+	}
+
+	// only the actions of the actuator are counted.
+	other := f.newTestPlanner(false)
+	defer other.Stop()
+	performed := sampleCount("ido_actions_performed_total")
+	other.ExecutePlan(common.State{}, []planner.Action{{Name: "rm_pod"}, {Name: "rm_pod"}, {Name: "foo"}})
+	if res := sampleCount("ido_actions_performed_total"); res != performed+2 {
+		t.Errorf("Expected 2 actions to be performed - got: %f.", res-performed)
+	}
+	time.Sleep(timeout * time.Millisecond)
+	f.waitGroup.Wait()
+}
+
+// TestShutdownWaitsForEffectForSanity tests for sanity.
+func TestShutdownWaitsForEffectForSanity(t *testing.T) {
+	action := blockingEffectAction{started: make(chan struct{}, 1), release: make(chan struct{}), running: &atomic.Int32{}}
+	cfg := common.Config{Generic: common.GenericConfig{MongoEndpoint: controller.MongoURIForTesting}}
+	cfg.Controller = common.ControllerConfig{TaskChannelLength: 10, ControllerTimeout: 1, PlanCacheTimeout: 100, PlanCacheTTL: 10}
+	cfg.Planner.AStar.MaxCandidates = 10
+	cfg.Planner.AStar.MaxStates = 1000
+	aPlanner := NewAPlanner([]actuators.Actuator{action}, cfg)
+	defer aPlanner.Stop()
+	client := fake.NewSimpleClientset()
+	informer := informers.NewSharedInformerFactory(client, 0)
+	c := controller.NewController(cfg, nopTracer{}, client, nil, informer.Core().V1().Pods(), informer.Core().V1().Nodes())
+	c.SetPlanner(aPlanner)
+	stopChannel := make(chan struct{})
+	defer close(stopChannel)
+	c.Run(1, stopChannel)
+	c.UpdateIntent() <- common.Intent{Key: "default/my-intent", Priority: 1.0, TargetKey: "default/my-deployment", TargetKind: "Deployment", Objectives: map[string]float64{"p99latency": 150}}
+	select {
+	case <-action.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Effect calculation was not triggered.")
+	}
+
+	// shutdown waits for the effect calculation to finish.
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- c.Shutdown(context.Background())
+	}()
+	select {
+	case err := <-shutdown:
+		close(action.release)
+		t.Fatalf("Shutdown should have waited for the effect calculation - got: %v.", err)
+	case <-time.After(10 * timeout * time.Millisecond):
+	}
+	close(action.release)
+	if err := <-shutdown; err != nil || action.running.Load() != 0 {
+		t.Errorf("Expected the effect calculation to be finished - got: %v, %d running.", err, action.running.Load())
+	}
+}
@@ -58,6 +58,15 @@ func (sg *stateGraph) addEdge(src Node, trg Node, utility float64, action planne
 	sg.successors[src] = append(sg.successors[src], edge{trg, utility, action})
 }
 
+// numEdges returns the number of edges in the graph.
+func (sg *stateGraph) numEdges() int {
+	res := 0
+	for _, edges := range sg.successors {
+		res += len(edges)
+	}
+	return res
+}
+
 // toDot converts a state graph into graphviz's dot format.
 func (sg *stateGraph) toDot(highlight []Node, fileName string) error {
 	f, err := os.Create(fileName)
@@ -10,12 +10,79 @@ type Action struct {
 	Properties interface{}
 }
 
+// ResultStatus is the outcome of an actuator performing those actions of a plan it is in charge of.
+type ResultStatus string
+
+const (
+	// ResultApplied indicates that the actuator applied its actions.
+	ResultApplied ResultStatus = "applied"
+	// ResultSkipped indicates that there was nothing to do for the actuator - or it was not permitted to act.
+	ResultSkipped ResultStatus = "skipped"
+	// ResultFailed indicates that the actuator failed to apply its actions.
+	ResultFailed ResultStatus = "failed"
+)
+
+// Result holds information about the outcome of an actuator performing its part of a plan.
+type Result struct {
+	Actuator string
+	// Group of the actuator - set by the planner.
+	Group  string
+	Status ResultStatus
+	// Reason describes why the actions were skipped or failed.
+	Reason string
+	// Transient indicates that a failure is likely to go away - e.g. caused by a conflict or a timeout.
+	Transient bool
+	// Objects lists the affected objects in the form "<kind> <namespace>/<name>".
+	Objects []string
+}
+
+// PatchTypeDelete is the type of patch which indicates that an object would be deleted.
+const PatchTypeDelete = "delete"
+
+// Patch holds information about a change an actuator would apply to a Kubernetes object.
+type Patch struct {
+	// Actuator is the name of the actuator applying the change.
+	Actuator string
+	// Kind, Namespace and Name identify the object; Subresource is set if the change applies to e.g. the scale
+	// subresource.
+	Kind        string
+	Namespace   string
+	Name        string
+	Subresource string
+	// Type is the type of patch - e.g. a strategic merge patch; or PatchTypeDelete.
+	Type string
+	// Data holds the patch itself.
+	Data string
+	// Diff lists the changes to the live object in the form "<path>: <old> -> <new>".
+	Diff []string
+}
+
 // Planner represents the basic interface all planners should adhere too.
 type Planner interface {
 	// CreatePlan creates a plan based on the given current and desired state.
 	CreatePlan(current common.State, desired common.State, profiles map[string]common.Profile) []Action
-	// ExecutePlan triggers the planner to actually perform the Plan.
-	ExecutePlan(common.State, []Action)
+	// ExecutePlan triggers the planner to actually perform the Plan; returns the results of all actuators.
+	ExecutePlan(common.State, []Action) []Result
 	// TriggerEffect triggers all actuators planning actuators to (optionally) reflect on the effect of their actions.
 	TriggerEffect(current common.State, profiles map[string]common.Profile)
 }
+
+// ReadinessChecker can optionally be implemented by planners which need to be set up before they can create plans.
+type ReadinessChecker interface {
+	// Ready returns an error if the planner is not ready yet.
+	Ready() error
+}
+
+// DryRunner can optionally be implemented by planners which can determine the changes the execution of a plan would
+// apply - without applying them.
+type DryRunner interface {
+	// DryRunPlan returns the patches the actuators would apply when performing the plan.
+	DryRunPlan(state common.State, plan []Action) []Patch
+}
+
+// Predictor can optionally be implemented by planners which can tell the states they expect a plan to lead to.
+type Predictor interface {
+	// CreatePredictedPlan creates a plan like CreatePlan - and returns the state each action of the plan is predicted to
+	// lead to.
+	CreatePredictedPlan(current common.State, desired common.State, profiles map[string]common.Profile) ([]Action, []common.State)
+}
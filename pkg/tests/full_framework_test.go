@@ -57,7 +57,7 @@ type fileTracer struct {
 	indexerMutex *sync.RWMutex
 }
 
-func (t fileTracer) TraceEvent(_ common.State, desired common.State, plan []planner.Action) {
+func (t fileTracer) TraceEvent(_ common.State, desired common.State, plan []planner.Action, _ []planner.Result) {
 	t.ch <- planEvent{
 		plan: plan,
 		name: desired.Intent.Key,
@@ -318,7 +318,7 @@ func (f *testFixture) newTestSetup(env testEnvironment, stopper chan struct{}) c
 	defer plnr.Stop()
 
 	// intent controller...
-	ctlr := controller.NewController(*env.defaults, f.tracer, f.k8sClient, f.k8sInformer.Core().V1().Pods())
+	ctlr := controller.NewController(*env.defaults, f.tracer, f.k8sClient, f.intentClient, f.k8sInformer.Core().V1().Pods(), f.k8sInformer.Core().V1().Nodes())
 	ctlr.SetPlanner(plnr)
 	go ctlr.Run(1, stopper)
 
@@ -327,7 +327,7 @@ func (f *testFixture) newTestSetup(env testEnvironment, stopper chan struct{}) c
 	go profileMonitor.Run(1, stopper)
 
 	// intent monitor...
-	intentMonitor := controller.NewIntentMonitor(f.intentClient, f.intentInformer.Ido().V1alpha1().Intents(), ctlr.UpdateIntent())
+	intentMonitor := controller.NewIntentMonitor(env.defaults.Monitor, f.intentClient, f.k8sClient, f.intentInformer.Ido().V1alpha1().Intents(), ctlr.UpdateIntent())
 	go intentMonitor.Run(1, stopper)
 
 	// pod monitor...
@@ -576,11 +576,8 @@ func (f *testFixture) setupIntent(name string, objectives map[string]float64) {
 			Namespace: tmp[0],
 		},
 		Spec: v1alpha1.IntentSpec{
-			Priority: 1.0,
-			TargetRef: v1alpha1.TargetRef(struct {
-				Kind string
-				Name string
-			}{Kind: "Deployment", Name: "default/function-deployment"}),
+			Priority:  1.0,
+			TargetRef: v1alpha1.TargetRef{Kind: "Deployment", Name: "default/function-deployment"},
 		},
 	}
 	for key, val := range objectives {
@@ -20,7 +20,7 @@ import (
 // dummyTracer allows us to control what information we give to the actuator.
 type dummyTracer struct{}
 
-func (d dummyTracer) TraceEvent(_ common.State, _ common.State, _ []planner.Action) {
+func (d dummyTracer) TraceEvent(_ common.State, _ common.State, _ []planner.Action, _ []planner.Result) {
 	klog.Fatal("implement me")
 }
 
@@ -72,7 +72,7 @@ func startScaleOutPlugin(tracer controller.Tracer, port, endpoint int) chan os.S
 		MaxPods: 128,
 	}
 	actuator0 := scaling.NewScaleOutActuator(nil, tracer, scaleCfg)
-	stopper := pluginsHelper.StartActuatorPlugin(actuator0, "localhost", port, "localhost", endpoint)
+	stopper, _ := pluginsHelper.StartActuatorPlugin(actuator0, "localhost", port, "localhost", endpoint, "")
 	return stopper
 }
 
@@ -82,7 +82,7 @@ func startRemovePodPlugin(tracer controller.Tracer, port, endpoint int) chan os.
 		MinPods:  1,
 	}
 	actuator1 := scaling.NewRmPodActuator(nil, tracer, rmPodCfg)
-	stopper := pluginsHelper.StartActuatorPlugin(actuator1, "localhost", port, "localhost", endpoint)
+	stopper, _ := pluginsHelper.StartActuatorPlugin(actuator1, "localhost", port, "localhost", endpoint, "")
 	return stopper
 }
 
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -8,7 +9,6 @@ import (
 	"github.com/intel/intent-driven-orchestration/pkg/controller"
 	"github.com/intel/intent-driven-orchestration/pkg/planner/actuators/energy"
 	pluginsHelper "github.com/intel/intent-driven-orchestration/plugins"
-	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/intel/intent-driven-orchestration/pkg/common"
@@ -52,7 +52,7 @@ func main() {
 	if err != nil {
 		klog.Fatalf("Error getting Kubernetes config: %s", err)
 	}
-	clusterClient, err := kubernetes.NewForConfig(config)
+	clusterClient, err := controller.NewTargetClientForConfig(config)
 	if err != nil {
 		klog.Fatalf("Error creating Kubernetes cluster client: %s", err)
 	}
@@ -60,8 +60,14 @@ func main() {
 	// once configuration is ready & valid start the plugin mechanism.
 	mt := controller.NewMongoTracer(cfg.MongoEndpoint)
 	actuator := energy.NewPowerActuator(clusterClient, mt, *cfg)
-	signal := pluginsHelper.StartActuatorPlugin(actuator, cfg.Endpoint, cfg.Port, cfg.PluginManagerEndpoint, cfg.PluginManagerPort)
-	<-signal
+	recorder, stopRecorder := controller.NewEventRecorder(clusterClient, actuator.Name())
+	defer stopRecorder()
+	actuator.SetEventRecorder(recorder)
+	_, done := pluginsHelper.StartActuatorPlugin(actuator, cfg.Endpoint, cfg.Port, cfg.PluginManagerEndpoint, cfg.PluginManagerPort, cfg.ProbesAddress)
+	<-done
+	if err := mt.Close(context.Background()); err != nil {
+		klog.Errorf("Error closing the tracer: %s", err)
+	}
 }
 
 func isValidConf(interpreter, analytics, prediction string, stepDown int, renewableLimit float64, profiles []string) error {
@@ -1,22 +1,45 @@
 package pluginshelper
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	plugins "github.com/intel/intent-driven-orchestration/pkg/api/plugins/v1alpha1"
+	"github.com/intel/intent-driven-orchestration/pkg/common"
 	"github.com/intel/intent-driven-orchestration/pkg/planner/actuators"
 	"k8s.io/klog/v2"
 )
 
-// StartActuatorPlugin starts the necessary Stubs and registers the plugin with the plugin manager.
-func StartActuatorPlugin(actuator actuators.Actuator, endpoint string, port int, serverEndpoint string, serverPort int) chan os.Signal {
+// registrationPeriod defines how often plugins register again with the plugin manager - so that a newly elected leader
+// of the planner learns about them after a failover.
+const registrationPeriod = 30 * time.Second
+
+// shutdownTimeout defines how long in-flight calls are given to finish when the plugin is shut down.
+const shutdownTimeout = 20 * time.Second
+
+// StartActuatorPlugin starts the necessary Stubs and registers the plugin with the plugin manager. If a probes address
+// is given, /healthz and /readyz are served on it. On SIGTERM (or when a signal is sent on the returned channel) the
+// plugin deregisters & shuts down; the returned done channel is closed once that has happened.
+func StartActuatorPlugin(actuator actuators.Actuator, endpoint string, port int, serverEndpoint string, serverPort int, probesAddress string) (chan os.Signal, <-chan struct{}) {
 	stub := plugins.NewActuatorPluginStub(actuator.Name(), endpoint, port, serverEndpoint, serverPort)
+	stub.SetGroup(actuator.Group())
+	if influencer, ok := actuator.(actuators.ProfileTypeInfluencer); ok {
+		var profileTypes []string
+		for _, item := range influencer.InfluencedProfileTypes() {
+			profileTypes = append(profileTypes, item.String())
+		}
+		stub.SetProfileTypes(profileTypes)
+	}
 	stub.SetNextStateFunc(actuator.NextState)
 	stub.SetPerformFunc(actuator.Perform)
+	if dryRunner, ok := actuator.(actuators.DryRunner); ok {
+		stub.SetDryRunFunc(dryRunner.DryRun)
+	}
 	stub.SetEffectFunc(actuator.Effect)
 	err := stub.Start()
 	if err != nil {
@@ -26,16 +49,39 @@ func StartActuatorPlugin(actuator actuators.Actuator, endpoint string, port int,
 	if err != nil {
 		klog.Fatalf("Error registering plugin: %s", err)
 	}
+	stub.KeepRegistered(registrationPeriod)
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	if probesAddress != "" {
+		probes := newProbes(actuator, stub)
+		go func() {
+			if err := common.ServeProbes(probesAddress, probes, done); err != nil {
+				klog.Fatalf("Error serving probes: %s", err)
+			}
+		}()
+	}
 	go func() {
+		defer close(done)
 		<-signalChan
-		err = stub.Stop()
-		if err != nil {
-			klog.Fatalf("Error stopping plugin server: %s", err)
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := stub.Shutdown(ctx); err != nil {
+			klog.Errorf("Error shutting down plugin server: %s", err)
 		}
 	}()
-	return signalChan
+	return signalChan, done
+}
+
+// newProbes returns the probes for a plugin: it is healthy as long as the actuator is, and ready once registered with
+// the plugin manager.
+func newProbes(actuator actuators.Actuator, stub *plugins.ActuatorPluginStub) *common.Probes {
+	probes := common.NewProbes()
+	if checker, ok := actuator.(actuators.HealthChecker); ok {
+		probes.AddHealthCheck("actuator", checker.Healthy)
+	}
+	probes.AddReadinessCheck("registration", stub.Registered)
+	return probes
 }
 
 // IsValidGenericConf checks if a set of generic configuration fields are valid.
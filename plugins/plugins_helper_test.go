@@ -1,6 +1,8 @@
 package pluginshelper
 
 import (
+	"errors"
+	"net/http"
 	"syscall"
 	"testing"
 	"time"
@@ -157,8 +159,8 @@ func (d DummyActuator) NextState(_ *common.State, _ *common.State, _ map[string]
 	return nil, nil, nil
 }
 
-func (d DummyActuator) Perform(_ *common.State, _ []planner.Action) {
-
+func (d DummyActuator) Perform(_ *common.State, _ []planner.Action) planner.Result {
+	return planner.Result{}
 }
 
 func (d DummyActuator) Effect(_ *common.State, _ map[string]common.Profile) {
@@ -174,15 +176,58 @@ func TestStartActuatorPluginForSuccess(t *testing.T) {
 	}
 
 	actuator := DummyActuator{}
-	exitChannel := StartActuatorPlugin(actuator, "localhost", 3350, "localhost", 33350)
+	exitChannel, done := StartActuatorPlugin(actuator, "localhost", 3350, "localhost", 33350, "127.0.0.1:33351")
 	if err != nil {
 		t.Errorf("Error should have been nil, was: %v", err)
 	}
 	time.Sleep(250 * time.Millisecond)
+	for _, path := range []string{"/healthz", "/readyz"} {
+		response, err := http.Get("http://127.0.0.1:33351" + path)
+		if err != nil {
+			t.Fatalf("Could not probe the plugin: %v", err)
+		}
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			t.Errorf("Expected %s to succeed - got: %d.", path, response.StatusCode)
+		}
+	}
 	exitChannel <- syscall.SIGINT
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Errorf("Plugin should have been shut down.")
+	}
 
 	err = pluginManager.Stop()
 	if err != nil {
 		t.Fatalf("Could not stop plugin manager error was: %v", err)
 	}
 }
+
+// UnhealthyActuator is an actuator whose subprocess died.
+type UnhealthyActuator struct {
+	DummyActuator
+}
+
+func (u UnhealthyActuator) Healthy() error {
+	return errors.New("prediction service is gone")
+}
+
+func TestNewProbes(t *testing.T) {
+	stub := plugins.NewActuatorPluginStub("dummy", "localhost", 3352, "localhost", 33352)
+
+	// not yet registered.
+	probes := newProbes(DummyActuator{}, stub)
+	if err := probes.Healthy(); err != nil {
+		t.Errorf("Should be healthy: %v", err)
+	}
+	if err := probes.Ready(); err == nil {
+		t.Errorf("Should not be ready before registration.")
+	}
+
+	// subprocess died.
+	probes = newProbes(UnhealthyActuator{}, stub)
+	if err := probes.Healthy(); err == nil {
+		t.Errorf("Should not be healthy.")
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -11,7 +12,6 @@ import (
 
 	val "github.com/intel/intent-driven-orchestration/plugins"
 
-	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/intel/intent-driven-orchestration/pkg/common"
@@ -57,7 +57,7 @@ func main() {
 	if err != nil {
 		klog.Fatalf("Error getting Kubernetes config: %s", err)
 	}
-	clusterClient, err := kubernetes.NewForConfig(config)
+	clusterClient, err := controller.NewTargetClientForConfig(config)
 	if err != nil {
 		klog.Fatalf("Error creating Kubernetes cluster client: %s", err)
 	}
@@ -65,8 +65,14 @@ func main() {
 	// once configuration is ready & valid start the plugin mechanism.
 	mt := controller.NewMongoTracer(cfg.MongoEndpoint)
 	actuator := platform.NewRdtActuator(clusterClient, mt, *cfg)
-	signal := pluginsHelper.StartActuatorPlugin(actuator, cfg.Endpoint, cfg.Port, cfg.PluginManagerEndpoint, cfg.PluginManagerPort)
-	<-signal
+	recorder, stopRecorder := controller.NewEventRecorder(clusterClient, actuator.Name())
+	defer stopRecorder()
+	actuator.SetEventRecorder(recorder)
+	_, done := pluginsHelper.StartActuatorPlugin(actuator, cfg.Endpoint, cfg.Port, cfg.PluginManagerEndpoint, cfg.PluginManagerPort, cfg.ProbesAddress)
+	<-done
+	if err := mt.Close(context.Background()); err != nil {
+		klog.Errorf("Error closing the tracer: %s", err)
+	}
 }
 
 func isValidConf(interpreter, analyticsScript, predictionScript string, options []string) error {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 
@@ -68,8 +69,14 @@ func main() {
 	// once configuration is ready & valid start the plugin mechanism.
 	mt := controller.NewMongoTracer(cfg.MongoEndpoint)
 	actuator := scaling.NewRmPodActuator(clusterClient, mt, *cfg)
-	signal := pluginsHelper.StartActuatorPlugin(actuator, cfg.Endpoint, cfg.Port, cfg.PluginManagerEndpoint, cfg.PluginManagerPort)
-	<-signal
+	recorder, stopRecorder := controller.NewEventRecorder(clusterClient, actuator.Name())
+	defer stopRecorder()
+	actuator.SetEventRecorder(recorder)
+	_, done := pluginsHelper.StartActuatorPlugin(actuator, cfg.Endpoint, cfg.Port, cfg.PluginManagerEndpoint, cfg.PluginManagerPort, cfg.ProbesAddress)
+	<-done
+	if err := mt.Close(context.Background()); err != nil {
+		klog.Errorf("Error closing the tracer: %s", err)
+	}
 }
 
 func isValidConf(confMinPods, lookBack int) error {